@@ -0,0 +1,305 @@
+package goip
+
+import (
+	"encoding/binary"
+
+	"github.com/pchchv/goip/address_error"
+)
+
+// The binary format produced by MarshalBinary is stable across versions of this library.
+// It consists of:
+//
+//   - 1 byte with the version of the format, which is currently 1
+//   - 1 byte with the type of address: 0 for the zero IPAddress with no IP version,
+//     4 for IPv4, 6 for IPv6, 48 for MAC-48 and 64 for EUI-64
+//   - the bytes of the lowest address, 4 for IPv4, 16 for IPv6, 6 for MAC-48 and 8 for EUI-64
+//   - the bytes of the highest address, of the same length
+//   - 1 byte that is 1 when there is a prefix length and 0 otherwise
+//   - when there is a prefix length, 1 byte with the prefix length
+//   - for IPv6 only, the length of the zone as an unsigned varint, as written by binary.PutUvarint, followed by the bytes of the zone
+//
+// For the zero IPAddress, nothing follows the type byte.
+// Since the values of each segment of a subnet form a range,
+// the lowest and highest addresses are sufficient to reconstruct any subnet.
+const (
+	binaryFormatVersion = 1
+
+	binaryTypeNone  = 0
+	binaryTypeIPv4  = 4
+	binaryTypeIPv6  = 6
+	binaryTypeMAC48 = 48
+	binaryTypeEUI64 = 64
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler, producing a compact binary representation of this address or subnet,
+// including any prefix length and any IPv6 zone.  The format is described alongside the implementation.
+// Use UnmarshalBinary to decode.  The binary representation is also used by encoding/gob.
+func (addr *IPAddress) MarshalBinary() ([]byte, error) {
+	addr = addr.init()
+	if addr.getIPVersion().IsIndeterminate() {
+		return []byte{binaryFormatVersion, binaryTypeNone}, nil
+	} else if addr.IsIPv4() {
+		return addr.ToIPv4().MarshalBinary()
+	}
+	return addr.ToIPv6().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, setting this address or subnet to the one represented by the given bytes,
+// which may be the binary representation of an IPv4 or IPv6 address or subnet, or of the zero IPAddress.
+// It is the inverse of MarshalBinary.
+func (addr *IPAddress) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != binaryFormatVersion {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+	}
+
+	switch data[1] {
+	case binaryTypeNone:
+		if len(data) != 2 {
+			return &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+		}
+		*addr = IPAddress{}
+	case binaryTypeIPv4:
+		var addr4 IPv4Address
+		if err := addr4.UnmarshalBinary(data); err != nil {
+			return err
+		}
+		*addr = *addr4.ToIP()
+	case binaryTypeIPv6:
+		var addr6 IPv6Address
+		if err := addr6.UnmarshalBinary(data); err != nil {
+			return err
+		}
+		*addr = *addr6.ToIP()
+	default:
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, producing a compact binary representation of this address or subnet,
+// including any prefix length.  The format is described alongside the implementation.
+// Use UnmarshalBinary to decode.  The binary representation is also used by encoding/gob.
+func (addr *IPv4Address) MarshalBinary() ([]byte, error) {
+	addr = addr.init()
+	data := make([]byte, 0, 2+2*IPv4ByteCount+2)
+	data = append(data, binaryFormatVersion, binaryTypeIPv4)
+	data = append(data, addr.Bytes()...)
+	data = append(data, addr.UpperBytes()...)
+	return appendBinaryPrefixLen(data, addr.GetPrefixLen()), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, setting this address or subnet to the one represented by the given bytes,
+// which must be the binary representation of an IPv4 address or subnet.  It is the inverse of MarshalBinary.
+func (addr *IPv4Address) UnmarshalBinary(data []byte) error {
+	lower, upper, prefLen, rest, err := readBinaryHeader(data, binaryTypeIPv4, IPv4ByteCount)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 || (prefLen != nil && prefLen.bitCount() > IPv4BitCount) {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+	}
+
+	// the prefix length is applied afterwards, so that an individual address with a zero host does not become the prefix block
+	result := NewIPv4AddressFromRange(
+		func(segmentIndex int) IPv4SegInt {
+			return IPv4SegInt(lower[segmentIndex])
+		},
+		func(segmentIndex int) IPv4SegInt {
+			return IPv4SegInt(upper[segmentIndex])
+		})
+	if prefLen != nil {
+		result = result.SetPrefixLen(prefLen.bitCount())
+	}
+	*addr = *result
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, producing a compact binary representation of this address or subnet,
+// including any prefix length and zone.  The format is described alongside the implementation.
+// Use UnmarshalBinary to decode.  The binary representation is also used by encoding/gob.
+func (addr *IPv6Address) MarshalBinary() ([]byte, error) {
+	addr = addr.init()
+	zone := string(addr.zone)
+	data := make([]byte, 0, 2+2*IPv6ByteCount+2+binary.MaxVarintLen64+len(zone))
+	data = append(data, binaryFormatVersion, binaryTypeIPv6)
+	data = append(data, addr.Bytes()...)
+	data = append(data, addr.UpperBytes()...)
+	data = appendBinaryPrefixLen(data, addr.GetPrefixLen())
+	data = binary.AppendUvarint(data, uint64(len(zone)))
+	return append(data, zone...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, setting this address or subnet to the one represented by the given bytes,
+// which must be the binary representation of an IPv6 address or subnet.  It is the inverse of MarshalBinary.
+func (addr *IPv6Address) UnmarshalBinary(data []byte) error {
+	lower, upper, prefLen, rest, err := readBinaryHeader(data, binaryTypeIPv6, IPv6ByteCount)
+	if err != nil {
+		return err
+	} else if prefLen != nil && prefLen.bitCount() > IPv6BitCount {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+	}
+
+	zoneLen, n := binary.Uvarint(rest)
+	if n <= 0 || uint64(len(rest)-n) != zoneLen {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+	}
+
+	result := NewIPv6AddressFromZonedRange(
+		func(segmentIndex int) IPv6SegInt {
+			return IPv6SegInt(binary.BigEndian.Uint16(lower[segmentIndex<<1:]))
+		},
+		func(segmentIndex int) IPv6SegInt {
+			return IPv6SegInt(binary.BigEndian.Uint16(upper[segmentIndex<<1:]))
+		},
+		string(rest[n:]))
+	if prefLen != nil {
+		result = result.SetPrefixLen(prefLen.bitCount())
+	}
+	*addr = *result
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, producing a compact binary representation of this address or address collection,
+// including any prefix length.  The format is described alongside the implementation.
+// Use UnmarshalBinary to decode.  The binary representation is also used by encoding/gob.
+func (addr *MACAddress) MarshalBinary() ([]byte, error) {
+	addr = addr.init()
+	addrType := byte(binaryTypeMAC48)
+	if addr.GetSegmentCount() == ExtendedUniqueIdentifier64SegmentCount {
+		addrType = binaryTypeEUI64
+	}
+
+	data := make([]byte, 0, 2+2*addr.GetByteCount()+2)
+	data = append(data, binaryFormatVersion, addrType)
+	data = append(data, addr.Bytes()...)
+	data = append(data, addr.UpperBytes()...)
+	return appendBinaryPrefixLen(data, addr.GetPrefixLen()), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, setting this address or address collection to the one represented by the given bytes,
+// which must be the binary representation of a MAC address or address collection.  It is the inverse of MarshalBinary.
+func (addr *MACAddress) UnmarshalBinary(data []byte) error {
+	addrType, byteCount := byte(binaryTypeMAC48), MediaAccessControlSegmentCount
+	if len(data) > 1 && data[1] == binaryTypeEUI64 {
+		addrType, byteCount = binaryTypeEUI64, ExtendedUniqueIdentifier64SegmentCount
+	}
+
+	lower, upper, prefLen, rest, err := readBinaryHeader(data, addrType, byteCount)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 || (prefLen != nil && prefLen.bitCount() > BitCount(byteCount)*MACBitsPerSegment) {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+	}
+
+	result := NewMACAddressFromRangeExt(
+		func(segmentIndex int) MACSegInt {
+			return MACSegInt(lower[segmentIndex])
+		},
+		func(segmentIndex int) MACSegInt {
+			return MACSegInt(upper[segmentIndex])
+		},
+		addrType == binaryTypeEUI64)
+	if prefLen != nil {
+		result = result.SetPrefixLen(prefLen.bitCount())
+	}
+	*addr = *result
+	return nil
+}
+
+func appendBinaryPrefixLen(data []byte, prefLen PrefixLen) []byte {
+	if prefLen == nil {
+		return append(data, 0)
+	}
+	return append(data, 1, byte(prefLen.bitCount()))
+}
+
+// readBinaryHeader reads the version, type, lower and upper bytes, and prefix length of the binary representation of an address,
+// returning the remaining bytes.
+func readBinaryHeader(data []byte, addrType byte, byteCount int) (lower, upper []byte, prefLen PrefixLen, rest []byte, err address_error.AddressValueError) {
+	headerLen := 2 + 2*byteCount + 1
+	if len(data) < headerLen || data[0] != binaryFormatVersion || data[1] != addrType {
+		err = &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+		return
+	}
+
+	lower, upper = data[2:2+byteCount], data[2+byteCount:2+2*byteCount]
+	rest = data[headerLen:]
+	switch data[headerLen-1] {
+	case 0:
+	case 1:
+		if len(rest) == 0 {
+			err = &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+			return
+		}
+		prefLen = cacheBitCount(BitCount(rest[0]))
+		rest = rest[1:]
+	default:
+		err = &addressValueError{addressError: addressError{key: "ipaddress.error.binary.format"}}
+	}
+	return
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, producing the compact prefix tree encoding of this trie
+// produced by Marshal, in which each node holds only those prefix bits not shared with its parent node.  Use UnmarshalBinary to decode.
+func (trie *Trie[T]) MarshalBinary() ([]byte, error) {
+	return trie.Marshal()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, replacing the contents of this trie with the elements of the given binary representation,
+// as produced by Marshal or MarshalBinary.  The resulting trie is the same as the trie obtained by adding each of the elements.
+//
+// An error is returned if the data is malformed, if the format version is not supported,
+// or if the addresses of the data are not of the key type of this trie.  In that case this trie is unchanged.
+func (trie *Trie[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	}
+
+	var bitCount BitCount
+	var creator func(bytes []byte, prefLen PrefixLen) (*Address, address_error.AddressValueError)
+	switch data[1] {
+	case trieFormatEmpty:
+	case trieFormatIPv4:
+		bitCount = IPv4BitCount
+		creator = func(bytes []byte, prefLen PrefixLen) (*Address, address_error.AddressValueError) {
+			addr, err := NewIPv4AddressFromPrefixedBytes(bytes, prefLen)
+			return addr.ToAddressBase(), err
+		}
+	case trieFormatIPv6:
+		bitCount = IPv6BitCount
+		creator = func(bytes []byte, prefLen PrefixLen) (*Address, address_error.AddressValueError) {
+			addr, err := NewIPv6AddressFromPrefixedBytes(bytes, prefLen)
+			return addr.ToAddressBase(), err
+		}
+	case trieFormatMAC, trieFormatMACExtended:
+		bitCount = MediaAccessControlSegmentCount * MACBitsPerSegment
+		if data[1] == trieFormatMACExtended {
+			bitCount = ExtendedUniqueIdentifier64SegmentCount * MACBitsPerSegment
+		}
+		creator = func(bytes []byte, prefLen PrefixLen) (*Address, address_error.AddressValueError) {
+			addr, err := NewMACAddressFromBytes(bytes)
+			if err == nil && prefLen != nil {
+				addr = addr.ToPrefixBlockLen(prefLen.bitCount())
+			}
+			return addr.ToAddressBase(), err
+		}
+	default:
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	}
+
+	res, err := unmarshalTrie(data, data[1], bitCount,
+		func(bytes []byte, prefLen PrefixLen) (key T, err address_error.AddressValueError) {
+			var addr *Address
+			if addr, err = creator(bytes, prefLen); err == nil {
+				var zero T
+				if key = addressToTrieKey[T](addr); key == zero { // the address is not of the key type
+					err = &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+				}
+			}
+			return
+		})
+	if err != nil {
+		return err
+	}
+	*trie = *res
+	return nil
+}
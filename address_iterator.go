@@ -55,6 +55,76 @@ func (it *singleIterator[T]) Next() (res T) {
 	return
 }
 
+type prefixBlockLenIterator[T interface{ ToPrefixBlockLen(BitCount) T }] struct {
+	original          T
+	prefLen, bitCount BitCount
+}
+
+func (it *prefixBlockLenIterator[T]) HasNext() bool {
+	return it.prefLen <= it.bitCount
+}
+
+func (it *prefixBlockLenIterator[T]) Next() (res T) {
+	if it.HasNext() {
+		res = it.original.ToPrefixBlockLen(it.prefLen)
+		it.prefLen++
+	}
+	return
+}
+
+// supernetIterator iterates through the prefix blocks of decreasing prefix length, from the starting prefix length down to zero.
+type supernetIterator[T interface{ ToPrefixBlockLen(BitCount) T }] struct {
+	original T
+	prefLen  BitCount
+}
+
+func (it *supernetIterator[T]) HasNext() bool {
+	return it.prefLen >= 0
+}
+
+func (it *supernetIterator[T]) Next() (res T) {
+	if it.HasNext() {
+		res = it.original.ToPrefixBlockLen(it.prefLen)
+		it.prefLen--
+	}
+	return
+}
+
+// innerIterator iterates through the elements of the wrapped iterator, excluding the first and the last.
+type innerIterator[T any] struct {
+	iter    Iterator[T]
+	next    T
+	hasNext bool
+}
+
+func newInnerIterator[T any](iter Iterator[T]) *innerIterator[T] {
+	res := &innerIterator[T]{iter: iter}
+	if iter.HasNext() {
+		iter.Next() // skip the first
+		res.advance()
+	}
+	return res
+}
+
+func (it *innerIterator[T]) advance() {
+	if it.hasNext = it.iter.HasNext(); it.hasNext {
+		it.next = it.iter.Next()
+		it.hasNext = it.iter.HasNext() // the element is excluded if it is the last
+	}
+}
+
+func (it *innerIterator[T]) HasNext() bool {
+	return it.hasNext
+}
+
+func (it *innerIterator[T]) Next() (res T) {
+	if it.hasNext {
+		res = it.next
+		it.advance()
+	}
+	return
+}
+
 type multiAddrIterator struct {
 	Iterator[*AddressSection]
 	zone Zone
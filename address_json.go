@@ -0,0 +1,367 @@
+package goip
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/pchchv/goip/address_error"
+)
+
+// seqRangeJSON is the JSON representation of a sequential range.
+type seqRangeJSON struct {
+	Lower string `json:"lower"`
+	Upper string `json:"upper"`
+}
+
+// MarshalJSON implements json.Marshaler, producing a JSON string with the canonical string of this address or subnet,
+// as returned by ToCanonicalString, such as "1.2.0.0/16" or "fe80::1%eth0".
+// The zero IPAddress, which has no IP version, is marshalled as an empty string, and a nil address as null.
+func (addr *IPAddress) MarshalJSON() ([]byte, error) {
+	if addr == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(addr.ToCanonicalString())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, setting this address or subnet to the one represented by the given JSON string,
+// which is parsed as with NewIPAddressString.  It is the inverse of MarshalJSON.
+// JSON null and the empty string result in the zero IPAddress.
+func (addr *IPAddress) UnmarshalJSON(data []byte) error {
+	str, isNull, err := unmarshalJSONString(data)
+	if err != nil {
+		return err
+	} else if isNull {
+		*addr = IPAddress{}
+		return nil
+	}
+	return addr.UnmarshalText([]byte(str))
+}
+
+// MarshalJSON implements json.Marshaler, producing a JSON string with the canonical string of this address or subnet,
+// as returned by ToCanonicalString, such as "1.2.0.0/16".  A nil address is marshalled as null.
+func (addr *IPv4Address) MarshalJSON() ([]byte, error) {
+	if addr == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(addr.ToCanonicalString())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, setting this address or subnet to the one represented by the given JSON string,
+// which is parsed as with NewIPAddressString and must be IPv4.  It is the inverse of MarshalJSON.
+// JSON null results in the zero IPv4Address, which is "0.0.0.0".
+func (addr *IPv4Address) UnmarshalJSON(data []byte) error {
+	str, isNull, err := unmarshalJSONString(data)
+	if err != nil {
+		return err
+	} else if isNull {
+		*addr = IPv4Address{}
+		return nil
+	}
+	return addr.UnmarshalText([]byte(str))
+}
+
+// MarshalJSON implements json.Marshaler, producing a JSON string with the canonical string of this address or subnet,
+// as returned by ToCanonicalString, such as "1:2::/32" or "fe80::1%eth0".  A nil address is marshalled as null.
+func (addr *IPv6Address) MarshalJSON() ([]byte, error) {
+	if addr == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(addr.ToCanonicalString())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, setting this address or subnet to the one represented by the given JSON string,
+// which is parsed as with NewIPAddressString and must be IPv6.  It is the inverse of MarshalJSON.
+// JSON null results in the zero IPv6Address, which is "::".
+func (addr *IPv6Address) UnmarshalJSON(data []byte) error {
+	str, isNull, err := unmarshalJSONString(data)
+	if err != nil {
+		return err
+	} else if isNull {
+		*addr = IPv6Address{}
+		return nil
+	}
+	return addr.UnmarshalText([]byte(str))
+}
+
+// MarshalJSON implements json.Marshaler, producing a JSON string with the canonical string of this address or address collection,
+// as returned by ToCanonicalString, such as "01-02-03-04-05-06" or "01-02-03-*-*-*".  A nil address is marshalled as null.
+func (addr *MACAddress) MarshalJSON() ([]byte, error) {
+	if addr == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(addr.ToCanonicalString())
+}
+
+// UnmarshalJSON implements json.Unmarshaler, setting this address or address collection to the one represented by the given JSON string,
+// which is parsed as with NewMACAddressString.  It is the inverse of MarshalJSON.
+// JSON null results in the zero MACAddress.
+func (addr *MACAddress) UnmarshalJSON(data []byte) error {
+	str, isNull, err := unmarshalJSONString(data)
+	if err != nil {
+		return err
+	} else if isNull {
+		*addr = MACAddress{}
+		return nil
+	}
+	return addr.UnmarshalText([]byte(str))
+}
+
+// MarshalJSON implements json.Marshaler, producing a JSON object with the canonical strings of the lower and upper addresses of this range,
+// such as {"lower":"1.2.3.4","upper":"1.2.4.0"}.  A nil range is marshalled as null.
+func (rng *SequentialRange[T]) MarshalJSON() ([]byte, error) {
+	if rng == nil {
+		return []byte("null"), nil
+	}
+
+	rng = rng.init()
+	return json.Marshal(seqRangeJSON{
+		Lower: rng.GetLower().ToCanonicalString(),
+		Upper: rng.GetUpper().ToCanonicalString(),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, setting this range to the range represented by the given JSON object,
+// which has the lower and upper addresses of the range as the "lower" and "upper" strings, the inverse of MarshalJSON.
+// JSON null, and empty strings for both addresses, result in the zero range.
+//
+// An error is returned if either address is invalid, or the two addresses do not have the IP version of the range type.
+// When the range type is IPAddressSeqRange, the two addresses must have the same IP version.
+func (rng *SequentialRange[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		*rng = SequentialRange[T]{}
+		return nil
+	}
+
+	var vals seqRangeJSON
+	if err := json.Unmarshal(data, &vals); err != nil {
+		return err
+	}
+
+	lower, err := parseJSONRangeAddress[T](vals.Lower)
+	if err != nil {
+		return err
+	}
+
+	upper, err := parseJSONRangeAddress[T](vals.Upper)
+	if err != nil {
+		return err
+	}
+
+	result := NewSequentialRange(lower, upper)
+	if result == nil {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.ipVersionMismatch"}}
+	}
+	*rng = *result
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, producing a JSON array with the canonical strings of the added elements of this trie, in sorted trie order,
+// such as ["1.2.0.0/16","1.2.3.4","5.0.0.0/8"].  An empty or nil trie is marshalled as an empty array.
+func (trie *Trie[T]) MarshalJSON() ([]byte, error) {
+	strs := []string{}
+	if trie != nil {
+		for iter := trie.Iterator(); iter.HasNext(); {
+			strs = append(strs, iter.Next().toAddressBase().ToCanonicalString())
+		}
+	}
+	return json.Marshal(strs)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of this trie with the elements of the given JSON array of address strings,
+// the inverse of MarshalJSON.  The resulting trie is the same as the trie obtained by adding each of the addresses.
+// JSON null results in an empty trie.
+//
+// An error is returned if any string is not a valid address of the key type of this trie, or is neither an individual address nor a prefix block.
+// In that case this trie is unchanged.
+func (trie *Trie[T]) UnmarshalJSON(data []byte) error {
+	var strs []string
+	if err := json.Unmarshal(data, &strs); err != nil {
+		return err
+	}
+
+	res := &Trie[T]{}
+	for _, str := range strs {
+		addr, err := parseJSONTrieKey[T](str)
+		if err != nil {
+			return err
+		}
+		res.Add(addr)
+	}
+	*trie = *res
+	return nil
+}
+
+// trieEntryJSON is the JSON representation of an added element of an associative trie along with its value.
+type trieEntryJSON struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// MarshalJSON implements json.Marshaler, producing a JSON array with an object for each added element of this trie, in sorted trie order.
+// Each object has the canonical string of the element as "key" and the JSON encoding of the associated value as "value",
+// such as [{"key":"1.2.0.0/16","value":1},{"key":"1.2.3.4","value":2}].
+// An empty or nil trie is marshalled as an empty array.
+//
+// The values are encoded with json.Marshal, so an error is returned if any value cannot be encoded.
+func (trie *AssociativeTrie[T, V]) MarshalJSON() ([]byte, error) {
+	entries := []trieEntryJSON{}
+	if trie != nil {
+		for iter := trie.NodeIterator(true); iter.HasNext(); {
+			node := iter.Next()
+			value, err := json.Marshal(node.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, trieEntryJSON{Key: node.GetKey().toAddressBase().ToCanonicalString(), Value: value})
+		}
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, replacing the contents of this trie with the elements and values of the given JSON array,
+// the inverse of MarshalJSON.  The values are decoded with json.Unmarshal.
+// JSON null results in an empty trie.
+//
+// An error is returned if any key is not a valid address of the key type of this trie, or is neither an individual address nor a prefix block,
+// or if any value cannot be decoded.  In that case this trie is unchanged.
+func (trie *AssociativeTrie[T, V]) UnmarshalJSON(data []byte) error {
+	var entries []trieEntryJSON
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	res := &AssociativeTrie[T, V]{}
+	for _, entry := range entries {
+		addr, err := parseJSONTrieKey[T](entry.Key)
+		if err != nil {
+			return err
+		}
+
+		var value V
+		if err := json.Unmarshal(entry.Value, &value); err != nil {
+			return err
+		}
+		res.Put(addr, value)
+	}
+	*trie = *res
+	return nil
+}
+
+// unmarshalJSONString returns the string value of the given JSON, or whether the JSON is null.
+func unmarshalJSONString(data []byte) (str string, isNull bool, err error) {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return "", true, nil
+	}
+	err = json.Unmarshal(data, &str)
+	return
+}
+
+// parseIPAddressOfVersion parses the given string to an address of the given version,
+// or to an address of either version when the version is indeterminate.
+func parseIPAddressOfVersion(str string, version IPVersion) (result *IPAddress, err address_error.AddressError) {
+	addrStr := NewIPAddressString(str)
+	if version.IsIndeterminate() {
+		result, err = addrStr.ToAddress()
+	} else if err = addrStr.ValidateVersion(version); err == nil {
+		result, err = addrStr.ToVersionedAddress(version)
+	}
+
+	if err == nil && result == nil { // the string represents all addresses of either version
+		err = &addressStringError{addressError{str: str, key: "ipaddress.error.ipVersionIndeterminate"}}
+	}
+	return
+}
+
+// parseJSONRangeAddress parses the given string to an address of the type of the range bounds.
+// The empty string, used for the bounds of the zero range, results in nil.
+func parseJSONRangeAddress[T SequentialRangeConstraint[T]](str string) (t T, err address_error.AddressError) {
+	if str == "" {
+		return
+	}
+
+	var version IPVersion
+	switch any(t).(type) {
+	case *IPv4Address:
+		version = IPv4
+	case *IPv6Address:
+		version = IPv6
+	}
+
+	addr, err := parseIPAddressOfVersion(str, version)
+	if err != nil {
+		return
+	}
+
+	switch any(t).(type) {
+	case *IPv4Address:
+		t = any(addr.ToIPv4()).(T)
+	case *IPv6Address:
+		t = any(addr.ToIPv6()).(T)
+	default:
+		t = any(addr).(T)
+	}
+	return
+}
+
+// parseJSONTrieKey parses the given string to an address of the key type of a trie,
+// which must be an individual address or a prefix block.
+// For the key type *Address, the string is parsed as an IP address, or as a MAC address when it is not a valid IP address.
+// Since the canonical strings of MAC addresses use dashes, which the canonical strings of IP addresses do not,
+// a string with a dash that is a valid MAC address is parsed as a MAC address,
+// even if it is also a valid IP address, such as the base 85 IPv6 string "0a-0a-0a-0b-0c-*-*-*".
+func parseJSONTrieKey[T TrieKeyConstraint[T]](str string) (t T, err address_error.AddressError) {
+	var addr *Address
+	_, isIP := any(t).(*IPAddress)
+	switch any(t).(type) {
+	case *IPv4Address:
+		var ipAddr *IPAddress
+		if ipAddr, err = parseIPAddressOfVersion(str, IPv4); err == nil {
+			addr = ipAddr.ToAddressBase()
+		}
+	case *IPv6Address:
+		var ipAddr *IPAddress
+		if ipAddr, err = parseIPAddressOfVersion(str, IPv6); err == nil {
+			addr = ipAddr.ToAddressBase()
+		}
+	case *MACAddress:
+		addr, err = parseMACAddress(str)
+	default:
+		if !isIP && strings.IndexByte(str, MACDashSegmentSeparator) >= 0 {
+			if addr, err = parseMACAddress(str); err == nil {
+				break
+			}
+		}
+
+		var ipAddr *IPAddress
+		if ipAddr, err = parseIPAddressOfVersion(str, IndeterminateIPVersion); err == nil {
+			addr = ipAddr.ToAddressBase()
+		} else if !isIP {
+			if macAddr, macErr := parseMACAddress(str); macErr == nil {
+				addr, err = macAddr, nil
+			}
+		}
+	}
+
+	if err != nil {
+		return
+	} else if addr.IsMultiple() && !addr.IsSinglePrefixBlock() {
+		err = &addressStringError{addressError{str: str, key: "ipaddress.error.address.not.block"}}
+		return
+	}
+	t = addressToTrieKey[T](addr)
+	return
+}
+
+// parseMACAddress parses the given string to a MAC address.
+func parseMACAddress(str string) (*Address, address_error.AddressError) {
+	macAddr, err := NewMACAddressString(str).ToAddress()
+	if err != nil {
+		return nil, err
+	} else if macAddr == nil {
+		return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.empty"}}
+	}
+	return macAddr.ToAddressBase(), nil
+}
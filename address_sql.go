@@ -0,0 +1,181 @@
+package goip
+
+import (
+	"database/sql/driver"
+	"net"
+)
+
+// Value implements driver.Valuer, returning the canonical string of this address or subnet, as returned by ToCanonicalString,
+// for storage in a database.  A nil address, and the zero IPAddress with no IP version, are stored as NULL.
+//
+// The canonical string of a prefixed address, such as "192.168.1.0/24", is the text format of the PostgreSQL inet and cidr types.
+func (addr *IPAddress) Value() (driver.Value, error) {
+	if addr == nil || addr.init().getIPVersion().IsIndeterminate() {
+		return nil, nil
+	}
+	return addr.ToCanonicalString(), nil
+}
+
+// Scan implements sql.Scanner, setting this address or subnet from a database value.
+// The value may be a string or []byte, which is parsed as with NewIPAddressString,
+// including the text format of the PostgreSQL inet and cidr types, such as "192.168.1.0/24".
+// The value may also be a net.IP.  NULL results in the zero IPAddress.
+// A value that cannot be parsed results in an AddressValueError which nests the parsing error.
+func (addr *IPAddress) Scan(src interface{}) error {
+	var result *IPAddress
+	switch src := src.(type) {
+	case nil:
+		*addr = IPAddress{}
+		return nil
+	case net.IP:
+		var err error
+		if result, err = NewIPAddressFromNetIP(src); err != nil {
+			return err
+		}
+	default:
+		str, err := scanString(src)
+		if err != nil {
+			return err
+		}
+		return scanError(addr.UnmarshalText([]byte(str)))
+	}
+	*addr = *result
+	return nil
+}
+
+// Value implements driver.Valuer, returning the canonical string of this address or subnet, as returned by ToCanonicalString,
+// for storage in a database.  A nil address is stored as NULL.
+func (addr *IPv4Address) Value() (driver.Value, error) {
+	if addr == nil {
+		return nil, nil
+	}
+	return addr.ToCanonicalString(), nil
+}
+
+// Scan implements sql.Scanner, setting this address or subnet from a database value.
+// The value may be a string or []byte, which is parsed as with NewIPAddressString and must be IPv4,
+// including the text format of the PostgreSQL inet and cidr types, such as "192.168.1.0/24".
+// The value may also be a net.IP, either 4 bytes or an IPv4-mapped 16 bytes.  NULL results in the zero IPv4Address.
+// A value that cannot be parsed results in an AddressValueError which nests the parsing error.
+func (addr *IPv4Address) Scan(src interface{}) error {
+	var result *IPv4Address
+	switch src := src.(type) {
+	case nil:
+		*addr = IPv4Address{}
+		return nil
+	case net.IP:
+		ipv4 := src.To4()
+		if ipv4 == nil {
+			return &addressValueError{addressError: addressError{key: "ipaddress.error.ipv4.invalid.byte.count"}}
+		}
+
+		var err error
+		if result, err = NewIPv4AddressFromBytes(ipv4); err != nil {
+			return err
+		}
+	default:
+		str, err := scanString(src)
+		if err != nil {
+			return err
+		}
+		return scanError(addr.UnmarshalText([]byte(str)))
+	}
+	*addr = *result
+	return nil
+}
+
+// Value implements driver.Valuer, returning the canonical string of this address or subnet, as returned by ToCanonicalString,
+// for storage in a database.  A nil address is stored as NULL.
+func (addr *IPv6Address) Value() (driver.Value, error) {
+	if addr == nil {
+		return nil, nil
+	}
+	return addr.ToCanonicalString(), nil
+}
+
+// Scan implements sql.Scanner, setting this address or subnet from a database value.
+// The value may be a string or []byte, which is parsed as with NewIPAddressString and must be IPv6,
+// including the text format of the PostgreSQL inet and cidr types, such as "2001:db8::/32".
+// The value may also be a net.IP of 16 bytes.  NULL results in the zero IPv6Address.
+// A value that cannot be parsed results in an AddressValueError which nests the parsing error.
+func (addr *IPv6Address) Scan(src interface{}) error {
+	var result *IPv6Address
+	switch src := src.(type) {
+	case nil:
+		*addr = IPv6Address{}
+		return nil
+	case net.IP:
+		if len(src) != IPv6ByteCount {
+			return &addressValueError{addressError: addressError{key: "ipaddress.error.ipv6.invalid.byte.count"}}
+		}
+
+		var err error
+		if result, err = NewIPv6AddressFromBytes(src); err != nil {
+			return err
+		}
+	default:
+		str, err := scanString(src)
+		if err != nil {
+			return err
+		}
+		return scanError(addr.UnmarshalText([]byte(str)))
+	}
+	*addr = *result
+	return nil
+}
+
+// Value implements driver.Valuer, returning the canonical string of this address or address collection, as returned by ToCanonicalString,
+// for storage in a database.  A nil address is stored as NULL.
+//
+// The canonical string of a MAC address, such as "01-02-03-04-05-06", is accepted by the PostgreSQL macaddr type.
+func (addr *MACAddress) Value() (driver.Value, error) {
+	if addr == nil {
+		return nil, nil
+	}
+	return addr.ToCanonicalString(), nil
+}
+
+// Scan implements sql.Scanner, setting this address or address collection from a database value.
+// The value may be a string or []byte, which is parsed as with NewMACAddressString,
+// or a net.HardwareAddr.  NULL results in the zero MACAddress.
+// A value that cannot be parsed results in an AddressValueError which nests the parsing error.
+func (addr *MACAddress) Scan(src interface{}) error {
+	var result *MACAddress
+	switch src := src.(type) {
+	case nil:
+		*addr = MACAddress{}
+		return nil
+	case net.HardwareAddr:
+		var err error
+		if result, err = NewMACAddressFromBytes(src); err != nil {
+			return err
+		}
+	default:
+		str, err := scanString(src)
+		if err != nil {
+			return err
+		}
+		return scanError(addr.UnmarshalText([]byte(str)))
+	}
+	*addr = *result
+	return nil
+}
+
+// scanString returns the string of a database value that is a string or []byte.
+func scanString(src interface{}) (string, error) {
+	switch src := src.(type) {
+	case string:
+		return src, nil
+	case []byte:
+		return string(src), nil
+	}
+	return "", &addressValueError{addressError: addressError{key: "ipaddress.error.scan.type"}}
+}
+
+// scanError returns an AddressValueError nesting the given error from parsing a database value, or nil if there is no error.
+func scanError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &addressValueNestedError{addressValueError: addressValueError{addressError: addressError{key: "ipaddress.error.scan.parse"}}, nested: err}
+}
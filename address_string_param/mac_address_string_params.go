@@ -205,6 +205,18 @@ func (builder *MACAddressStringParamsBuilder) AllowSpaceDelimited(allow bool) *M
 	return builder
 }
 
+// SetSeparator dictates that only addresses using the given segment separator are allowed,
+// one of ':' for "aa:bb:cc:dd:ee:ff", '-' for "aa-bb-cc-dd-ee-ff" and "aabbcc-ddeeff", '.' for "aaaa.bbbb.cccc" or ' ' for "aa bb cc dd ee ff".
+// Any other separator disallows all of those formats.
+func (builder *MACAddressStringParamsBuilder) SetSeparator(separator rune) *MACAddressStringParamsBuilder {
+	builder.params.noAllowColonDelimited = separator != ':'
+	builder.params.noAllowDashed = separator != '-'
+	builder.params.noAllowSingleDashed = separator != '-'
+	builder.params.noAllowDotted = separator != '.'
+	builder.params.noAllowSpaceDelimited = separator != ' '
+	return builder
+}
+
 // AllowWildcardedSeparator dictates whether the wildcard '*' or '%' can replace the segment separators '.', '-' and ':'.
 // If so, then you can write addresses like "*.*" or "*:*".
 func (builder *MACAddressStringParamsBuilder) AllowWildcardedSeparator(allow bool) *MACAddressStringParamsBuilder {
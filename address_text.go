@@ -0,0 +1,146 @@
+package goip
+
+// MarshalText implements encoding.TextMarshaler, producing the canonical string of this address or subnet,
+// as returned by ToCanonicalString, such as "1.2.0.0/16" or "fe80::1%eth0".
+// A nil address, and the zero IPAddress with no IP version, produce empty text.
+func (addr *IPAddress) MarshalText() ([]byte, error) {
+	if addr == nil {
+		return []byte{}, nil
+	}
+	return []byte(addr.ToCanonicalString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, setting this address or subnet to the one represented by the given text,
+// which is parsed as with NewIPAddressString.  It is the inverse of MarshalText.
+// Empty text results in the zero IPAddress.
+func (addr *IPAddress) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*addr = IPAddress{}
+		return nil
+	}
+
+	result, err := parseIPAddressOfVersion(string(text), IndeterminateIPVersion)
+	if err != nil {
+		return err
+	}
+	*addr = *result
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the canonical string of this address or subnet,
+// as returned by ToCanonicalString, such as "1.2.0.0/16".  A nil address produces empty text.
+func (addr *IPv4Address) MarshalText() ([]byte, error) {
+	if addr == nil {
+		return []byte{}, nil
+	}
+	return []byte(addr.ToCanonicalString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, setting this address or subnet to the one represented by the given text,
+// which is parsed as with NewIPAddressString and must be IPv4.  It is the inverse of MarshalText.
+func (addr *IPv4Address) UnmarshalText(text []byte) error {
+	result, err := parseIPAddressOfVersion(string(text), IPv4)
+	if err != nil {
+		return err
+	}
+	*addr = *result.ToIPv4()
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the canonical string of this address or subnet,
+// as returned by ToCanonicalString, such as "1:2::/32" or "fe80::1%eth0".  A nil address produces empty text.
+func (addr *IPv6Address) MarshalText() ([]byte, error) {
+	if addr == nil {
+		return []byte{}, nil
+	}
+	return []byte(addr.ToCanonicalString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, setting this address or subnet to the one represented by the given text,
+// which is parsed as with NewIPAddressString and must be IPv6.  It is the inverse of MarshalText.
+func (addr *IPv6Address) UnmarshalText(text []byte) error {
+	result, err := parseIPAddressOfVersion(string(text), IPv6)
+	if err != nil {
+		return err
+	}
+	*addr = *result.ToIPv6()
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the canonical string of this address or address collection,
+// as returned by ToCanonicalString, such as "01-02-03-04-05-06" or "01-02-03-*-*-*".  A nil address produces empty text.
+func (addr *MACAddress) MarshalText() ([]byte, error) {
+	if addr == nil {
+		return []byte{}, nil
+	}
+	return []byte(addr.ToCanonicalString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, setting this address or address collection to the one represented by the given text,
+// which is parsed as with NewMACAddressString.  It is the inverse of MarshalText.
+func (addr *MACAddress) UnmarshalText(text []byte) error {
+	str := string(text)
+	result, err := NewMACAddressString(str).ToAddress()
+	if err != nil {
+		return err
+	} else if result == nil {
+		return &addressStringError{addressError{str: str, key: "ipaddress.error.empty"}}
+	}
+	*addr = *result
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the original string used to create this IPAddressString.
+// A nil IPAddressString produces empty text.
+func (addrStr *IPAddressString) MarshalText() ([]byte, error) {
+	if addrStr == nil {
+		return []byte{}, nil
+	}
+	return []byte(addrStr.str), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, replacing this IPAddressString with one for the given text, as with Set.
+// If the text is not a valid address string, the error is returned and this IPAddressString is unchanged.
+func (addrStr *IPAddressString) UnmarshalText(text []byte) error {
+	return addrStr.Set(string(text))
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the original string used to create this HostName.
+// A nil HostName produces empty text.
+func (host *HostName) MarshalText() ([]byte, error) {
+	if host == nil {
+		return []byte{}, nil
+	}
+	return []byte(host.str), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, replacing this HostName with one for the given text, parsed as with NewHostName.
+// If the text is not a valid host name, the error is returned and this HostName is unchanged.
+func (host *HostName) UnmarshalText(text []byte) error {
+	res := NewHostName(string(text))
+	if err := res.Validate(); err != nil {
+		return err
+	}
+	*host = *res
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the original string used to create this MACAddressString.
+// A nil MACAddressString produces empty text.
+func (addrStr *MACAddressString) MarshalText() ([]byte, error) {
+	if addrStr == nil {
+		return []byte{}, nil
+	}
+	return []byte(addrStr.str), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, replacing this MACAddressString with one for the given text, parsed as with NewMACAddressString.
+// If the text is not a valid MAC address string, the error is returned and this MACAddressString is unchanged.
+func (addrStr *MACAddressString) UnmarshalText(text []byte) error {
+	res := NewMACAddressString(string(text))
+	if err := res.Validate(); err != nil {
+		return err
+	}
+	*addrStr = *res
+	return nil
+}
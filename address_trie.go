@@ -1,7 +1,10 @@
 package goip
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math/bits"
+	"sort"
 	"unsafe"
 
 	"github.com/pchchv/goip/address_error"
@@ -19,6 +22,18 @@ type (
 	NodeValue = any
 )
 
+// the serialized trie format, see Trie.Marshal
+const (
+	trieFormatVersion     byte = 2 // the tree format
+	trieFormatFlatVersion byte = 1 // the list format, each element with its prefix length
+	trieFormatEmpty       byte = 0
+	trieFormatIPv4        byte = 4
+	trieFormatIPv6        byte = 6
+	trieFormatMAC         byte = 48
+	trieFormatMACExtended byte = 64
+	trieFormatNoPrefix    byte = 0xff
+)
+
 type trieBase[T TrieKeyConstraint[T], V any] struct {
 	trie tree.BinTrie[trieKey[T], V]
 }
@@ -516,6 +531,58 @@ func (trie *Trie[T]) NodeIterator(forward bool) IteratorWithRemove[*TrieNode[T]]
 	return addressTrieNodeIteratorRem[T, emptyValue]{trie.tobase().nodeIterator(forward)}
 }
 
+// FilteredIterator returns an iterator that iterates through the added nodes in the trie in forward trie order,
+// skipping those nodes for which the given predicate returns false.
+// The predicate is applied lazily as the iteration proceeds.
+//
+// Use PrunedIterator to also skip the sub-tries of those nodes.
+func (trie *Trie[T]) FilteredIterator(predicate func(*TrieNode[T]) bool) Iterator[*TrieNode[T]] {
+	res := &filteredTrieNodeIterator[T]{predicate: predicate, iter: trie.NodeIterator(true)}
+	res.Next()
+	return res
+}
+
+// PrunedIterator returns an iterator that iterates through the added nodes in the trie in containing-first order,
+// with lower sub-nodes before upper sub-nodes,
+// skipping those nodes for which the given predicate returns false, along with their entire sub-tries.
+// The predicate is applied lazily as the iteration proceeds, and is not applied to the nodes of skipped sub-tries.
+//
+// This is useful for large tries in which whole sub-tries can be excluded by examining their containing prefix block.
+func (trie *Trie[T]) PrunedIterator(predicate func(*TrieNode[T]) bool) Iterator[*TrieNode[T]] {
+	res := &prunedTrieNodeIterator[T]{predicate: predicate}
+	if root := trie.GetRoot(); root != nil {
+		res.stack = append(res.stack, root)
+	}
+	res.Next()
+	return res
+}
+
+// Walk visits all the nodes in the trie depth-first in containing-first order, with lower sub-nodes before upper sub-nodes,
+// calling the visitor with each node, its depth, which is zero for the root, and whether it is a leaf node with no sub-nodes.
+// Both added nodes and the non-added branching nodes of the trie are visited, use IsAdded to distinguish them.
+// When the visitor returns false, the sub-trie of that node is not visited.
+//
+// This is useful for examining the structure of the trie, such as for computing statistics or producing graphical output.
+func (trie *Trie[T]) Walk(visitor func(node *TrieNode[T], depth int, isLeaf bool) bool) {
+	if root := trie.GetRoot(); root != nil {
+		walkTrieNode(root, 0, visitor)
+	}
+}
+
+func walkTrieNode[T TrieKeyConstraint[T]](node *TrieNode[T], depth int, visitor func(node *TrieNode[T], depth int, isLeaf bool) bool) {
+	lower, upper := node.GetLowerSubNode(), node.GetUpperSubNode()
+	if !visitor(node, depth, lower == nil && upper == nil) {
+		return
+	}
+
+	if lower != nil {
+		walkTrieNode(lower, depth+1, visitor)
+	}
+	if upper != nil {
+		walkTrieNode(upper, depth+1, visitor)
+	}
+}
+
 // AllNodeIterator returns an iterator that iterates through all the nodes in the trie in forward or reverse trie order.
 func (trie *Trie[T]) AllNodeIterator(forward bool) IteratorWithRemove[*TrieNode[T]] {
 	return addressTrieNodeIteratorRem[T, emptyValue]{trie.tobase().allNodeIterator(forward)}
@@ -681,6 +748,167 @@ func (trie *Trie[T]) ShortestPrefixMatchNode(addr T) *TrieNode[T] {
 	return toAddressTrieNode[T](trie.shortestPrefixMatchNode(addr))
 }
 
+// UnionWith returns a new trie containing the added elements of both this trie and the given trie.
+// Neither trie is modified.
+//
+// The address type/version of the keys of the two tries must match.
+func (trie *Trie[T]) UnionWith(other *Trie[T]) *Trie[T] {
+	res := &Trie[T]{}
+	if trie != nil {
+		res.AddTrie(trie.GetRoot())
+	}
+	if other != nil {
+		res.AddTrie(other.GetRoot())
+	}
+	return res
+}
+
+// IntersectWith returns a new trie containing the elements added to both this trie and the given trie.
+// Neither trie is modified.
+//
+// Only elements that match exactly are included.
+// An element of one trie that is contained by a larger prefix block element of the other trie is not included,
+// use IntersectContained to include such elements.
+func (trie *Trie[T]) IntersectWith(other *Trie[T]) *Trie[T] {
+	res := &Trie[T]{}
+	if trie == nil || other == nil {
+		return res
+	}
+
+	for iter := trie.Iterator(); iter.HasNext(); {
+		if addr := iter.Next(); other.Contains(addr) {
+			res.Add(addr)
+		}
+	}
+	return res
+}
+
+// IntersectContained returns a new trie containing the addresses and prefix blocks that are contained by both this trie and the given trie,
+// the set intersection of the addresses of the two tries.
+// Neither trie is modified.
+//
+// Unlike IntersectWith, which includes only the elements added to both tries, an element of one trie that is contained
+// by a larger prefix block element of the other trie is included.
+// For instance, the intersection of a trie with "1.2.0.0/16" and a trie with "1.2.3.0/24" and "5.6.7.8" is a trie with "1.2.3.0/24",
+// while IntersectWith produces an empty trie.
+func (trie *Trie[T]) IntersectContained(other *Trie[T]) *Trie[T] {
+	res := &Trie[T]{}
+	if trie == nil || other == nil {
+		return res
+	}
+
+	// two prefix blocks are either disjoint or one contains the other,
+	// so the intersection consists of the elements of each trie contained by an element of the other
+	for iter := trie.Iterator(); iter.HasNext(); {
+		if addr := iter.Next(); other.ElementContains(addr) {
+			res.Add(addr)
+		}
+	}
+	for iter := other.Iterator(); iter.HasNext(); {
+		if addr := iter.Next(); trie.ElementContains(addr) {
+			res.Add(addr)
+		}
+	}
+	return res
+}
+
+// Marshal produces a compact binary representation of this trie,
+// which can be reconstructed with UnmarshalIPv4AddressTrie or UnmarshalIPv6AddressTrie according to the address version.
+//
+// The format starts with a format version byte, allowing for future changes to the format,
+// followed by a byte indicating the address type of the trie.
+// The nodes of the binary trie follow in pre-order as a stream of bits, padded with zero bits to a whole number of bytes.
+// Each node starts with three bits indicating whether the node is added and whether it has a lower and an upper sub-node.
+// Since the key of each node shares the prefix bits of its parent node, along with the following bit that determines the sub-node it is,
+// only the count of the remaining prefix bits follows, as an Elias gamma code of the count plus one, and then the remaining prefix bits themselves.
+// When the key has a full-length prefix, a single bit in between indicates whether it is an individual address with no prefix length.
+//
+// So a trie of densely packed addresses takes a byte or two per address, while a trie of scattered addresses takes little more than the addresses themselves.
+func (trie *Trie[T]) Marshal() ([]byte, error) {
+	bytes := []byte{trieFormatVersion, trieFormatEmpty}
+	root := trie.GetRoot()
+	if root == nil {
+		return bytes, nil
+	}
+
+	rootAddr := root.GetKey().toAddressBase()
+	if rootAddr.IsIPv4() {
+		bytes[1] = trieFormatIPv4
+	} else if rootAddr.IsIPv6() {
+		bytes[1] = trieFormatIPv6
+	} else if rootAddr.GetSegmentCount() == ExtendedUniqueIdentifier64SegmentCount {
+		bytes[1] = trieFormatMACExtended
+	} else {
+		bytes[1] = trieFormatMAC
+	}
+
+	writer := trieBitWriter{bytes: bytes}
+	writeTrieNode(&writer, root, -1)
+	return writer.bytes, nil
+}
+
+// writeTrieNode writes the tree format of the given node and its sub-nodes,
+// the parent node of the given node having the given prefix length, or -1 for the root node.
+func writeTrieNode[T TrieKeyConstraint[T]](writer *trieBitWriter, node *TrieNode[T], parentPrefLen BitCount) {
+	addr := node.GetKey().toAddressBase()
+	lower, upper := node.GetLowerSubNode(), node.GetUpperSubNode()
+	writer.writeBit(node.IsAdded())
+	writer.writeBit(lower != nil)
+	writer.writeBit(upper != nil)
+
+	bitCount := addr.GetBitCount()
+	prefLen := bitCount
+	isPrefixed := addr.IsPrefixed() && addr.IsPrefixBlock()
+	if isPrefixed {
+		prefLen = addr.GetPrefixLen().bitCount()
+	}
+
+	start := parentPrefLen + 1
+	writer.writeCount(uint(prefLen - start))
+	if prefLen == bitCount {
+		writer.writeBit(!isPrefixed)
+	}
+	key := addr.Bytes()
+	for i := start; i < prefLen; i++ {
+		writer.writeBit(key[i>>3]&(0x80>>(i&7)) != 0)
+	}
+
+	if lower != nil {
+		writeTrieNode(writer, lower, prefLen)
+	}
+	if upper != nil {
+		writeTrieNode(writer, upper, prefLen)
+	}
+}
+
+// trieBitWriter writes a stream of bits, most significant bit first.
+type trieBitWriter struct {
+	bytes     []byte
+	bitOffset uint // the count of bits written to the last byte, zero when a new byte is needed
+}
+
+func (writer *trieBitWriter) writeBit(bit bool) {
+	if writer.bitOffset == 0 {
+		writer.bytes = append(writer.bytes, 0)
+	}
+	if bit {
+		writer.bytes[len(writer.bytes)-1] |= 0x80 >> writer.bitOffset
+	}
+	writer.bitOffset = (writer.bitOffset + 1) & 7
+}
+
+// writeCount writes the Elias gamma code of count + 1, which is the bits of count + 1 preceded by one less zero bits.
+func (writer *trieBitWriter) writeCount(count uint) {
+	val := count + 1
+	bitLen := bits.Len(val)
+	for i := 1; i < bitLen; i++ {
+		writer.writeBit(false)
+	}
+	for i := bitLen - 1; i >= 0; i-- {
+		writer.writeBit(val&(1<<i) != 0)
+	}
+}
+
 // AssociativeTrie represents a binary address trie in which each added node can be associated with a value.
 // It is an instance of [Trie] that can also function as a key-value map. The keys are addresses or prefix blocks.
 // Each can be mapped to a value with type specified by the generic type V.
@@ -816,6 +1044,30 @@ func (trie *AssociativeTrie[T, V]) NodeIterator(forward bool) IteratorWithRemove
 	return associativeAddressTrieNodeIteratorRem[T, V]{trie.tobase().nodeIterator(forward)}
 }
 
+// Walk visits all the nodes in the trie depth-first in containing-first order, with lower sub-nodes before upper sub-nodes,
+// calling the visitor with each node, its depth, which is zero for the root, and whether it is a leaf node with no sub-nodes.
+// Both added nodes and the non-added branching nodes of the trie are visited, use IsAdded to distinguish them.
+// When the visitor returns false, the sub-trie of that node is not visited.
+func (trie *AssociativeTrie[T, V]) Walk(visitor func(node *AssociativeTrieNode[T, V], depth int, isLeaf bool) bool) {
+	if root := trie.GetRoot(); root != nil {
+		walkAssociativeTrieNode(root, 0, visitor)
+	}
+}
+
+func walkAssociativeTrieNode[T TrieKeyConstraint[T], V any](node *AssociativeTrieNode[T, V], depth int, visitor func(node *AssociativeTrieNode[T, V], depth int, isLeaf bool) bool) {
+	lower, upper := node.GetLowerSubNode(), node.GetUpperSubNode()
+	if !visitor(node, depth, lower == nil && upper == nil) {
+		return
+	}
+
+	if lower != nil {
+		walkAssociativeTrieNode(lower, depth+1, visitor)
+	}
+	if upper != nil {
+		walkAssociativeTrieNode(upper, depth+1, visitor)
+	}
+}
+
 // AllNodeIterator returns an iterator that iterates through all the nodes in the trie in forward or reverse tree order.
 func (trie *AssociativeTrie[T, V]) AllNodeIterator(forward bool) IteratorWithRemove[*AssociativeTrieNode[T, V]] {
 	return associativeAddressTrieNodeIteratorRem[T, V]{trie.tobase().allNodeIterator(forward)}
@@ -950,6 +1202,94 @@ func (trie *AssociativeTrie[T, V]) DeepEqual(other *AssociativeTrie[T, V]) bool
 	return trie.toTrie().DeepEqual(other.toTrie())
 }
 
+// UnionWith returns a new trie containing the added elements of both this trie and the given trie, along with their associated values.
+// When both tries have the same key, the value from the given trie is used.
+// Neither trie is modified.
+//
+// The address type/version of the keys of the two tries must match.
+func (trie *AssociativeTrie[T, V]) UnionWith(other *AssociativeTrie[T, V]) *AssociativeTrie[T, V] {
+	return trie.UnionWithFunc(other, func(_, otherValue V) V {
+		return otherValue
+	})
+}
+
+// UnionWithFunc returns a new trie containing the added elements of both this trie and the given trie, along with their associated values.
+// When both tries have the same key, the value is the result of calling the given merge function with the values from this trie and the given trie.
+// Neither trie is modified.
+//
+// The address type/version of the keys of the two tries must match.
+func (trie *AssociativeTrie[T, V]) UnionWithFunc(other *AssociativeTrie[T, V], merge func(value, otherValue V) V) *AssociativeTrie[T, V] {
+	res := &AssociativeTrie[T, V]{}
+	if trie != nil {
+		res.PutTrie(trie.GetRoot())
+	}
+
+	if other != nil {
+		for iter := other.NodeIterator(true); iter.HasNext(); {
+			node := iter.Next()
+			addr, otherValue := node.GetKey(), node.GetValue()
+			res.Remap(addr, func(existingValue V, found bool) (V, bool) {
+				if found {
+					return merge(existingValue, otherValue), true
+				}
+				return otherValue, true
+			})
+		}
+	}
+	return res
+}
+
+// IntersectWith returns a new trie containing the elements added to both this trie and the given trie,
+// each associated with the value from the given trie.
+// Neither trie is modified.
+//
+// Only elements that match exactly are included.
+// An element of one trie that is contained by a larger prefix block element of the other trie is not included,
+// use IntersectContainedFunc to include such elements.
+func (trie *AssociativeTrie[T, V]) IntersectWith(other *AssociativeTrie[T, V]) *AssociativeTrie[T, V] {
+	res := &AssociativeTrie[T, V]{}
+	if trie == nil || other == nil {
+		return res
+	}
+
+	for iter := trie.Iterator(); iter.HasNext(); {
+		addr := iter.Next()
+		if value, found := other.Get(addr); found {
+			res.Put(addr, value)
+		}
+	}
+	return res
+}
+
+// IntersectContainedFunc returns a new trie containing the addresses and prefix blocks that are contained by both this trie and the given trie,
+// the set intersection of the addresses of the two tries, as with Trie.IntersectContained.
+// Unlike IntersectWith, the elements of one trie contained by the larger prefix block elements of the other are included.
+// Neither trie is modified.
+//
+// Each element of the result is an element of one trie contained by an element of the other,
+// and is associated with the result of calling the given merge function with the values from this trie and the given trie.
+// When the element is contained by more than one element of the other trie, the value of the containing element with the longest prefix is used.
+func (trie *AssociativeTrie[T, V]) IntersectContainedFunc(other *AssociativeTrie[T, V], merge func(value, otherValue V) V) *AssociativeTrie[T, V] {
+	res := &AssociativeTrie[T, V]{}
+	if trie == nil || other == nil {
+		return res
+	}
+
+	for iter := trie.NodeIterator(true); iter.HasNext(); {
+		node := iter.Next()
+		if containing := other.LongestPrefixMatchNode(node.GetKey()); containing != nil {
+			res.Put(node.GetKey(), merge(node.GetValue(), containing.GetValue()))
+		}
+	}
+	for iter := other.NodeIterator(true); iter.HasNext(); {
+		node := iter.Next()
+		if containing := trie.LongestPrefixMatchNode(node.GetKey()); containing != nil {
+			res.Put(node.GetKey(), merge(containing.GetValue(), node.GetValue()))
+		}
+	}
+	return res
+}
+
 // Put associates the specified value with the specified key in this map.
 //
 // If the argument is not a single address nor prefix block, this method will panic.
@@ -1144,6 +1484,17 @@ func (trie *AssociativeTrie[T, V]) LongestPrefixMatchNode(addr T) *AssociativeTr
 	return toAssociativeTrieNode[T, V](trie.longestPrefixMatchNode(addr))
 }
 
+// LongestPrefixMatchWithValue returns the address with the longest matching prefix compared to the provided address,
+// along with its associated value, and true.
+// If no address in the trie contains the provided address, it returns nil, the zero value, and false.
+// An address in the trie matching the provided address exactly is its own longest prefix match.
+func (trie *AssociativeTrie[T, V]) LongestPrefixMatchWithValue(addr T) (t T, v V, found bool) {
+	if node := trie.LongestPrefixMatchNode(addr); node != nil {
+		t, v, found = node.GetKey(), node.GetValue(), true
+	}
+	return
+}
+
 // GetNode gets the node in the trie corresponding to the given address,
 // or returns nil if not such element exists.
 //
@@ -1430,6 +1781,103 @@ func NewAssociativeTrie[T TrieKeyConstraint[T], V any]() *AssociativeTrie[T, V]
 	return &AssociativeTrie[T, V]{}
 }
 
+// NewTrieFromSlice constructs an address trie for the given type containing the given addresses,
+// the same trie as the one obtained by adding each of the addresses to a trie constructed with NewTrie.
+//
+// The addresses are sorted first, so that each can be added from the position in the trie of the previously added address, rather than from the root,
+// which is faster than adding the addresses individually.  The given slice is not modified.
+//
+// If any of the addresses is not a single address nor prefix block, this function will panic.
+func NewTrieFromSlice[T TrieKeyConstraint[T]](addrs []T) *Trie[T] {
+	keys := make([]trieKey[T], len(addrs))
+	for i, addr := range addrs {
+		keys[i] = createKey(mustBeBlockOrAddress(addr))
+	}
+	sortTrieKeys[T, emptyValue](keys, nil)
+
+	res := &Trie[T]{}
+	res.trie.AddSorted(keys)
+	return res
+}
+
+// NewAssociativeTrieFromMap constructs an associative address trie for the given types containing the keys and values of the given map,
+// the same trie as the one obtained by putting each of the keys and values in a trie constructed with NewAssociativeTrie.
+//
+// The keys are sorted first, as with NewTrieFromSlice, which is faster than putting the keys individually.
+// When the map has more than one key for the same address or prefix block, which of the values is kept is unspecified.
+//
+// If any of the keys is not a single address nor prefix block, this function will panic.
+func NewAssociativeTrieFromMap[T TrieKeyConstraint[T], V any](m map[T]V) *AssociativeTrie[T, V] {
+	keys := make([]trieKey[T], 0, len(m))
+	values := make([]V, 0, len(m))
+	for addr, value := range m {
+		keys = append(keys, createKey(mustBeBlockOrAddress(addr)))
+		values = append(values, value)
+	}
+	sortTrieKeys(keys, values)
+
+	res := &AssociativeTrie[T, V]{}
+	res.trie.PutSorted(keys, values)
+	return res
+}
+
+// sortTrieKeys sorts the given keys, along with the values at the same indices when the values are not nil,
+// in the order of a pre-order traversal of the trie, with each prefix block preceding the keys it contains.
+// This is the order of the lowest values, with ties broken by the prefix lengths, shortest first.
+func sortTrieKeys[T TrieKeyConstraint[T], V any](keys []trieKey[T], values []V) {
+	sorter := trieKeySorter[T, V]{keys: keys, values: values, data: make([]*tree.TrieKeyData, len(keys))}
+	for i, key := range keys {
+		data := key.address.toAddressBase().getTrieCache()
+		if !data.Is32Bits && !data.Is128Bits {
+			sorter.data = nil // compare the addresses instead
+			break
+		}
+		sorter.data[i] = data
+	}
+	sort.Sort(sorter)
+}
+
+// trieKeySorter sorts trie keys using the values in the trie key data of IPv4 and IPv6 keys,
+// or by comparing the addresses of other keys.
+type trieKeySorter[T TrieKeyConstraint[T], V any] struct {
+	keys   []trieKey[T]
+	values []V
+	data   []*tree.TrieKeyData
+}
+
+func (sorter trieKeySorter[T, V]) Len() int {
+	return len(sorter.keys)
+}
+
+func (sorter trieKeySorter[T, V]) Less(i, j int) bool {
+	if sorter.data == nil {
+		return ReverseLowValueComparator.CompareAddresses(sorter.keys[i].address.toAddressBase(), sorter.keys[j].address.toAddressBase()) < 0
+	}
+
+	data1, data2 := sorter.data[i], sorter.data[j]
+	if data1.Is32Bits {
+		if data1.Uint32Val != data2.Uint32Val {
+			return data1.Uint32Val < data2.Uint32Val
+		}
+	} else if data1.Uint64HighVal != data2.Uint64HighVal {
+		return data1.Uint64HighVal < data2.Uint64HighVal
+	} else if data1.Uint64LowVal != data2.Uint64LowVal {
+		return data1.Uint64LowVal < data2.Uint64LowVal
+	}
+	// an individual address follows the prefix blocks with the same lowest value
+	return data2.PrefLen == nil && data1.PrefLen != nil || (data1.PrefLen != nil && data1.PrefLen.Len() < data2.PrefLen.Len())
+}
+
+func (sorter trieKeySorter[T, V]) Swap(i, j int) {
+	sorter.keys[i], sorter.keys[j] = sorter.keys[j], sorter.keys[i]
+	if sorter.values != nil {
+		sorter.values[i], sorter.values[j] = sorter.values[j], sorter.values[i]
+	}
+	if sorter.data != nil {
+		sorter.data[i], sorter.data[j] = sorter.data[j], sorter.data[i]
+	}
+}
+
 // NewIPv4AddressTrie constructs an IPv4 address trie with
 // the root as the 0.0.0.0/0 prefix block
 // This is here for backwards compatibility.
@@ -1438,6 +1886,27 @@ func NewIPv4AddressTrie() *Trie[*IPv4Address] { // for backwards compatibility
 	return &Trie[*IPv4Address]{trieBase[*IPv4Address, emptyValue]{tree.NewBinTrie[trieKey[*IPv4Address], emptyValue](trieKey[*IPv4Address]{address: ipv4All})}}
 }
 
+// UnmarshalIPv4AddressTrie reconstructs an IPv4 address trie from the binary representation produced by Trie.Marshal.
+// An error is returned if the data is malformed, if the format version is not supported, or if the data is not that of an IPv4 trie.
+func UnmarshalIPv4AddressTrie(data []byte) (*IPv4AddressTrie, address_error.AddressValueError) {
+	return unmarshalTrie(data, trieFormatIPv4, IPv4BitCount,
+		func(bytes []byte, prefLen PrefixLen) (*IPv4Address, address_error.AddressValueError) {
+			return NewIPv4AddressFromPrefixedBytes(bytes, prefLen)
+		})
+}
+
+// NewIPv4AddressTrieFromSlice constructs an IPv4 address trie containing the given addresses,
+// the same trie as the one obtained by adding each of the addresses individually.  See NewTrieFromSlice.
+func NewIPv4AddressTrieFromSlice(addrs []*IPv4Address) *IPv4AddressTrie {
+	return NewTrieFromSlice(addrs)
+}
+
+// NewIPv4AddressAssociativeTrieFromMap constructs an IPv4 associative address trie containing the keys and values of the given map,
+// the same trie as the one obtained by putting each of the keys and values individually.  See NewAssociativeTrieFromMap.
+func NewIPv4AddressAssociativeTrieFromMap[V any](m map[*IPv4Address]V) *AssociativeTrie[*IPv4Address, V] {
+	return NewAssociativeTrieFromMap(m)
+}
+
 // NewIPv4AddressAssociativeTrie constructs an IPv4 associative address trie with
 // the root as the 0.0.0.0/0 prefix block
 // This is here for backwards compatibility.
@@ -1454,6 +1923,246 @@ func NewIPv6AddressTrie() *Trie[*IPv6Address] { // for backwards compatibility
 	return &Trie[*IPv6Address]{trieBase[*IPv6Address, emptyValue]{tree.NewBinTrie[trieKey[*IPv6Address], emptyValue](trieKey[*IPv6Address]{address: ipv6All})}}
 }
 
+// UnmarshalIPv6AddressTrie reconstructs an IPv6 address trie from the binary representation produced by Trie.Marshal.
+// An error is returned if the data is malformed, if the format version is not supported, or if the data is not that of an IPv6 trie.
+func UnmarshalIPv6AddressTrie(data []byte) (*IPv6AddressTrie, address_error.AddressValueError) {
+	return unmarshalTrie(data, trieFormatIPv6, IPv6BitCount,
+		func(bytes []byte, prefLen PrefixLen) (*IPv6Address, address_error.AddressValueError) {
+			return NewIPv6AddressFromPrefixedBytes(bytes, prefLen)
+		})
+}
+
+func unmarshalTrie[T TrieKeyConstraint[T]](
+	data []byte,
+	format byte,
+	bitCount BitCount,
+	creator func(bytes []byte, prefLen PrefixLen) (T, address_error.AddressValueError),
+) (*Trie[T], address_error.AddressValueError) {
+	if len(data) < 2 {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	} else if data[0] != trieFormatVersion && data[0] != trieFormatFlatVersion {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.trie.version"}, val: int(data[0])}
+	} else if data[1] != format && data[1] != trieFormatEmpty {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	}
+
+	var addrs []T
+	var err address_error.AddressValueError
+	if data[0] == trieFormatFlatVersion {
+		addrs, err = unmarshalTrieList(data[2:], bitCount, creator)
+	} else if data[1] != trieFormatEmpty {
+		decoder := trieDecoder[T]{reader: trieBitReader{bytes: data[2:]}, bitCount: bitCount, creator: creator}
+		if err = decoder.decodeNode(make([]byte, (bitCount+7)>>3), -1); err == nil && !decoder.reader.isPaddingOnly() {
+			err = &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+		}
+		addrs = decoder.addrs
+	} else if len(data) > 2 {
+		err = &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addrs) == 0 {
+		return NewTrie[T](), nil
+	}
+	// the elements are in trie order, with each prefix block preceding the keys it contains
+	return NewTrieFromSlice(addrs), nil
+}
+
+// trieDecoder decodes the nodes of the tree format produced by Trie.Marshal, collecting the added keys.
+type trieDecoder[T TrieKeyConstraint[T]] struct {
+	reader   trieBitReader
+	bitCount BitCount
+	creator  func(bytes []byte, prefLen PrefixLen) (T, address_error.AddressValueError)
+	addrs    []T
+}
+
+// decodeNode decodes a node and its sub-nodes, given the key bytes of the node with the bits preceding its remaining prefix bits,
+// and the prefix length of the parent node, or -1 for the root node.
+func (decoder *trieDecoder[T]) decodeNode(key []byte, parentPrefLen BitCount) address_error.AddressValueError {
+	reader := &decoder.reader
+	isAdded, hasLower, hasUpper := reader.readBit(), reader.readBit(), reader.readBit()
+	start := parentPrefLen + 1
+	count, ok := reader.readCount(uint(decoder.bitCount - start))
+	if !ok {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	}
+
+	keyPrefLen := start + BitCount(count)
+	prefLen := cacheBitCount(keyPrefLen)
+	if keyPrefLen == decoder.bitCount {
+		// an individual address can have no sub-nodes
+		if hasLower || hasUpper {
+			return &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+		} else if reader.readBit() {
+			prefLen = nil
+		}
+	}
+	for i := start; i < keyPrefLen; i++ {
+		if reader.readBit() {
+			key[i>>3] |= 0x80 >> (i & 7)
+		}
+	}
+	if reader.isOverrun() {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	}
+
+	if isAdded {
+		addr, err := decoder.creator(append([]byte(nil), key...), prefLen)
+		if err != nil {
+			return err
+		}
+		decoder.addrs = append(decoder.addrs, addr)
+	}
+	if hasLower {
+		if err := decoder.decodeNode(append([]byte(nil), key...), keyPrefLen); err != nil {
+			return err
+		}
+	}
+	if hasUpper {
+		upperKey := append([]byte(nil), key...)
+		upperKey[keyPrefLen>>3] |= 0x80 >> (keyPrefLen & 7)
+		if err := decoder.decodeNode(upperKey, keyPrefLen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trieBitReader reads a stream of bits written by trieBitWriter.
+// Reading past the end produces zero bits, after which isOverrun returns true.
+type trieBitReader struct {
+	bytes     []byte
+	bitOffset uint // the count of bits read from the total
+}
+
+func (reader *trieBitReader) readBit() bool {
+	byteIndex := reader.bitOffset >> 3
+	bit := byteIndex < uint(len(reader.bytes)) && reader.bytes[byteIndex]&(0x80>>(reader.bitOffset&7)) != 0
+	reader.bitOffset++
+	return bit
+}
+
+// readCount reads the count written by writeCount, which must not exceed the given maximum.
+func (reader *trieBitReader) readCount(maxCount uint) (uint, bool) {
+	zeros := 0
+	for !reader.readBit() {
+		if zeros++; zeros >= bits.Len(maxCount+1) || reader.isOverrun() {
+			return 0, false
+		}
+	}
+	val := uint(1)
+	for ; zeros > 0; zeros-- {
+		val <<= 1
+		if reader.readBit() {
+			val |= 1
+		}
+	}
+	if val-1 > maxCount {
+		return 0, false
+	}
+	return val - 1, true
+}
+
+func (reader *trieBitReader) isOverrun() bool {
+	return reader.bitOffset > uint(len(reader.bytes))<<3
+}
+
+// isPaddingOnly returns whether the bits remaining to be read are only the zero bits padding the last byte.
+func (reader *trieBitReader) isPaddingOnly() bool {
+	if reader.isOverrun() || uint(len(reader.bytes))<<3-reader.bitOffset >= 8 {
+		return false
+	}
+	for !reader.isOverrun() && reader.bitOffset < uint(len(reader.bytes))<<3 {
+		if reader.readBit() {
+			return false
+		}
+	}
+	return true
+}
+
+// unmarshalTrieList decodes the list format, an element count as an unsigned varint followed by the elements,
+// each a prefix length byte (0xff for an individual address with no prefix length) followed by the address bytes containing prefix bits.
+func unmarshalTrieList[T TrieKeyConstraint[T]](
+	data []byte,
+	bitCount BitCount,
+	creator func(bytes []byte, prefLen PrefixLen) (T, address_error.AddressValueError),
+) ([]T, address_error.AddressValueError) {
+	count, n := binary.Uvarint(data)
+	if n <= 0 {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	}
+
+	data = data[n:]
+	byteCount := int((bitCount + 7) >> 3)
+	var addrs []T
+	for ; count > 0; count-- {
+		if len(data) == 0 {
+			return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+		}
+
+		var prefLen PrefixLen
+		addrByteCount := byteCount
+		if data[0] != trieFormatNoPrefix {
+			if BitCount(data[0]) > bitCount {
+				return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+			}
+			prefLen = cacheBitCount(BitCount(data[0]))
+			addrByteCount = int((prefLen.bitCount() + 7) >> 3)
+		}
+
+		data = data[1:]
+		if len(data) < addrByteCount {
+			return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+		}
+
+		bytes := make([]byte, byteCount)
+		copy(bytes, data[:addrByteCount])
+		data = data[addrByteCount:]
+		addr, err := creator(bytes, prefLen)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+
+	if len(data) > 0 {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.trie.data"}}
+	}
+	return addrs, nil
+}
+
+// addressToTrieKey converts the given address to the key type of a trie,
+// returning nil when the address is not of the key type.
+func addressToTrieKey[T TrieKeyConstraint[T]](addr *Address) (t T) {
+	switch any(t).(type) {
+	case *IPv4Address:
+		t = any(addr.ToIPv4()).(T)
+	case *IPv6Address:
+		t = any(addr.ToIPv6()).(T)
+	case *IPAddress:
+		t = any(addr.ToIP()).(T)
+	case *MACAddress:
+		t = any(addr.ToMAC()).(T)
+	default:
+		t = any(addr).(T)
+	}
+	return
+}
+
+// NewIPv6AddressTrieFromSlice constructs an IPv6 address trie containing the given addresses,
+// the same trie as the one obtained by adding each of the addresses individually.  See NewTrieFromSlice.
+func NewIPv6AddressTrieFromSlice(addrs []*IPv6Address) *IPv6AddressTrie {
+	return NewTrieFromSlice(addrs)
+}
+
+// NewIPv6AddressAssociativeTrieFromMap constructs an IPv6 associative address trie containing the keys and values of the given map,
+// the same trie as the one obtained by putting each of the keys and values individually.  See NewAssociativeTrieFromMap.
+func NewIPv6AddressAssociativeTrieFromMap[V any](m map[*IPv6Address]V) *AssociativeTrie[*IPv6Address, V] {
+	return NewAssociativeTrieFromMap(m)
+}
+
 // NewIPv6AddressAssociativeTrie constructs
 // an IPv6 associative address trie with the root as
 // the ::/0 prefix block
@@ -131,6 +131,68 @@ func (iter associativeAddressTrieNodeIterator[T, V]) Next() *AssociativeTrieNode
 	return toAssociativeTrieNode[T, V](iter.TrieNodeIterator.Next())
 }
 
+// filteredTrieNodeIterator iterates through the nodes of the wrapped iterator for which the predicate returns true.
+type filteredTrieNodeIterator[T TrieKeyConstraint[T]] struct {
+	predicate func(*TrieNode[T]) bool
+	iter      Iterator[*TrieNode[T]]
+	next      *TrieNode[T]
+}
+
+func (it *filteredTrieNodeIterator[T]) Next() (res *TrieNode[T]) {
+	res = it.next
+	for {
+		next := it.iter.Next()
+		if next == nil || it.predicate(next) {
+			it.next = next
+			break
+		}
+	}
+	return res
+}
+
+func (it *filteredTrieNodeIterator[T]) HasNext() bool {
+	return it.next != nil
+}
+
+// prunedTrieNodeIterator iterates through the added nodes in containing-first order,
+// skipping any added node for which the predicate returns false along with its entire sub-trie.
+type prunedTrieNodeIterator[T TrieKeyConstraint[T]] struct {
+	predicate func(*TrieNode[T]) bool
+	stack     []*TrieNode[T]
+	next      *TrieNode[T]
+}
+
+func (it *prunedTrieNodeIterator[T]) Next() (res *TrieNode[T]) {
+	res = it.next
+	it.next = nil
+	for len(it.stack) > 0 {
+		node := it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+		added := node.IsAdded()
+		if added && !it.predicate(node) {
+			continue
+		}
+
+		// push the upper sub-node first so that the lower sub-node is visited first
+		if upper := node.GetUpperSubNode(); upper != nil {
+			it.stack = append(it.stack, upper)
+		}
+		if lower := node.GetLowerSubNode(); lower != nil {
+			it.stack = append(it.stack, lower)
+		}
+
+		if added {
+			it.next = node
+			break
+		}
+	}
+	return res
+}
+
+func (it *prunedTrieNodeIterator[T]) HasNext() bool {
+	return it.next != nil
+}
+
 func nilAddressIterator[T any]() Iterator[T] {
 	return emptyIterator[T]{}
 }
@@ -1057,6 +1057,47 @@ func (node *TrieNode[T]) IsEmpty() bool {
 	return node.Size() == 0
 }
 
+// GetAllChildren returns the sub-nodes of this node, the lower sub-node followed by the upper sub-node,
+// omitting either when it does not exist.
+func (node *TrieNode[T]) GetAllChildren() []*TrieNode[T] {
+	var children []*TrieNode[T]
+	if lower := node.GetLowerSubNode(); lower != nil {
+		children = append(children, lower)
+	}
+	if upper := node.GetUpperSubNode(); upper != nil {
+		children = append(children, upper)
+	}
+	return children
+}
+
+// GetAllDescendants returns the elements of the sub-trie with this node as the root in sorted element order,
+// excluding the element of this node itself.
+// Only nodes for which IsAdded returns true are included.
+func (node *TrieNode[T]) GetAllDescendants() []T {
+	if node == nil {
+		return nil
+	}
+
+	descendants := make([]T, 0, node.GetDescendantCount())
+	for _, child := range node.GetAllChildren() {
+		for iter := child.Iterator(); iter.HasNext(); {
+			descendants = append(descendants, iter.Next())
+		}
+	}
+	return descendants
+}
+
+// GetDescendantCount returns the number of elements in the sub-trie with this node as the root,
+// excluding the element of this node itself, without creating the slice returned by GetAllDescendants.
+func (node *TrieNode[T]) GetDescendantCount() int {
+	if node == nil {
+		return 0
+	} else if node.IsAdded() {
+		return node.Size() - 1
+	}
+	return node.Size()
+}
+
 // TreeString returns a visual representation of the sub-trie with this node as the root,
 // with one node per line.
 //
@@ -0,0 +1,193 @@
+package goip
+
+import (
+	"math/bits"
+	"sort"
+)
+
+type seriesMerger func([]ExtendedIPSegmentSeries) []ExtendedIPSegmentSeries
+
+func aggregateIPAddrs(
+	addrs []*IPAddress,
+	ipv4Aggregator func([]*IPv4Address) []*IPv4Address,
+	ipv6Aggregator func([]*IPv6Address) []*IPv6Address,
+) (result []*IPAddress) {
+	var ipv4Addrs []*IPv4Address
+	var ipv6Addrs []*IPv6Address
+	for _, addr := range addrs {
+		if addr == nil {
+			continue
+		}
+		addr = addr.init()
+		if addr.IsIPv4() {
+			ipv4Addrs = append(ipv4Addrs, addr.ToIPv4())
+		} else if addr.IsIPv6() {
+			ipv6Addrs = append(ipv6Addrs, addr.ToIPv6())
+		}
+	}
+
+	for _, addr := range ipv4Aggregator(ipv4Addrs) {
+		result = append(result, addr.ToIP())
+	}
+	for _, addr := range ipv6Aggregator(ipv6Addrs) {
+		result = append(result, addr.ToIP())
+	}
+	return
+}
+
+// aggregateIPv4PrefixBlocks merges the given addresses with integer arithmetic, which is much faster than the general merging,
+// by merging the sorted ranges of values and then spanning each merged range with prefix blocks.
+func aggregateIPv4PrefixBlocks(addrs []*IPv4Address) (result []*IPv4Address) {
+	type valueRange struct{ lower, upper uint32 }
+	ranges := make([]valueRange, 0, len(addrs))
+	for _, addr := range addrs {
+		if addr == nil {
+			continue
+		}
+		addr = addr.init()
+		if addr.IsSequential() {
+			ranges = append(ranges, valueRange{addr.Uint32Value(), addr.UpperUint32Value()})
+		} else {
+			for iter := addr.SequentialBlockIterator(); iter.HasNext(); {
+				block := iter.Next()
+				ranges = append(ranges, valueRange{block.Uint32Value(), block.UpperUint32Value()})
+			}
+		}
+	}
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].lower < ranges[j].lower
+	})
+	merged := ranges[:1]
+	for _, rng := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if uint64(rng.lower) <= uint64(last.upper)+1 { // overlapping or adjacent
+			if rng.upper > last.upper {
+				last.upper = rng.upper
+			}
+		} else {
+			merged = append(merged, rng)
+		}
+	}
+
+	for _, rng := range merged {
+		// each block is the largest block starting at the lower value that does not extend past the upper value
+		for lower, upper := uint64(rng.lower), uint64(rng.upper); lower <= upper; {
+			hostBits := bits.TrailingZeros64(lower | 1<<IPv4BitCount)
+			for lower+(1<<hostBits)-1 > upper {
+				hostBits--
+			}
+			result = append(result, NewIPv4AddressFromPrefixedUint32(uint32(lower), cacheBitCount(IPv4BitCount-BitCount(hostBits))))
+			lower += 1 << hostBits
+		}
+	}
+	return
+}
+
+func aggregateIPv4Addrs(addrs []*IPv4Address, merger seriesMerger) []*IPv4Address {
+	sorted := make([]*IPv4Address, 0, len(addrs))
+	for _, addr := range addrs {
+		if addr != nil {
+			sorted = append(sorted, addr.init())
+		}
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	// sorting by the integer values beforehand is much faster than the sorting done when merging,
+	// which then has little left to do
+	sort.Slice(sorted, func(i, j int) bool {
+		one, two := sorted[i], sorted[j]
+		if oneVal, twoVal := one.Uint32Value(), two.Uint32Value(); oneVal != twoVal {
+			return oneVal < twoVal
+		}
+		return one.UpperUint32Value() < two.UpperUint32Value()
+	})
+	series := make([]ExtendedIPSegmentSeries, len(sorted))
+	for i, addr := range sorted {
+		series[i] = addr.Wrap()
+	}
+	return cloneToIPv4Addrs(merger(series))
+}
+
+func aggregateIPv6Addrs(addrs []*IPv6Address, merger seriesMerger) []*IPv6Address {
+	sorted := make([]*IPv6Address, 0, len(addrs))
+	for _, addr := range addrs {
+		if addr != nil {
+			sorted = append(sorted, addr.init())
+		}
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		one, two := sorted[i], sorted[j]
+		oneHigh, oneLow := one.Uint64Values()
+		twoHigh, twoLow := two.Uint64Values()
+		if oneHigh != twoHigh {
+			return oneHigh < twoHigh
+		} else if oneLow != twoLow {
+			return oneLow < twoLow
+		}
+		oneHigh, oneLow = one.UpperUint64Values()
+		twoHigh, twoLow = two.UpperUint64Values()
+		return oneHigh < twoHigh || (oneHigh == twoHigh && oneLow < twoLow)
+	})
+	series := make([]ExtendedIPSegmentSeries, len(sorted))
+	for i, addr := range sorted {
+		series[i] = addr.Wrap()
+	}
+	return cloneToIPv6Addrs(merger(series))
+}
+
+// AggregateToPrefixBlocks merges the given addresses and subnets to produce the smallest array of CIDR prefix blocks
+// covering exactly the same addresses.
+// It is equivalent to MergeToPrefixBlocks, but requires no receiver, so that it can be used with any slice, including an empty or nil slice.
+//
+// IPv4 and IPv6 elements are merged separately, and the resulting slice holds the IPv4 blocks followed by the IPv6 blocks,
+// each sorted from lowest address value to highest.
+// Nil elements are ignored, and nil is returned when there are no addresses to merge.
+func AggregateToPrefixBlocks(addrs []*IPAddress) []*IPAddress {
+	return aggregateIPAddrs(addrs, AggregateIPv4ToPrefixBlocks, AggregateIPv6ToPrefixBlocks)
+}
+
+// AggregateToSequentialBlocks merges the given addresses and subnets to produce the smallest array of sequential blocks
+// covering exactly the same addresses.
+// It is equivalent to MergeToSequentialBlocks, but requires no receiver.
+// See AggregateToPrefixBlocks for the handling of mixed IPv4 and IPv6 elements.
+func AggregateToSequentialBlocks(addrs []*IPAddress) []*IPAddress {
+	return aggregateIPAddrs(addrs, AggregateIPv4ToSequentialBlocks, AggregateIPv6ToSequentialBlocks)
+}
+
+// AggregateIPv4ToPrefixBlocks merges the given IPv4 addresses and subnets to produce the smallest array of CIDR prefix blocks,
+// sorted from lowest address value to highest.
+// Nil elements are ignored, and nil is returned when there are no addresses to merge.
+func AggregateIPv4ToPrefixBlocks(addrs []*IPv4Address) []*IPv4Address {
+	return aggregateIPv4PrefixBlocks(addrs)
+}
+
+// AggregateIPv4ToSequentialBlocks merges the given IPv4 addresses and subnets to produce the smallest array of sequential blocks,
+// sorted from lowest address value to highest.
+// Nil elements are ignored, and nil is returned when there are no addresses to merge.
+func AggregateIPv4ToSequentialBlocks(addrs []*IPv4Address) []*IPv4Address {
+	return aggregateIPv4Addrs(addrs, getMergedSequentialBlocks)
+}
+
+// AggregateIPv6ToPrefixBlocks merges the given IPv6 addresses and subnets to produce the smallest array of CIDR prefix blocks,
+// sorted from lowest address value to highest.
+// Nil elements are ignored, and nil is returned when there are no addresses to merge.
+func AggregateIPv6ToPrefixBlocks(addrs []*IPv6Address) []*IPv6Address {
+	return aggregateIPv6Addrs(addrs, getMergedPrefixBlocks)
+}
+
+// AggregateIPv6ToSequentialBlocks merges the given IPv6 addresses and subnets to produce the smallest array of sequential blocks,
+// sorted from lowest address value to highest.
+// Nil elements are ignored, and nil is returned when there are no addresses to merge.
+func AggregateIPv6ToSequentialBlocks(addrs []*IPv6Address) []*IPv6Address {
+	return aggregateIPv6Addrs(addrs, getMergedSequentialBlocks)
+}
@@ -6,6 +6,8 @@ import (
 	"math/big"
 	"sort"
 	"strings"
+
+	"github.com/pchchv/goip/address_error"
 )
 
 var (
@@ -41,6 +43,7 @@ type PrefixBlockConstraint[T any] interface {
 type PrefixBlockAllocator[T PrefixBlockConstraint[T]] struct {
 	version         IPVersion
 	blocks          [][]T
+	allocated       []T
 	reservedCount   int
 	totalBlockCount int
 }
@@ -112,6 +115,31 @@ func (alloc *PrefixBlockAllocator[T]) GetAvailable() (blocks []T) {
 	return
 }
 
+// ListAvailable returns all the blocks available for allocating in the allocator,
+// sorted from the smallest block, the one with the longest prefix length, to the largest.
+// Blocks of the same size are sorted by address.
+// It returns nil when no blocks are available.
+func (alloc *PrefixBlockAllocator[T]) ListAvailable() (blocks []T) {
+	for i := len(alloc.blocks) - 1; i >= 0; i-- {
+		start := len(blocks)
+		blocks = append(blocks, alloc.blocks[i]...)
+		sameSize := blocks[start:]
+		sort.Slice(sameSize, func(i, j int) bool {
+			return sameSize[i].Compare(sameSize[j]) < 0
+		})
+	}
+	return
+}
+
+// ListAllocated returns all the blocks that have been allocated by the allocator since it was created, in the order of allocation.
+// It returns nil when no blocks have been allocated.
+func (alloc *PrefixBlockAllocator[T]) ListAllocated() []T {
+	if len(alloc.allocated) == 0 {
+		return nil
+	}
+	return append([]T(nil), alloc.allocated...)
+}
+
 // AddAvailable provides the given blocks to
 // the allocator for allocating.
 func (alloc *PrefixBlockAllocator[T]) AddAvailable(blocks ...T) {
@@ -170,6 +198,9 @@ func (alloc *PrefixBlockAllocator[T]) AllocateBitLen(bitLength BitCount) T {
 	}
 
 	if !block.IsMultiple() || i == newPrefixBitCount {
+		if i >= 0 {
+			alloc.allocated = append(alloc.allocated, block)
+		}
 		return block
 	}
 	// block is larger than needed, adjust it
@@ -180,6 +211,7 @@ func (alloc *PrefixBlockAllocator[T]) AllocateBitLen(bitLength BitCount) T {
 	// now we add the remaining from the block iterator back into the list
 	alloc.insertBlocks(newSequRangeUnchecked(blockIterator.Next().GetLower(), block.GetUpper(), true).SpanWithPrefixBlocks())
 
+	alloc.allocated = append(alloc.allocated, result)
 	return result
 }
 
@@ -337,3 +369,58 @@ func (alloc AllocatedBlock[T]) String() string {
 	}
 	return fmt.Sprint(alloc.block, " for ", alloc.blockSize, " hosts")
 }
+
+// NextAvailableIPv4Address returns the lowest host address in the given subnet that is not an element of the given trie,
+// nor contained by any prefix block element of the trie.
+// When the subnet is a prefix block with a prefix length of 30 or less, the network and broadcast addresses are skipped.
+// The subnet is treated as the sequential range from its lowest to its highest address.
+//
+// Rather than iterating through each address of the subnet, each used prefix block in the trie is skipped in its entirety.
+// A nil trie is treated as empty, with no addresses in use.
+// An error is returned if all the addresses are in use.
+func NextAvailableIPv4Address(subnet *IPv4Address, trie *IPv4AddressTrie) (*IPv4Address, address_error.AddressValueError) {
+	return nextAvailableAddress(subnet, trie, true)
+}
+
+// NextAvailableIPv6Address returns the lowest host address in the given subnet that is not an element of the given trie,
+// nor contained by any prefix block element of the trie.
+// When the subnet is a prefix block with a prefix length of 126 or less, the zero-host subnet-router anycast address is skipped.
+// The subnet is treated as the sequential range from its lowest to its highest address.
+//
+// Rather than iterating through each address of the subnet, each used prefix block in the trie is skipped in its entirety.
+// A nil trie is treated as empty, with no addresses in use.
+// An error is returned if all the addresses are in use.
+func NextAvailableIPv6Address(subnet *IPv6Address, trie *IPv6AddressTrie) (*IPv6Address, address_error.AddressValueError) {
+	return nextAvailableAddress(subnet, trie, false)
+}
+
+func nextAvailableAddress[T interface {
+	TrieKeyConstraint[T]
+	GetLower() T
+	GetUpper() T
+	Increment(int64) T
+	AddressItem
+	Compare(AddressItem) int
+}](subnet T, trie *Trie[T], skipBroadcast bool) (res T, err address_error.AddressValueError) {
+	lower, upper := subnet.GetLower().WithoutPrefixLen(), subnet.GetUpper().WithoutPrefixLen()
+	if prefLen := subnet.GetPrefixLen(); prefLen != nil && subnet.IsPrefixBlock() && prefLen.bitCount() < subnet.GetBitCount()-1 {
+		lower = lower.Increment(1)
+		if skipBroadcast {
+			upper = upper.Increment(-1)
+		}
+	}
+
+	var zero T
+	for candidate := lower; candidate != zero && candidate.Compare(upper) <= 0; {
+		if trie == nil { // nothing allocated
+			return candidate, nil
+		}
+		used := trie.ShortestPrefixMatch(candidate)
+		if used == zero {
+			return candidate, nil
+		}
+		candidate = used.GetUpper().WithoutPrefixLen().Increment(1)
+	}
+	err = &addressValueError{addressError: addressError{key: "ipaddress.error.no.available.address"}}
+	return
+}
@@ -0,0 +1,46 @@
+package goip
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+)
+
+// NewAllowListHandler returns an http.Handler that passes requests to the given handler
+// only when the remote IP address of the request is within this address or subnet.
+// Other requests are answered with 403 Forbidden, as are requests with a remote address that cannot be parsed.
+//
+// The remote address is taken from the RemoteAddr field of the request, not from headers such as X-Forwarded-For,
+// so behind a proxy it is the address of the proxy.
+// Any zone of an IPv6 remote address is dropped before comparison,
+// and an IPv4-mapped IPv6 remote address such as "::ffff:1.2.3.4" is compared as IPv4 when this is an IPv4 address or subnet.
+func (addr *IPAddress) NewAllowListHandler(next http.Handler) http.Handler {
+	addr = addr.init()
+	isIPv4 := addr.IsIPv4()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if remote, ok := parseRemoteAddr(r.RemoteAddr, isIPv4); ok && addr.Contains(remote) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	})
+}
+
+// parseRemoteAddr parses the remote address of an http.Request, which is an IP address usually followed by a port.
+func parseRemoteAddr(remoteAddr string, unmap bool) (*IPAddress, bool) {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr // no port
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return nil, false
+	}
+
+	ip = ip.WithZone("")
+	if unmap {
+		ip = ip.Unmap()
+	}
+	return NewIPAddressFromNetNetIPAddr(ip), true
+}
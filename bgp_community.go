@@ -0,0 +1,54 @@
+package goip
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pchchv/goip/address_error"
+)
+
+const (
+	// BGPLargeCommunitySeparator is the separator between the three decimal parts of a BGP large community string.
+	BGPLargeCommunitySeparator = ':'
+	// BGPLargeCommunitySeparatorStr is the separator between the three decimal parts of a BGP large community string, as a string.
+	BGPLargeCommunitySeparatorStr = ":"
+)
+
+// NewBGPLargeCommunity returns the string for a BGP large community as defined in RFC 8092,
+// the 32-bit global administrator (the AS number) and the two 32-bit local data parts,
+// written in decimal and separated by ':', as in "64496:4294967295:2".
+func NewBGPLargeCommunity(asn, val1, val2 uint32) string {
+	var builder strings.Builder
+	builder.Grow(32)
+	builder.WriteString(strconv.FormatUint(uint64(asn), 10))
+	builder.WriteByte(BGPLargeCommunitySeparator)
+	builder.WriteString(strconv.FormatUint(uint64(val1), 10))
+	builder.WriteByte(BGPLargeCommunitySeparator)
+	builder.WriteString(strconv.FormatUint(uint64(val2), 10))
+	return builder.String()
+}
+
+// ParseBGPLargeCommunity parses the string of a BGP large community as defined in RFC 8092,
+// such as "64496:4294967295:2", returning the AS number followed by the two local data parts.
+//
+// The string must consist of exactly three unsigned 32-bit decimal values separated by ':'.
+// Leading and trailing whitespace is ignored.
+func ParseBGPLargeCommunity(str string) (asn, val1, val2 uint32, err address_error.AddressStringError) {
+	str = strings.TrimSpace(str)
+	parts := strings.Split(str, BGPLargeCommunitySeparatorStr)
+	if len(parts) != 3 {
+		err = &addressStringError{addressError{str: str, key: "ipaddress.error.bgp.large.community"}}
+		return
+	}
+
+	var vals [3]uint32
+	for i, part := range parts {
+		val, parseErr := strconv.ParseUint(part, 10, 32)
+		if parseErr != nil {
+			err = &addressStringError{addressError{str: str, key: "ipaddress.error.bgp.large.community"}}
+			return
+		}
+		vals[i] = uint32(val)
+	}
+	return vals[0], vals[1], vals[2], nil
+}
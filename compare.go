@@ -1,6 +1,10 @@
 package goip
 
-import "math/big"
+import (
+	"math/big"
+	"math/bits"
+	"sort"
+)
 
 const (
 	ipv6sectype          groupingType = 7
@@ -41,6 +45,27 @@ var (
 	ReverseLowValueComparator = AddressComparator{valueComparator{flipSecond: true}}
 )
 
+// SpecificityComparator returns a comparator that orders from most specific to least specific,
+// which is useful for ordering firewall or routing rules so that the more specific rules come first.
+//
+// Items with a longer prefix length come before those with a shorter one, with ties broken by low value, then high value.
+// An item with no prefix length, such as an individual address or the subnet "1.2.*.*",
+// is treated as having the prefix length of the smallest prefix block containing its values, such as 32 or 16 respectively.
+// Sequential ranges, which have no prefix length, are treated in the same way, so the range "1.2.3.4 -> 1.2.3.4" has specificity 32,
+// the range "1.0.0.0 -> 1.255.255.255" has specificity 8, and a range that is not a prefix block, such as "1.2.3.4 -> 1.2.3.5", has specificity 32.
+// As with the other comparators, IPv4 items come before IPv6 items.
+func SpecificityComparator() AddressComparator {
+	return AddressComparator{specificityComparator{}}
+}
+
+// SortBySpecificity sorts the given addresses from most specific to least specific, in the order of SpecificityComparator.
+func SortBySpecificity(addrs []*IPAddress) {
+	comparator := SpecificityComparator()
+	sort.Slice(addrs, func(i, j int) bool {
+		return comparator.CompareAddresses(addrs[i], addrs[j]) < 0
+	})
+}
+
 type groupingType int
 
 type divType int
@@ -865,6 +890,90 @@ func (comp valueComparator) compareParts(oneSeries, twoSeries AddressDivisionSer
 	return 0
 }
 
+// specificityComparator compares by specificity first, with more specific items first, then by low value, then high.
+type specificityComparator struct {
+	valueComparator
+}
+
+func (comp specificityComparator) compareSectionParts(one, two *AddressSection) int {
+	if result := compareSpecificity(one, two); result != 0 {
+		return result
+	}
+	return comp.valueComparator.compareSectionParts(one, two)
+}
+
+func (comp specificityComparator) compareParts(one, two AddressDivisionSeries) int {
+	if one.GetBitCount() == two.GetBitCount() {
+		if result := compareSpecificity(one, two); result != 0 {
+			return result
+		}
+	}
+	return comp.valueComparator.compareParts(one, two)
+}
+
+// compareSpecificity compares the specificity of two series with the same bit count,
+// returning a negative integer if one is more specific than two.
+func compareSpecificity(one, two AddressDivisionSeries) int {
+	return int(getSpecificity(two) - getSpecificity(one))
+}
+
+func (comp specificityComparator) compareSegValues(oneUpper, oneLower, twoUpper, twoLower SegInt) int {
+	if result := compareHostBits(uint64(oneUpper), uint64(oneLower), uint64(twoUpper), uint64(twoLower)); result != 0 {
+		return result
+	}
+	return comp.valueComparator.compareSegValues(oneUpper, oneLower, twoUpper, twoLower)
+}
+
+func (comp specificityComparator) compareValues(oneUpper, oneLower, twoUpper, twoLower uint64) int {
+	if result := compareHostBits(oneUpper, oneLower, twoUpper, twoLower); result != 0 {
+		return result
+	}
+	return comp.valueComparator.compareValues(oneUpper, oneLower, twoUpper, twoLower)
+}
+
+func (comp specificityComparator) compareLargeValues(oneUpper, oneLower, twoUpper, twoLower *big.Int) int {
+	if result := getLargeHostBits(oneUpper, oneLower) - getLargeHostBits(twoUpper, twoLower); result != 0 {
+		return result
+	}
+	return comp.valueComparator.compareLargeValues(oneUpper, oneLower, twoUpper, twoLower)
+}
+
+// compareHostBits compares the specificity of two ranges of values of the same bit count, such as sequential ranges,
+// returning a negative integer if one is more specific than two.
+// Since ranges have no prefix length, a range of values is treated as having the prefix length of the prefix block it matches,
+// or the full bit count if it matches no prefix block, in the same way as getSpecificity treats a series with no prefix length.
+func compareHostBits(oneUpper, oneLower, twoUpper, twoLower uint64) int {
+	return getHostBits(oneUpper, oneLower) - getHostBits(twoUpper, twoLower)
+}
+
+// getHostBits returns the number of host bits of the prefix block with the given range of values,
+// or zero if the range of values is not a prefix block.
+func getHostBits(upper, lower uint64) int {
+	if diff := upper - lower; diff&(diff+1) == 0 && lower&diff == 0 {
+		return bits.OnesCount64(diff)
+	}
+	return 0
+}
+
+// getLargeHostBits is the same as getHostBits for values of any size.
+func getLargeHostBits(upper, lower *big.Int) int {
+	diff := new(big.Int).Sub(upper, lower)
+	next := new(big.Int).Add(diff, bigOneConst())
+	if next.And(next, diff).Sign() == 0 && new(big.Int).And(lower, diff).Sign() == 0 {
+		return diff.BitLen()
+	}
+	return 0
+}
+
+// getSpecificity returns the prefix length of the series, or if it has none,
+// the prefix length of the smallest prefix block containing its values.
+func getSpecificity(series AddressDivisionSeries) BitCount {
+	if prefLen := series.GetPrefixLen(); prefLen != nil {
+		return prefLen.bitCount()
+	}
+	return series.GetMinPrefixLenForBlock()
+}
+
 // Note: never called with an address instance, never called with an instance of AddressType
 func getCount(item AddressItem) (b *big.Int, u uint64) {
 	if sect, ok := item.(StandardDivGroupingType); ok {
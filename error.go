@@ -50,6 +50,15 @@ type addressValueError struct {
 	val int
 }
 
+type addressValueNestedError struct {
+	addressValueError
+	nested error
+}
+
+func (a *addressValueNestedError) Error() string {
+	return a.addressError.Error() + ": " + a.nested.Error()
+}
+
 type mergedError struct {
 	address_error.AddressError
 	merged []address_error.AddressError
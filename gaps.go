@@ -0,0 +1,91 @@
+package goip
+
+type gapAddress[T any] interface {
+	AddressType
+	GetLower() T
+	GetUpper() T
+	WithoutPrefixLen() T
+	Increment(int64) T
+	SpanWithPrefixBlocksTo(T) []T
+}
+
+// gapBetween returns the prefix blocks spanning the addresses strictly between the given upper bound and the given lower bound,
+// or nil if there are no such addresses.
+func gapBetween[T gapAddress[T]](upper, lower T) []T {
+	if compareLowIPAddressValues(upper, lower) >= 0 {
+		return nil
+	}
+
+	// since upper is below lower, neither incrementing nor decrementing can overflow
+	first, last := upper.WithoutPrefixLen().Increment(1), lower.WithoutPrefixLen().Increment(-1)
+	if compareLowIPAddressValues(first, last) > 0 {
+		return nil // adjacent
+	}
+	return first.SpanWithPrefixBlocksTo(last)
+}
+
+func gapTo[T gapAddress[T]](addr, other T) []T {
+	return gapBetween(addr.GetUpper(), other.GetLower())
+}
+
+// GapTo returns the list of prefix blocks spanning all the addresses strictly between the upper bound of this subnet
+// and the lower bound of the given subnet, sorted from lowest to highest.
+// For instance, the gap from "1.2.3.0/24" to "1.2.6.0/24" is "1.2.4.0/23".
+//
+// If the two subnets are adjacent or overlapping, or if the given subnet is below this one, there is no gap and nil is returned.
+// Nil is also returned if the two subnets are not the same version.
+func (addr *IPAddress) GapTo(other *IPAddress) []*IPAddress {
+	if addr == nil || other == nil {
+		return nil
+	}
+	addr = addr.init()
+	other = other.init()
+	if !addr.isIP() || !versionsMatch(addr, other) {
+		return nil
+	}
+	return gapTo(addr, other)
+}
+
+// GapTo returns the list of prefix blocks spanning all the addresses strictly between the upper bound of this subnet
+// and the lower bound of the given subnet, sorted from lowest to highest.
+// See IPAddress.GapTo for details.
+func (addr *IPv4Address) GapTo(other *IPv4Address) []*IPv4Address {
+	if addr == nil || other == nil {
+		return nil
+	}
+	return gapTo(addr.init(), other.init())
+}
+
+// GapTo returns the list of prefix blocks spanning all the addresses strictly between the upper bound of this subnet
+// and the lower bound of the given subnet, sorted from lowest to highest.
+// See IPAddress.GapTo for details.
+func (addr *IPv6Address) GapTo(other *IPv6Address) []*IPv6Address {
+	if addr == nil || other == nil {
+		return nil
+	}
+	return gapTo(addr.init(), other.init())
+}
+
+// FindGaps returns the prefix blocks spanning the unallocated addresses between the given subnets,
+// which are expected to be sorted by their lower address, with IPv4 subnets preceding IPv6 subnets.
+// The addresses below the first subnet and above the last subnet of each version are not included.
+// Nil elements and elements that are neither IPv4 nor IPv6 are ignored.
+//
+// The given subnets may overlap, in which case each gap starts after the highest upper bound of the preceding subnets.
+func FindGaps(subnets []*IPAddress) (gaps []*IPAddress) {
+	var upper *IPAddress
+	for _, subnet := range subnets {
+		if subnet == nil || !subnet.isIP() {
+			continue
+		}
+		if upper == nil || !versionsMatch(upper, subnet) {
+			upper = subnet.GetUpper()
+			continue
+		}
+		gaps = append(gaps, gapBetween(upper, subnet.GetLower())...)
+		if subnetUpper := subnet.GetUpper(); compareLowIPAddressValues(subnetUpper, upper) > 0 {
+			upper = subnetUpper
+		}
+	}
+	return
+}
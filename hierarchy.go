@@ -0,0 +1,84 @@
+package goip
+
+import (
+	"sort"
+
+	"github.com/pchchv/goip/address_error"
+)
+
+// PrefixHierarchy is a containment tree of prefix blocks, as built by BuildPrefixHierarchy.
+type PrefixHierarchy struct {
+	// Root is the root of the tree.
+	// It has a nil Prefix, and its children are the blocks not contained by any other block.
+	Root *PrefixNode
+}
+
+// PrefixNode is a node in a PrefixHierarchy.
+type PrefixNode struct {
+	// Prefix is the prefix block of this node, or nil for the root of the hierarchy.
+	Prefix *IPAddress
+	// Children are the nodes for which this is the smallest containing block, in sorted order.
+	Children []*PrefixNode
+}
+
+// BuildPrefixHierarchy organizes the given prefix blocks into a containment tree,
+// in which the parent of each block is the smallest of the given blocks containing it.
+// Blocks that are not contained by any other block are children of the root.
+//
+// Each element must be a prefix block or an individual address.
+// A block without a prefix length, such as "1.2.*.*", is converted to the equivalent prefix block "1.2.0.0/16".
+// Duplicate blocks appear once, and IPv4 and IPv6 blocks may be mixed, with the IPv4 blocks first.
+//
+// This can be used to visualize a subnet hierarchy,
+// or in a network audit to find supernets that contain other subnets and subnets that have no containing network.
+//
+// An error is returned if an element is nil, is the zero IPAddress with no IP version, or is neither a prefix block nor an individual address.
+func BuildPrefixHierarchy(prefixes []*IPAddress) (*PrefixHierarchy, address_error.AddressError) {
+	blocks := make([]*IPAddress, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if prefix == nil || prefix.getIPVersion().IsIndeterminate() {
+			return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipVersionIndeterminate"}}
+		}
+
+		prefLen := prefix.GetPrefixLenForSingleBlock()
+		if prefLen == nil {
+			return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.address.not.block"}}
+		}
+		blocks = append(blocks, prefix.ToPrefixBlockLen(prefLen.bitCount()))
+	}
+
+	// sort by version, then lower value, with larger blocks before the blocks they contain
+	sort.Slice(blocks, func(i, j int) bool {
+		one, two := blocks[i], blocks[j]
+		if oneIsIPv4, twoIsIPv4 := one.IsIPv4(), two.IsIPv4(); oneIsIPv4 != twoIsIPv4 {
+			return oneIsIPv4
+		}
+
+		if res := one.GetLower().Compare(two.GetLower()); res != 0 {
+			return res < 0
+		}
+		return one.GetPrefixLen().bitCount() < two.GetPrefixLen().bitCount()
+	})
+
+	root := &PrefixNode{}
+	var stack []*PrefixNode // the path of containing nodes leading to the current node
+	for i, block := range blocks {
+		if i > 0 && block.Equal(blocks[i-1]) {
+			continue
+		}
+
+		for len(stack) > 0 && !stack[len(stack)-1].Prefix.Contains(block) {
+			stack = stack[:len(stack)-1]
+		}
+
+		parent := root
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+
+		node := &PrefixNode{Prefix: block}
+		parent.Children = append(parent.Children, node)
+		stack = append(stack, node)
+	}
+	return &PrefixHierarchy{Root: root}, nil
+}
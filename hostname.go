@@ -411,6 +411,47 @@ func (host *HostName) GetAddress() *IPAddress {
 	return addr
 }
 
+// GetIPv4Address returns the IPv4 address of this host name.
+// If this represents an IPv4 address, returns that address.
+// If this represents a host, returns the first resolved IPv4 address of that host.
+//
+// An error is returned if this represents an IPv6 address, or if the host cannot be resolved to an IPv4 address.
+func (host *HostName) GetIPv4Address() (*IPv4Address, address_error.AddressError) {
+	addr, err := host.toVersionedAddress(IPv4)
+	if err != nil {
+		return nil, err
+	}
+	return addr.ToIPv4(), nil
+}
+
+// GetIPv6Address returns the IPv6 address of this host name.
+// If this represents an IPv6 address, returns that address.
+// If this represents a host, returns the first resolved IPv6 address of that host.
+//
+// An error is returned if this represents an IPv4 address, or if the host cannot be resolved to an IPv6 address.
+func (host *HostName) GetIPv6Address() (*IPv6Address, address_error.AddressError) {
+	addr, err := host.toVersionedAddress(IPv6)
+	if err != nil {
+		return nil, err
+	}
+	return addr.ToIPv6(), nil
+}
+
+// toVersionedAddress returns the first address of the given version resolved by ToAddresses.
+func (host *HostName) toVersionedAddress(version IPVersion) (*IPAddress, address_error.AddressError) {
+	addrs, err := host.ToAddresses()
+	for _, addr := range addrs {
+		if addr.getIPVersion() == version {
+			return addr, nil
+		}
+	}
+
+	if err == nil {
+		err = &hostNameError{addressError{str: host.String(), key: "ipaddress.error.ipVersionMismatch"}}
+	}
+	return nil, err
+}
+
 // AsAddressString returns the address string if this host name represents an ip address or an ip address string.
 // Otherwise, this returns nil.
 // Note that translation includes prefix lengths and IPv6 zones.
@@ -678,6 +719,35 @@ func (host *HostName) GetHost() string {
 	return ""
 }
 
+// ToHTTPURL returns a URL as in RFC 3986 with this host as the URL host and with the port of this host, if any,
+// such as "http://example.com:8080/path" or "http://[::1]:8080/path".
+//
+// An empty scheme defaults to "http", and a non-empty path is given a leading slash if it does not have one.
+// If this host is an IP address, the URL host is formed as with IPAddress.ToHTTPURL.
+//
+// An error is returned if this host is invalid, if the scheme is invalid,
+// or if this host is an address string that is not a single address.
+func (host *HostName) ToHTTPURL(scheme, path string) (string, address_error.AddressError) {
+	host = host.init()
+	if err := host.Validate(); err != nil {
+		return "", err
+	}
+
+	var port PortInt
+	if p := host.parsedHost.getPort(); p != nil {
+		port = p.portNum()
+	}
+
+	if host.IsAddressString() {
+		addr := host.AsAddress()
+		if addr == nil || addr.IsMultiple() {
+			return "", &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+		}
+		return toHTTPURLString(scheme, toURLHostString(addr), port, path)
+	}
+	return toHTTPURLString(scheme, host.parsedHost.getHost(), port, path)
+}
+
 // Wrap wraps this host name, returning a WrappedHostName, an implementation of ExtendedIdentifierString,
 // which can be used to write code that works with a host identifier string including [IPAddressString], [MACAddressString], and [HostName].
 func (host *HostName) Wrap() ExtendedIdentifierString {
@@ -881,6 +951,52 @@ func toNormalizedAddrPortString(addr *IPAddress, port PortInt) string {
 	return builder.String()
 }
 
+func toURLHostString(addr *IPAddress) string {
+	if addr.isIPv6() {
+		builder := strings.Builder{}
+		builder.WriteByte(IPv6StartBracket)
+		translateReserved(addr.ToIPv6(), addr.WithoutPrefixLen().ToCanonicalString(), &builder)
+		builder.WriteByte(IPv6EndBracket)
+		return builder.String()
+	}
+	return addr.WithoutPrefixLen().ToCanonicalString()
+}
+
+func toHTTPURLString(scheme, host string, port PortInt, path string) (string, address_error.AddressError) {
+	if scheme == "" {
+		scheme = "http"
+	} else if !isURLScheme(scheme) {
+		return "", &addressValueError{addressError: addressError{key: "ipaddress.error.url.scheme"}}
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(strings.ToLower(scheme))
+	builder.WriteString("://")
+	builder.WriteString(host)
+	if port != 0 {
+		toNormalizedPortString(port, &builder)
+	}
+
+	if path != "" && path[0] != '/' {
+		builder.WriteByte('/')
+	}
+	builder.WriteString(path)
+	return builder.String(), nil
+}
+
+// isURLScheme returns whether the scheme has the syntax of RFC 3986: ALPHA *( ALPHA / DIGIT / "+" / "-" / "." )
+func isURLScheme(scheme string) bool {
+	for i := 0; i < len(scheme); i++ {
+		c := scheme[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+			continue
+		} else if i == 0 || !((c >= '0' && c <= '9') || c == '+' || c == '-' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
 func newHostNameFromSocketAddr(ip net.IP, port int, zone string) (hostName *HostName, err address_error.AddressValueError) {
 	var ipAddr *IPAddress
 	ipAddr, err = NewIPAddressFromNetIPAddr(&net.IPAddr{IP: ip, Zone: zone})
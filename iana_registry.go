@@ -0,0 +1,99 @@
+package goip
+
+// IANAAddressRegistration is an entry of the IANA IPv4 Special-Purpose Address Registry, described by RFC 6890.
+type IANAAddressRegistration struct {
+	// Block is the registered address block.
+	Block *IPv4Address
+	// Name is the name of the registration, such as "Private-Use" or "Loopback".
+	Name string
+	// RFC is the document defining the registration, such as "RFC 1918".
+	RFC string
+	// Forwardable indicates whether a router may forward a packet with a destination address in the block.
+	Forwardable bool
+	// GloballyRoutable indicates whether an address in the block is reachable on the global internet.
+	GloballyRoutable bool
+	// Reserved indicates whether the block is reserved by the protocol, making it special in the base specification of IPv4.
+	Reserved bool
+}
+
+// ipv4SpecialRegistry is the IANA IPv4 Special-Purpose Address Registry,
+// sorted by address, with each block preceding the blocks it contains.
+var ipv4SpecialRegistry = []*IANAAddressRegistration{
+	{newIANABlock(0x00000000, 8), "This network", "RFC 791", false, false, true},
+	{newIANABlock(0x00000000, 32), "This host on this network", "RFC 1122", false, false, true},
+	{newIANABlock(0x0a000000, 8), "Private-Use", "RFC 1918", true, false, false},
+	{newIANABlock(0x64400000, 10), "Shared Address Space", "RFC 6598", true, false, false},
+	{newIANABlock(0x7f000000, 8), "Loopback", "RFC 1122", false, false, true},
+	{newIANABlock(0xa9fe0000, 16), "Link Local", "RFC 3927", false, false, true},
+	{newIANABlock(0xac100000, 12), "Private-Use", "RFC 1918", true, false, false},
+	{newIANABlock(0xc0000000, 24), "IETF Protocol Assignments", "RFC 6890", false, false, false},
+	{newIANABlock(0xc0000000, 29), "IPv4 Service Continuity Prefix", "RFC 7335", true, false, false},
+	{newIANABlock(0xc0000008, 32), "IPv4 dummy address", "RFC 7600", false, false, false},
+	{newIANABlock(0xc0000009, 32), "Port Control Protocol Anycast", "RFC 7723", true, true, false},
+	{newIANABlock(0xc000000a, 32), "Traversal Using Relays around NAT Anycast", "RFC 8155", true, true, false},
+	{newIANABlock(0xc00000aa, 32), "NAT64/DNS64 Discovery", "RFC 8880", false, false, true},
+	{newIANABlock(0xc00000ab, 32), "NAT64/DNS64 Discovery", "RFC 8880", false, false, true},
+	{newIANABlock(0xc0000200, 24), "Documentation (TEST-NET-1)", "RFC 5737", false, false, false},
+	{newIANABlock(0xc01fc400, 24), "AS112-v4", "RFC 7535", true, true, false},
+	{newIANABlock(0xc034c100, 24), "AMT", "RFC 7450", true, true, false},
+	{newIANABlock(0xc0586300, 24), "Deprecated (6to4 Relay Anycast)", "RFC 7526", false, false, false},
+	{newIANABlock(0xc0586302, 32), "6a44-relay anycast address", "RFC 6751", true, true, false},
+	{newIANABlock(0xc0a80000, 16), "Private-Use", "RFC 1918", true, false, false},
+	{newIANABlock(0xc0af3000, 24), "Direct Delegation AS112 Service", "RFC 7534", true, true, false},
+	{newIANABlock(0xc6120000, 15), "Benchmarking", "RFC 2544", true, false, false},
+	{newIANABlock(0xc6336400, 24), "Documentation (TEST-NET-2)", "RFC 5737", false, false, false},
+	{newIANABlock(0xcb007100, 24), "Documentation (TEST-NET-3)", "RFC 5737", false, false, false},
+	{newIANABlock(0xf0000000, 4), "Reserved", "RFC 1112", false, false, true},
+	{newIANABlock(0xffffffff, 32), "Limited Broadcast", "RFC 919", false, false, true},
+}
+
+// ipv4NonGlobalRegistryBlocks returns the prefix blocks of the addresses that are not globally routable,
+// which are those of the registry blocks that are not globally reachable, other than those of the reachable blocks nested within them,
+// along with the multicast block, which is not part of the special-purpose registry.
+func ipv4NonGlobalRegistryBlocks() []*IPv4Address {
+	var nonGlobal, global []*IPv4Address
+	for _, entry := range ipv4SpecialRegistry {
+		if entry.GloballyRoutable {
+			global = append(global, entry.Block)
+		} else {
+			nonGlobal = append(nonGlobal, entry.Block)
+		}
+	}
+	nonGlobal = append(nonGlobal, NewIPv4AddressFromPrefixedUint32(0xe0000000, cacheBitCount(4))) // 224.0.0.0/4 multicast
+	return spanNonGlobalBlocks(nonGlobal, global)
+}
+
+// spanNonGlobalBlocks returns the prefix blocks spanning the addresses of the non-global blocks
+// that are not within any of the global blocks, sorted from lowest to highest.
+func spanNonGlobalBlocks[T SequentialRangeConstraint[T]](nonGlobal, global []T) []T {
+	set := &RangeSet[T]{}
+	for _, block := range nonGlobal {
+		set.Add(NewSequentialRange(block.GetLower(), block.GetUpper()))
+	}
+	for _, block := range global {
+		set.Remove(NewSequentialRange(block.GetLower(), block.GetUpper()))
+	}
+	return set.ToPrefixBlocks()
+}
+
+func newIANABlock(val uint32, prefLen BitCount) *IPv4Address {
+	return NewIPv4AddressFromPrefixedUint32(val, cacheBitCount(prefLen)).ToPrefixBlock()
+}
+
+// GetIANARegistration returns the entry of the IANA IPv4 Special-Purpose Address Registry for this address or subnet,
+// or nil if this address or subnet is not contained in any registered block, as is the case for most unicast addresses.
+//
+// When registered blocks are nested, such as "192.0.0.0/24" and "192.0.0.9/32", the entry for the smallest block containing this address or subnet is returned.
+// The registry is embedded in the package, so no network lookup is performed.
+func (addr *IPv4Address) GetIANARegistration() *IANAAddressRegistration {
+	addr = addr.init()
+	var result *IANAAddressRegistration
+	for _, entry := range ipv4SpecialRegistry {
+		if entry.Block.Contains(addr) {
+			result = entry // later entries are nested within earlier ones
+		} else if entry.Block.GetLower().Compare(addr.GetLower()) > 0 {
+			break
+		}
+	}
+	return result
+}
@@ -39,7 +39,7 @@ func checkOverflowBig(increment int64, bigIncrement, lowerValue, upperValue, cou
 			bigIncrement.Sub(bigIncrement, count.Sub(count, bigOneConst()))
 		}
 		maxVal := maxValue()
-		if bigIncrement.CmpAbs(maxVal.Sub(maxVal, upperValue)) > 0 {
+		if bigIncrement.Cmp(maxVal.Sub(maxVal, upperValue)) > 0 {
 			return true
 		}
 	}
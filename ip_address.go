@@ -1,10 +1,16 @@
 package goip
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"net"
 	"net/netip"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	"github.com/pchchv/goip/address_error"
@@ -19,6 +25,11 @@ const (
 	PrefixLenSeparatorStr            = "/"
 )
 
+const (
+	socks5AddrTypeIPv4 byte = 0x01
+	socks5AddrTypeIPv6 byte = 0x04
+)
+
 var zeroIPAddr = createIPAddress(zeroSection, NoZone)
 
 // IPAddressValueProvider supplies all the values that incorporate an IPAddress instance.
@@ -796,6 +807,19 @@ func (addr *IPAddress) ToPrefixBlockLen(prefLen BitCount) *IPAddress {
 	return addr.init().toPrefixBlockLen(prefLen).ToIP()
 }
 
+// FirstNBits returns the address whose first n bits match those of this address, with the remaining bits zero, and with prefix length n.
+// For example, for "10.1.2.3" and n of 16, it returns "10.1.0.0/16".
+// If this is a subnet, the first n bits are taken from the lowest address in the subnet.
+//
+// This is equivalent to ToPrefixBlockLen(n).GetLower(), except that an error is returned if n is negative or exceeds the bit count.
+func (addr *IPAddress) FirstNBits(n BitCount) (*IPAddress, address_error.AddressValueError) {
+	addr = addr.init()
+	if n < 0 || n > addr.GetBitCount() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.prefixSize"}, val: n}
+	}
+	return addr.ToPrefixBlockLen(n).GetLower(), nil
+}
+
 // GetCount returns the count of addresses that this address or subnet represents.
 //
 // If just a single address, not a subnet of multiple addresses, returns 1.
@@ -810,12 +834,57 @@ func (addr *IPAddress) GetCount() *big.Int {
 	return addr.getCount()
 }
 
+// UsableHostCount returns the number of usable host addresses in the prefix block of this address or subnet,
+// or in this subnet if it has no prefix length.
+// For IPv4, this is IPv4Address.UsableHostCount, which excludes the network and broadcast addresses from blocks of more than two addresses.
+// For IPv6, this is IPv6Address.UsableHostCount, which includes all addresses.
+func (addr *IPAddress) UsableHostCount() *big.Int {
+	if addr == nil {
+		return bigZero()
+	} else if ipv4 := addr.ToIPv4(); ipv4 != nil {
+		return new(big.Int).SetInt64(ipv4.UsableHostCount())
+	} else if ipv6 := addr.ToIPv6(); ipv6 != nil {
+		return ipv6.UsableHostCount()
+	}
+	return addr.GetCount()
+}
+
 // IsMultiple returns true if this represents more than a single individual address,
 // whether it is a subnet of multiple addresses.
 func (addr *IPAddress) IsMultiple() bool {
 	return addr != nil && addr.isMultiple()
 }
 
+// IsHost returns true if this represents a single individual host address,
+// one that is neither a subnet of multiple addresses nor a prefix block,
+// equivalent to !IsMultiple() && !IsPrefixBlock().
+//
+// This is useful when validating input that should accept only host addresses and not subnets.
+// For instance, "1.2.3.4" and "1.2.3.4/16" are hosts, while "1.2.3.*", "1.2.0.0/16", and "1.2.3.4/32" are not.
+func (addr *IPAddress) IsHost() bool {
+	if addr == nil {
+		return false
+	}
+	addr = addr.init()
+	return !addr.isMultiple() && !addr.IsPrefixBlock()
+}
+
+// IsPrefixBlockForLength returns whether this is exactly the prefix block for the given prefix length,
+// a single prefix value of that length with all possible host values, equivalent to ContainsSinglePrefixBlock.
+// The assigned prefix length, if any, is not considered, only the range of values.
+//
+// This differs from ContainsPrefixBlock, which is also true for subnets spanning multiple prefix blocks of the given length,
+// such as "1.2.0.0/16" for length 24.
+//
+// This is useful for validating that a CIDR block is in canonical form.
+// For instance, "1.2.0.0/16" and "1.2.*.*" are prefix blocks for length 16, while "1.2.3.0/16" and "1.2.0.0/24" are not.
+func (addr *IPAddress) IsPrefixBlockForLength(n BitCount) bool {
+	if addr == nil {
+		return false
+	}
+	return addr.init().ContainsSinglePrefixBlock(n)
+}
+
 // GetSection returns the backing section for this address or subnet, comprising all segments.
 func (addr *IPAddress) GetSection() *IPAddressSection {
 	return addr.init().section.ToIP()
@@ -848,6 +917,20 @@ func (addr *IPAddress) GetByteCount() int {
 	return addr.addressInternal.GetByteCount()
 }
 
+// Bits returns the number of bits comprising this address as a plain int,
+// which is 32 for IPv4, 128 for IPv6, and 0 for the zero IPAddress.
+// It is equivalent to GetBitCount.
+func (addr *IPAddress) Bits() int {
+	return addr.GetBitCount()
+}
+
+// ByteCount returns the number of bytes required for this address,
+// which is 4 for IPv4, 16 for IPv6, and 0 for the zero IPAddress.
+// It is equivalent to GetByteCount, and is not to be confused with Bytes, which returns the address bytes.
+func (addr *IPAddress) ByteCount() int {
+	return addr.GetByteCount()
+}
+
 // GetLowerIPAddress returns the address in the subnet or address collection with the lowest numeric value,
 // which will be the receiver if it represents a single address.
 // For example, for "1.2-3.4.5-6", the series "1.2.4.5" is returned.
@@ -885,6 +968,24 @@ func (addr *IPAddress) AdjustPrefixLen(prefixLen BitCount) *IPAddress {
 	return addr.init().adjustPrefixLen(prefixLen).ToIP()
 }
 
+// GrowPrefix increases the prefix length by one, equivalent to AdjustPrefixLen(1).
+// The address bits are not zeroed, use AdjustPrefixLenZeroed for that.
+//
+// If this address has no prefix length, the prefix length starts from zero, so the result has prefix length 1.
+// The prefix length will not be increased beyond the bit length of the address.
+func (addr *IPAddress) GrowPrefix() *IPAddress {
+	return addr.AdjustPrefixLen(1)
+}
+
+// ShrinkPrefix decreases the prefix length by one, equivalent to AdjustPrefixLen(-1).
+//
+// If this address has no prefix length, the prefix length starts from the bit length of the address,
+// so the result has prefix length 31 for IPv4 or 127 for IPv6.
+// The prefix length will not be decreased below zero.
+func (addr *IPAddress) ShrinkPrefix() *IPAddress {
+	return addr.AdjustPrefixLen(-1)
+}
+
 // AdjustPrefixLenZeroed increases or decreases the prefix length by
 // the given increment while zeroing out the bits that have moved into or outside the prefix.
 //
@@ -915,6 +1016,49 @@ func (addr *IPAddress) UpperBytes() []byte {
 	return addr.init().section.UpperBytes()
 }
 
+// ToBase64String returns the lowest address in this subnet or address encoded in base64,
+// using the URL-safe alphabet of RFC 4648 when urlSafe is true, and the standard alphabet otherwise.
+// The encoding is of the raw address bytes only, 4 bytes for IPv4 and 16 bytes for IPv6,
+// so the prefix length is not included.  Use NewIPAddressFromBase64 to decode.
+func (addr *IPAddress) ToBase64String(urlSafe bool) string {
+	return getBase64Encoding(urlSafe).EncodeToString(addr.Bytes())
+}
+
+// ToSocks5Address returns the address in the SOCKS5 wire format of RFC 1928,
+// the address type byte (0x01 for IPv4, 0x04 for IPv6) followed by the address bytes in network byte order.
+//
+// An error is returned if this is a subnet with multiple addresses, or if it is the zero IPAddress with no IP version.
+func (addr *IPAddress) ToSocks5Address() ([]byte, address_error.AddressError) {
+	addr = addr.init()
+	var addrType byte
+	if addr.IsIPv4() {
+		addrType = socks5AddrTypeIPv4
+	} else if addr.IsIPv6() {
+		addrType = socks5AddrTypeIPv6
+	} else {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipVersionIndeterminate"}}
+	}
+
+	if addr.IsMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	}
+
+	bytes := make([]byte, 1, 1+addr.GetByteCount())
+	bytes[0] = addrType
+	return append(bytes, addr.Bytes()...), nil
+}
+
+// ToProtoBytes returns the lowest address in this subnet or address in the form used for IP addresses in proto3 bytes fields,
+// 4 bytes in network byte order for IPv4 and 16 bytes for IPv6.
+// Unlike net.IP, an IPv4 address is never given in the 16-byte IPv4-mapped form.
+// The prefix length and any IPv6 zone are not included.  Use NewIPAddressFromProtoBytes to decode.
+//
+// This is the method to use for interoperability with Protocol Buffers and gRPC.
+// The zero IPAddress with no IP version returns an empty slice.
+func (addr *IPAddress) ToProtoBytes() []byte {
+	return addr.Bytes()
+}
+
 // GetNetIP returns the lowest address in this subnet or address as a net.IP.
 func (addr *IPAddress) GetNetIP() net.IP {
 	return addr.Bytes()
@@ -1018,6 +1162,29 @@ func (addr *IPAddress) GetSegments() []*IPAddressSegment {
 	return addr.GetSection().GetSegments()
 }
 
+// ToSegmentBytesSlice returns a slice with the bytes of each segment, from most-significant to least,
+// each element having 1 byte for IPv4 or 2 bytes for IPv6.
+// For a subnet, the bytes of the lowest value of each segment are used.
+func (addr *IPAddress) ToSegmentBytesSlice() [][]byte {
+	res := make([][]byte, addr.GetSegmentCount())
+	addr.ForEachSegment(func(segmentIndex int, segment *IPAddressSegment) bool {
+		res[segmentIndex] = segment.Bytes()
+		return false
+	})
+	return res
+}
+
+// ToSegmentIntsSlice returns a slice with the value of each segment, from most-significant to least.
+// For a subnet, the lowest value of each segment is used.
+func (addr *IPAddress) ToSegmentIntsSlice() []uint32 {
+	res := make([]uint32, addr.GetSegmentCount())
+	addr.ForEachSegment(func(segmentIndex int, segment *IPAddressSegment) bool {
+		res[segmentIndex] = uint32(segment.GetSegmentValue())
+		return false
+	})
+	return res
+}
+
 // ForEachSegment visits each segment in order from most-significant to least, the most significant with index 0,
 // calling the given function for each, terminating early if the function returns true.
 // Returns the number of visited segments.
@@ -1114,6 +1281,15 @@ func (addr *IPAddress) IncludesMax() bool {
 	return addr.init().section.IncludesMax()
 }
 
+// IsFullRange returns whether this address covers the entire address space of its IP version,
+// such as "0.0.0.0/0", "::/0" or "*.*.*.*".
+//
+// This is true if and only if both IncludesZero and IncludesMax return true.
+// The zero value IPAddress has no IP version, so it returns false.
+func (addr *IPAddress) IsFullRange() bool {
+	return addr != nil && !addr.getIPVersion().IsIndeterminate() && addr.section.IsFullRange()
+}
+
 // TestBit returns true if the bit in the lower value of this address at the given index is 1,
 // where index 0 refers to the least significant bit.
 // In other words, it computes (bits & (1 << n)) != 0), using the lower value of this address.
@@ -1129,6 +1305,69 @@ func (addr *IPAddress) IsOneBit(bitIndex BitCount) bool {
 	return addr.init().isOneBit(bitIndex)
 }
 
+// IsOdd returns whether the least significant bit of the lower value of this address is 1,
+// which for a /31 subnet used with point-to-point links as in RFC 3021 indicates the higher address of the pair.
+// For a subnet, the lower value is used.
+func (addr *IPAddress) IsOdd() bool {
+	if addr == nil {
+		return false
+	}
+
+	addr = addr.init()
+	return addr.GetBitCount() > 0 && addr.testBit(0)
+}
+
+// IsEven returns whether the least significant bit of the lower value of this address is 0,
+// which for a /31 subnet used with point-to-point links as in RFC 3021 indicates the lower address of the pair.
+// For a subnet, the lower value is used.
+func (addr *IPAddress) IsEven() bool {
+	return addr != nil && !addr.IsOdd()
+}
+
+// WithBitSet returns an address like this one, with the bit at the given index set to 1 if value is true, or to 0 if value is false,
+// where index 0 refers to the least significant bit, as with TestBit.
+// The prefix length and zone of this address are retained.
+//
+// An error is returned if this address is a subnet with multiple values,
+// or if n < 0 or n matches or exceeds the bit count of this address.
+func (addr *IPAddress) WithBitSet(n BitCount, value bool) (*IPAddress, address_error.AddressError) {
+	addr = addr.init()
+	if addr.isMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	} else if n < 0 || n >= addr.GetBitCount() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.exceeds.size"}, val: int(n)}
+	}
+
+	bytes := addr.Bytes()
+	byteIndex := len(bytes) - 1 - int(n>>3)
+	mask := byte(1) << (n & 7)
+	if value {
+		bytes[byteIndex] |= mask
+	} else {
+		bytes[byteIndex] &^= mask
+	}
+
+	// the prefix length is applied afterwards, so that clearing the host bits does not produce the prefix block
+	var res *IPAddress
+	if addr.IsIPv4() {
+		ipv4Addr, err := NewIPv4AddressFromBytes(bytes)
+		if err != nil {
+			return nil, err
+		}
+		res = ipv4Addr.ToIP()
+	} else {
+		ipv6Addr, err := NewIPv6AddressFromZonedBytes(bytes, string(addr.zone))
+		if err != nil {
+			return nil, err
+		}
+		res = ipv6Addr.ToIP()
+	}
+	if prefLen := addr.getPrefixLen(); prefLen != nil {
+		res = res.SetPrefixLen(prefLen.bitCount())
+	}
+	return res, nil
+}
+
 // GetMaxSegmentValue returns the maximum possible segment value for this type of address.
 //
 // Note this is not the maximum of the range of segment values in this specific address,
@@ -1150,6 +1389,51 @@ func (addr *IPAddress) Iterator() Iterator[*IPAddress] {
 	return ipAddrIterator{addr.init().addrIterator(nil)}
 }
 
+// PrefixLengthIterator provides an iterator to iterate through the prefix blocks of this address or subnet for each prefix length,
+// from prefix length 0 to the bit count, 33 for IPv4 and 129 for IPv6 addresses in total.
+// Each iterated element is the result of ToPrefixBlockLen for the corresponding prefix length.
+//
+// This is useful for algorithms that try all prefix lengths, such as finding the smallest containing block.
+func (addr *IPAddress) PrefixLengthIterator() Iterator[*IPAddress] {
+	if addr == nil {
+		return nilIterator[*IPAddress]()
+	}
+	addr = addr.init()
+	return &prefixBlockLenIterator[*IPAddress]{original: addr, bitCount: addr.GetBitCount()}
+}
+
+// SupernetIterator provides an iterator to iterate through the prefix blocks containing this address or subnet,
+// from the smallest to the largest, the prefix block of length zero.
+// The first iterated element is the prefix block of this address, as returned by ToPrefixBlock,
+// or the smallest prefix block covering this address or subnet if it has no prefix length, as returned by CoverWithPrefixBlock.
+// The iterator constructs each block only as it is iterated.
+// There are no blocks for a nil address or the zero IPAddress with no IP version.
+func (addr *IPAddress) SupernetIterator() Iterator[*IPAddress] {
+	if addr == nil || addr.getIPVersion().IsIndeterminate() {
+		return &sliceIterator[*IPAddress]{}
+	}
+
+	addr = addr.init()
+	prefLen := addr.CoverWithPrefixBlock().GetPrefixLen()
+	if addr.IsPrefixed() && addr.GetPrefixLen().bitCount() < prefLen.bitCount() {
+		prefLen = addr.GetPrefixLen()
+	}
+	return &supernetIterator[*IPAddress]{original: addr, prefLen: prefLen.bitCount()}
+}
+
+// Supernets returns all the prefix blocks containing this address or subnet, from the smallest to the largest,
+// starting with the prefix block of this address and ending with "0.0.0.0/0" or "::/0".
+// For an address with prefix length 24, it returns 25 blocks, with prefix lengths 24, 23, ..., 0.
+//
+// Use SupernetIterator to construct the blocks only as needed.
+func (addr *IPAddress) Supernets() []*IPAddress {
+	var result []*IPAddress
+	for iter := addr.SupernetIterator(); iter.HasNext(); {
+		result = append(result, iter.Next())
+	}
+	return result
+}
+
 // BlockIterator iterates through the addresses that can be obtained by iterating through all the upper segments up to the given segment count.
 // The segments following remain the same in all iterated addresses.
 //
@@ -1260,6 +1544,23 @@ func (addr *IPAddress) GetHostMask() *IPAddress {
 	return addr.getHostMask(addr.getNetwork())
 }
 
+// ToMaskPair returns both the network mask and the wildcard mask, also known as the host mask,
+// for the prefix length of this address or subnet.
+// For example, for "192.168.0.0/16" it returns "255.255.0.0" and "0.0.255.255".
+//
+// An error is returned if this address has no prefix length.
+func (addr *IPAddress) ToMaskPair() (networkMask, wildcardMask *IPAddress, err address_error.AddressValueError) {
+	if thisAddr := addr.ToIPv4(); thisAddr != nil {
+		var ipv4NetworkMask, ipv4WildcardMask *IPv4Address
+		ipv4NetworkMask, ipv4WildcardMask, err = thisAddr.ToMaskPair()
+		return ipv4NetworkMask.ToIP(), ipv4WildcardMask.ToIP(), err
+	} else if !addr.IsPrefixed() {
+		err = &addressValueError{addressError: addressError{key: "ipaddress.error.no.prefix.length"}}
+		return
+	}
+	return addr.GetNetworkMask(), addr.GetHostMask(), nil
+}
+
 // IsZeroHostLen returns whether the host section is always zero for all individual addresses in this subnet,
 // for the given prefix length.
 //
@@ -1290,6 +1591,45 @@ func (addr *IPAddress) GetUpperNetNetIPAddr() netip.Addr {
 	return addr.init().getUpperNetNetIPAddr()
 }
 
+// ToNetipAddr returns this address as a netip.Addr, including the zone if this is an IPv6 address with a zone.
+// For a subnet, the lowest address in the subnet is returned, use ToNetipPrefix to preserve the subnet.
+// For the zero IPAddress with no IP version, the zero netip.Addr is returned.
+func (addr *IPAddress) ToNetipAddr() netip.Addr {
+	if addr == nil {
+		return netip.Addr{}
+	}
+	return addr.GetNetNetIPAddr()
+}
+
+// ToNetipPrefix returns this address or subnet as a netip.Prefix, along with true,
+// if it can be represented by a netip.Prefix.
+//
+// A prefix block subnet, such as "1.2.0.0/16" or "1.2.*.*", is returned as its lowest address along with the block prefix length.
+// A single address is returned along with its prefix length if it has one, so "1.2.3.4/16" is returned as "1.2.3.4/16",
+// and otherwise along with the full address bit-length.
+// A subnet that is not a single prefix block cannot be represented, nor can the zero IPAddress with no IP version,
+// in which case the zero netip.Prefix is returned along with false.
+//
+// Since netip.Prefix does not support zones, the zone of an IPv6 address is dropped.
+func (addr *IPAddress) ToNetipPrefix() (netip.Prefix, bool) {
+	if addr == nil {
+		return netip.Prefix{}, false
+	}
+
+	addr = addr.init()
+	if addr.getIPVersion().IsIndeterminate() {
+		return netip.Prefix{}, false
+	}
+
+	var prefLen PrefixLen
+	if !addr.isMultiple() && addr.IsPrefixed() {
+		prefLen = addr.getPrefixLen()
+	} else if prefLen = addr.GetPrefixLenForSingleBlock(); prefLen == nil {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr.getNetNetIPAddr(), prefLen.bitCount()), true
+}
+
 // GetIPVersion returns the IP version of this IP address.
 func (addr *IPAddress) GetIPVersion() IPVersion {
 	if addr == nil {
@@ -1382,6 +1722,31 @@ func (addr *IPAddress) Mask(other *IPAddress) (masked *IPAddress, err address_er
 	return addr.maskPrefixed(other, true)
 }
 
+// ApplySubnetMask applies the given network mask to this address or subnet,
+// and assigns the prefix length of the mask to the result.
+// It is equivalent to Mask followed by SetPrefixLen with the prefix length from GetBlockMaskPrefixLen(true).
+//
+// For instance, applying the mask "255.255.255.0" to "1.2.3.4" gives "1.2.3.0/24".
+//
+// An error is returned if the mask is not a network mask, if the mask is a different version than this,
+// or if applying the mask to this subnet results in a set of addresses that cannot be represented as a sequential range within each segment.
+func (addr *IPAddress) ApplySubnetMask(mask *IPAddress) (*IPAddress, address_error.AddressError) {
+	if mask == nil {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.notNetworkMask"}}
+	}
+
+	prefLen := mask.GetBlockMaskPrefixLen(true)
+	if prefLen == nil {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.notNetworkMask"}}
+	}
+
+	masked, err := addr.Mask(mask)
+	if err != nil {
+		return nil, err
+	}
+	return masked.SetPrefixLen(prefLen.bitCount()), nil
+}
+
 func (addr *IPAddress) maskPrefixed(other *IPAddress, retainPrefix bool) (*IPAddress, address_error.IncompatibleAddressError) {
 	if thisAddr := addr.ToIPv4(); thisAddr != nil {
 		if oth := other.ToIPv4(); oth != nil {
@@ -1419,6 +1784,16 @@ func (addr *IPAddress) IsLocal() bool {
 	return false
 }
 
+// IsOperatorReserved returns whether this address, or all addresses in the subnet, are reserved for use by network operators,
+// such as the IPv4 carrier-grade NAT shared address space "100.64.0.0/10".
+// It returns false for IPv6, which has no such reserved block.  See IPv4Address.IsOperatorReserved.
+func (addr *IPAddress) IsOperatorReserved() bool {
+	if thisAddr := addr.ToIPv4(); thisAddr != nil {
+		return thisAddr.IsOperatorReserved()
+	}
+	return false
+}
+
 // IsLoopback returns whether this address is a loopback address,
 // such as "::1" or "127.0.0.1".
 func (addr *IPAddress) IsLoopback() bool {
@@ -1430,6 +1805,19 @@ func (addr *IPAddress) IsLoopback() bool {
 	return false
 }
 
+// IsGloballyRoutable returns whether this address, or every address in this subnet, can be routed in the global internet.
+// It returns false for private, shared, link local, documentation, loopback, multicast, and unspecified addresses,
+// as well as the other non-routable ranges checked by IPv4Address.IsGloballyRoutable and IPv6Address.IsGloballyRoutable.
+// It returns false if this address is the zero IPAddress, which has no version.
+func (addr *IPAddress) IsGloballyRoutable() bool {
+	if thisAddr := addr.ToIPv4(); thisAddr != nil {
+		return thisAddr.IsGloballyRoutable()
+	} else if thisAddr := addr.ToIPv6(); thisAddr != nil {
+		return thisAddr.IsGloballyRoutable()
+	}
+	return false
+}
+
 // ReverseBytes returns a new address with the bytes reversed.  Any prefix length is dropped.
 //
 // If each segment is more than 1 byte long,
@@ -1501,6 +1889,17 @@ func (addr *IPAddress) toSinglePrefixBlockOrAddress() (*IPAddress, address_error
 	return res, nil
 }
 
+// TryCanonicalBlock returns this address if it is a prefix block, the canonical form of a CIDR block such as "10.0.0.0/8".
+// Otherwise, for addresses such as "10.1.2.3/8" which have host bits set, or addresses with no prefix length, it returns an error.
+//
+// This is useful for validating CIDR input that must not include host bits.
+func (addr *IPAddress) TryCanonicalBlock() (*IPAddress, address_error.AddressValueError) {
+	if addr == nil || !addr.IsPrefixBlock() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.host.bits.set"}}
+	}
+	return addr, nil
+}
+
 // BitwiseOr does the bitwise disjunction with this address or subnet, useful when subnetting.
 // It is similar to Mask which does the bitwise conjunction.
 //
@@ -1514,6 +1913,35 @@ func (addr *IPAddress) BitwiseOr(other *IPAddress) (masked *IPAddress, err addre
 	return addr.bitwiseOrPrefixed(other, true)
 }
 
+// SetHostBits returns the address combining the network bits of this address with the host bits of the given address,
+// the boundary between them determined by the prefix length of this address, which is also the prefix length of the result.
+// For example, for "10.0.0.0/24" and the host address "0.0.0.50", it returns "10.0.0.50/24".
+//
+// An error is returned if this address has no prefix length, if the given address is a different version than this,
+// or if either is a subnet and combining the bits results in a set of addresses that cannot be represented as a sequential range within each segment.
+func (addr *IPAddress) SetHostBits(hostAddr *IPAddress) (*IPAddress, address_error.AddressError) {
+	addr = addr.init()
+	if !addr.IsPrefixed() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.no.prefix.length"}}
+	}
+
+	network, err := addr.WithoutPrefixLen().Mask(addr.GetNetworkMask())
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := hostAddr.WithoutPrefixLen().Mask(addr.GetHostMask())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := network.BitwiseOr(host)
+	if err != nil {
+		return nil, err
+	}
+	return result.SetPrefixLen(addr.GetPrefixLen().bitCount()), nil
+}
+
 func (addr *IPAddress) bitwiseOrPrefixed(other *IPAddress, retainPrefix bool) (*IPAddress, address_error.IncompatibleAddressError) {
 	if thisAddr := addr.ToIPv4(); thisAddr != nil {
 		if oth := other.ToIPv4(); oth != nil {
@@ -1607,6 +2035,36 @@ func (addr *IPAddress) ToMaxHostLen(prefixLength BitCount) (*IPAddress, address_
 	return addr.init().toMaxHostLen(prefixLength)
 }
 
+// ToNetworkBoundary returns the network address for the prefix length of this address or subnet,
+// the lowest address in the prefix block, in which the host bits are all zero.
+// It is equivalent to ToPrefixBlock().GetLower().
+//
+// For instance, the network boundary of "1.2.3.4/16" is "1.2.0.0/16".
+//
+// An error is returned if this address has no prefix length.
+func (addr *IPAddress) ToNetworkBoundary() (*IPAddress, address_error.AddressValueError) {
+	addr = addr.init()
+	if !addr.IsPrefixed() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.no.prefix.length"}}
+	}
+	return addr.ToPrefixBlock().GetLower(), nil
+}
+
+// ToHostBoundary returns the broadcast address for the prefix length of this address or subnet,
+// the highest address in the prefix block, in which the host bits are all one.
+// It is equivalent to ToPrefixBlock().GetUpper().
+//
+// For instance, the host boundary of "1.2.3.4/16" is "1.2.255.255/16".
+//
+// An error is returned if this address has no prefix length.
+func (addr *IPAddress) ToHostBoundary() (*IPAddress, address_error.AddressValueError) {
+	addr = addr.init()
+	if !addr.IsPrefixed() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.no.prefix.length"}}
+	}
+	return addr.ToPrefixBlock().GetUpper(), nil
+}
+
 // SpanWithRange returns an IPAddressSeqRange instance that spans this subnet to the given subnet.
 // If the other address is a different version than this,
 // then the other is ignored, and the result is equivalent to calling ToSequentialRange.
@@ -1714,6 +2172,19 @@ func (addr *IPAddress) Contains(other AddressType) bool {
 	return addr.init().contains(other)
 }
 
+// StrictContains returns whether this address or subnet contains all addresses in the given address or subnet,
+// and also contains at least one address not in the given address or subnet.
+// In other words, it returns whether this is a proper superset of the given address or subnet.
+//
+// Unlike Contains, it returns false when the two represent the same set of addresses.
+func (addr *IPAddress) StrictContains(other AddressType) bool {
+	if addr == nil {
+		return false
+	}
+	addr = addr.init()
+	return addr.contains(other) && !addr.equals(other)
+}
+
 // GetGenericDivision returns the segment at the given index as a DivisionType.
 func (addr *IPAddress) GetGenericDivision(index int) DivisionType {
 	return addr.getDivision(index)
@@ -2060,6 +2531,27 @@ func (addr *IPAddress) ToSequentialRange() *SequentialRange[*IPAddress] {
 	return nil
 }
 
+// SplitToSequentialRanges returns the minimal list of sequential ranges that together represent the same set of individual addresses as this subnet,
+// in increasing order.
+// It applies ToSequentialRange to each subnet from SequentialBlockIterator.
+//
+// For instance, "1.2-3.4.5-6" is split into the ranges "1.2.4.5 -> 1.2.4.6" and "1.3.4.5 -> 1.3.4.6".
+// If this subnet is sequential, the list has the single range from ToSequentialRange.
+//
+// Use GetSequentialBlockCount to get the number of ranges before splitting, as it can be very large.
+func (addr *IPAddress) SplitToSequentialRanges() []*SequentialRange[*IPAddress] {
+	if addr == nil {
+		return nil
+	}
+
+	var res []*SequentialRange[*IPAddress]
+	iter := addr.SequentialBlockIterator()
+	for iter.HasNext() {
+		res = append(res, iter.Next().ToSequentialRange())
+	}
+	return res
+}
+
 // ToNormalizedWildcardString produces a string similar to the normalized string but avoids the CIDR prefix length.
 // CIDR addresses will be shown with wildcards and ranges (denoted by '*' and '-') instead of using the CIDR prefix notation.
 func (addr *IPAddress) ToNormalizedWildcardString() string {
@@ -2158,6 +2650,103 @@ func (addr *IPAddress) ToSegmentedBinaryString() string {
 	return addr.init().toSegmentedBinaryString()
 }
 
+// ToSegmentedHexString writes this IP address segment series as segments of hexadecimal values
+// separated by the segment separator, which is '.' for IPv4 and ':' for IPv6, such as "0x01.0x02.0x03.0x04".
+// Each segment is written with the full number of hex digits for its bit count.
+// When uppercase is true the hex digits are uppercase, and when prefix is true each segment is preceded by the "0x" prefix,
+// or "0X" when uppercase is also true.
+//
+// This complements ToSegmentedBinaryString, and like that string it includes any prefix length.  Any IPv6 zone is not included.
+func (addr *IPAddress) ToSegmentedHexString(uppercase bool, prefix bool) string {
+	if addr == nil {
+		return nilString()
+	}
+
+	addr = addr.init()
+	separator := byte(IPv6SegmentSeparator)
+	if addr.IsIPv4() {
+		separator = IPv4SegmentSeparator
+	}
+
+	builder := new(address_string.IPStringOptionsBuilder).SetRadix(16).SetSeparator(separator).SetExpandedSegments(true).SetUppercase(uppercase)
+	if prefix {
+		if uppercase {
+			builder.SetSegmentStrPrefix(otherHexPrefix)
+		} else {
+			builder.SetSegmentStrPrefix(HexPrefix)
+		}
+	}
+	return addr.getSection().toCustomString(builder.ToOptions())
+}
+
+// ToBitLayoutString returns a multi-line string showing the bit layout of this address,
+// for use in documentation and teaching tools.
+// The first line has the value of each segment, aligned with the bits of that segment in the second line.
+// In the second line, segments are separated by spaces and the prefix length boundary is marked by '|',
+// which comes first or last for a prefix length of zero or of the full bit count.
+// The last line gives the network and host bit counts, or states that there is no prefix length.
+// For "192.168.1.25/24" it is:
+//
+//	192      168      1        25
+//	11000000 10101000 00000001|00011001
+//	network: 24 bits, host: 8 bits
+//
+// For a subnet, the bits shown are those of the lowest address.
+// The format is stable, so it is suitable for golden file comparisons.
+// The zero IPAddress with no IP version returns an empty string.
+func (addr *IPAddress) ToBitLayoutString() string {
+	if addr == nil {
+		return nilString()
+	}
+
+	addr = addr.init()
+	if addr.getIPVersion().IsIndeterminate() {
+		return ""
+	}
+
+	prefLen := addr.GetPrefixLen()
+	bitsPerSegment := addr.GetBitsPerSegment()
+	var labels, bits strings.Builder
+	if prefLen != nil && prefLen.bitCount() == 0 {
+		bits.WriteByte('|')
+		labels.WriteByte(' ')
+	}
+
+	for i, seg := range addr.GetSegments() {
+		segBits := fmt.Sprintf("%0*b", bitsPerSegment, seg.GetSegmentValue())
+		if i > 0 {
+			if segStart := BitCount(i) * bitsPerSegment; prefLen != nil && prefLen.bitCount() == segStart {
+				bits.WriteByte('|')
+			} else {
+				bits.WriteByte(' ')
+			}
+			labels.WriteByte(' ')
+		}
+
+		width := int(bitsPerSegment)
+		if prefLen != nil {
+			if segPrefLen := prefLen.bitCount() - BitCount(i)*bitsPerSegment; segPrefLen > 0 && segPrefLen < bitsPerSegment {
+				segBits = segBits[:segPrefLen] + "|" + segBits[segPrefLen:]
+				width++
+			}
+		}
+		bits.WriteString(segBits)
+		fmt.Fprintf(&labels, "%-*s", width, seg.ToNormalizedString())
+	}
+
+	if prefLen != nil && prefLen.bitCount() == addr.GetBitCount() {
+		bits.WriteByte('|')
+	}
+
+	var summary string
+	if prefLen == nil {
+		summary = "no prefix length"
+	} else {
+		summary = fmt.Sprintf("network: %d bits, host: %d bits", prefLen.bitCount(), addr.GetBitCount()-prefLen.bitCount())
+	}
+	return strings.TrimRight(labels.String(), " ") + "\n" + bits.String() + "\n" + summary
+}
+
 // ToSQLWildcardString create a string similar to that
 // from toNormalizedWildcardString except that it uses SQL wildcards.
 // It uses '%' instead of '*' and also uses the wildcard '_'.
@@ -2211,6 +2800,95 @@ func (addr *IPAddress) ToSubnetString() string {
 	return addr.init().toSubnetString()
 }
 
+// ToIPRangeString produces a string with the hyphenated range notation used by many firewall and VPN configuration tools,
+// such as "10.0.0.1-10.0.0.255".
+//
+// If this is a subnet of multiple addresses that is not a prefix block,
+// the string is the canonical string of the lowest address, followed by '-',
+// followed by the canonical string of the highest address.
+// Otherwise, it is the canonical string given by ToCanonicalString.
+//
+// For subnets that are not sequential, such as "1.2-3.4.5", the range string spans from the lowest to the highest address,
+// and so it includes addresses not in this subnet.  Use SequentialBlockIterator to obtain the sequential blocks.
+func (addr *IPAddress) ToIPRangeString() string {
+	if addr == nil {
+		return nilString()
+	}
+
+	addr = addr.init()
+	if addr.IsMultiple() && !addr.IsPrefixBlock() {
+		return addr.ToSequentialRange().ToString((*IPAddress).ToCanonicalString, RangeSeparatorStr, (*IPAddress).ToCanonicalString)
+	}
+	return addr.ToCanonicalString()
+}
+
+// AnnotationOptions selects the properties that are annotated by IPAddress.ToCustomAnnotatedString.
+type AnnotationOptions struct {
+	// Count annotates the number of hosts, which is the size of the prefix block, or the size of the subnet when there is no prefix length.
+	Count bool
+	// Network annotates the network address, the lowest address of the prefix block.
+	Network bool
+	// Broadcast annotates the IPv4 broadcast address, the highest address of the prefix block.
+	Broadcast bool
+	// NetworkMask annotates the network mask for the prefix length.
+	NetworkMask bool
+	// WildcardMask annotates the wildcard mask, also known as the host mask, for the prefix length.
+	WildcardMask bool
+}
+
+// DefaultAnnotationOptions are the options used by IPAddress.ToAnnotatedString.
+var DefaultAnnotationOptions = AnnotationOptions{Count: true, Network: true, Broadcast: true}
+
+// ToAnnotatedString returns the canonical string followed by the annotations selected by DefaultAnnotationOptions,
+// such as "192.168.0.0/16 (65536 hosts, network: 192.168.0.0, broadcast: 192.168.255.255)".
+func (addr *IPAddress) ToAnnotatedString() string {
+	return addr.ToCustomAnnotatedString(DefaultAnnotationOptions)
+}
+
+// ToCustomAnnotatedString returns the canonical string followed by the parenthesized annotations selected by the given options,
+// such as "10.0.0.0/8 (network mask: 255.0.0.0, wildcard mask: 0.255.255.255)".
+//
+// The network, broadcast and mask annotations are only included when this address has a prefix length,
+// and the broadcast annotation is only included for IPv4.
+// If no annotations apply, the canonical string alone is returned.
+func (addr *IPAddress) ToCustomAnnotatedString(options AnnotationOptions) string {
+	if addr == nil {
+		return nilString()
+	}
+
+	addr = addr.init()
+	str := addr.ToCanonicalString()
+	var annotations []string
+	if options.Count {
+		if count := addr.ToPrefixBlock().GetCount(); count.IsInt64() && count.Int64() == 1 {
+			annotations = append(annotations, "1 host")
+		} else {
+			annotations = append(annotations, count.String()+" hosts")
+		}
+	}
+
+	if addr.IsPrefixed() {
+		block := addr.ToPrefixBlock()
+		if options.Network {
+			annotations = append(annotations, "network: "+block.GetLower().WithoutPrefixLen().ToCanonicalString())
+		}
+		if options.Broadcast && addr.IsIPv4() {
+			annotations = append(annotations, "broadcast: "+block.GetUpper().WithoutPrefixLen().ToCanonicalString())
+		}
+		if options.NetworkMask {
+			annotations = append(annotations, "network mask: "+addr.GetNetworkMask().ToCanonicalString())
+		}
+		if options.WildcardMask {
+			annotations = append(annotations, "wildcard mask: "+addr.GetHostMask().ToCanonicalString())
+		}
+	}
+
+	if len(annotations) == 0 {
+		return str
+	}
+	return str + " (" + strings.Join(annotations, ", ") + ")"
+}
+
 // ToHexString writes this address as a single hexadecimal value
 // (possibly two values if a range that is not a prefixed block),
 // the number of digits according to the bit count,
@@ -2258,6 +2936,37 @@ func (addr *IPAddress) ToBinaryString(with0bPrefix bool) (string, address_error.
 	return addr.init().toBinaryString(with0bPrefix)
 }
 
+// ToBitString returns the lowest address in this subnet or address as a string of binary digits,
+// one for each bit in the address, with no prefix and no separators,
+// such as "00001010000000000000000000000001" for "10.0.0.1".
+// Any prefix length is omitted, use ToBitStringWithPrefixLen to include it.
+//
+// Such strings are suitable as keys for radix trees and other bit-oriented data structures.
+// Use NewIPAddressFromBitString to parse the string.
+func (addr *IPAddress) ToBitString() string {
+	if addr == nil {
+		return nilString()
+	}
+	res, _ := addr.init().GetLower().WithoutPrefixLen().ToBinaryString(false)
+	return res
+}
+
+// ToBitStringWithPrefixLen is like ToBitString, but when this address has a prefix length,
+// the prefix length is appended as in CIDR notation,
+// such as "00001010000000000000000000000000/8" for "10.0.0.0/8".
+func (addr *IPAddress) ToBitStringWithPrefixLen() string {
+	if addr == nil {
+		return nilString()
+	}
+
+	addr = addr.init()
+	res := addr.ToBitString()
+	if addr.IsPrefixed() {
+		res += PrefixLenSeparatorStr + addr.getPrefixLen().String()
+	}
+	return res
+}
+
 // ToCustomString creates a customized string from this address or subnet according to the given string option parameters.
 func (addr *IPAddress) ToCustomString(stringOptions address_string.IPStringOptions) string {
 	if addr == nil {
@@ -2295,6 +3004,36 @@ func (addr *IPAddress) ToUNCHostName() string {
 	return addr.ToCanonicalString()
 }
 
+// ToURLHostString returns this address formatted as the host component of a URL as in RFC 3986,
+// such as "192.168.1.1" or "[2001:db8::1]".
+//
+// IPv6 addresses are enclosed in brackets, and any zone is encoded as in RFC 6874, such as "[fe80::1%25eth0]".
+// IPv4 addresses are not enclosed in brackets.
+// Any prefix length is omitted, and for a subnet the lowest address in the subnet is used.
+func (addr *IPAddress) ToURLHostString() string {
+	if addr == nil {
+		return nilString()
+	}
+	return toURLHostString(addr.init().GetLower())
+}
+
+// ToHTTPURL returns a URL as in RFC 3986 with this address as the host, such as "http://192.168.1.1:8080" or "http://[::1]:8080/path".
+//
+// IPv6 addresses are enclosed in brackets, and any zone is encoded as in RFC 6874, such as "http://[fe80::1%25eth0]".
+// An empty scheme defaults to "http", a zero port is omitted, and a non-empty path is given a leading slash if it does not have one.
+// Any prefix length is omitted.
+//
+// An error is returned if the scheme is invalid, if this is a subnet with multiple addresses, or if it is the zero IPAddress with no IP version.
+func (addr *IPAddress) ToHTTPURL(scheme string, port uint16, path string) (string, address_error.AddressError) {
+	addr = addr.init()
+	if !addr.IsIPv4() && !addr.IsIPv6() {
+		return "", &addressValueError{addressError: addressError{key: "ipaddress.error.ipVersionIndeterminate"}}
+	} else if addr.IsMultiple() {
+		return "", &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	}
+	return toHTTPURLString(scheme, toURLHostString(addr), PortInt(port), path)
+}
+
 // ToHostName returns the HostName used to resolve, if this address was resolved from a host.
 // Otherwise, if this address represents a subnet of multiple addresses,
 // returns a HostName for that subnet.
@@ -2323,6 +3062,116 @@ func (addr *IPAddress) ToHostName() *HostName {
 	return h
 }
 
+// MarshalCompactJSON produces a space-efficient JSON representation of this address,
+// a JSON array of the segment values for IPv4, such as [192,168,1,1],
+// and a JSON string of the 32 hexadecimal digits of the address value for IPv6, such as "00010002000300040005000600070008",
+// followed by '%' and the zone if there is a zone.
+// The zero IPAddress, which has no IP version, is marshalled as null.
+//
+// The compact form is intended for binary protocols and compact logs, in which the readability of the canonical string is not needed.
+// The compact form cannot represent prefix lengths or subnets,
+// so an error is returned if this is a subnet with multiple addresses, and any prefix length is dropped.
+// Use the canonical string to preserve subnets and prefix lengths.
+func (addr *IPAddress) MarshalCompactJSON() ([]byte, error) {
+	if addr == nil {
+		return []byte("null"), nil
+	}
+
+	addr = addr.init()
+	if addr.IsMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	} else if addr.IsIPv4() {
+		bytes := make([]byte, 0, 17)
+		bytes = append(bytes, '[')
+		for i, b := range addr.Bytes() {
+			if i > 0 {
+				bytes = append(bytes, ',')
+			}
+			bytes = strconv.AppendUint(bytes, uint64(b), 10)
+		}
+		return append(bytes, ']'), nil
+	} else if addr.IsIPv6() {
+		str := hex.EncodeToString(addr.Bytes())
+		if addr.hasZone() {
+			str += IPv6ZoneSeparatorStr + string(addr.zone)
+		}
+		return json.Marshal(str)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalCompactJSON sets this address to the address represented by the given JSON, the inverse of MarshalCompactJSON.
+//
+// Both compact representations are accepted, a JSON array of 4 IPv4 or 8 IPv6 segment values,
+// or a JSON string of 8 or 32 hexadecimal digits for an IPv4 or IPv6 address respectively, with an optional IPv6 zone.
+// JSON null results in the zero IPAddress.
+func (addr *IPAddress) UnmarshalCompactJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		*addr = IPAddress{}
+		return nil
+	}
+
+	var result *IPAddress
+	if len(data) > 0 && data[0] == '[' {
+		var vals []IPv6SegInt
+		if err := json.Unmarshal(data, &vals); err != nil {
+			return err
+		}
+
+		switch len(vals) {
+		case IPv4SegmentCount:
+			for _, val := range vals {
+				if val > IPv4MaxValuePerSegment {
+					return &addressValueError{addressError: addressError{key: "ipaddress.error.ipv4.segment.too.large"}}
+				}
+			}
+			result = NewIPv4AddressFromVals(func(segmentIndex int) IPv4SegInt {
+				return IPv4SegInt(vals[segmentIndex])
+			}).ToIP()
+		case IPv6SegmentCount:
+			result = NewIPv6AddressFromVals(func(segmentIndex int) IPv6SegInt {
+				return vals[segmentIndex]
+			}).ToIP()
+		default:
+			return &addressValueError{addressError: addressError{key: "ipaddress.error.invalid.size"}}
+		}
+	} else {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+
+		str, zone, _ := strings.Cut(str, IPv6ZoneSeparatorStr)
+		byts, err := hex.DecodeString(str)
+		if err != nil {
+			return err
+		}
+
+		switch len(byts) {
+		case IPv4ByteCount:
+			if zone != "" {
+				return &addressValueError{addressError: addressError{key: "ipaddress.error.only.ipv6.has.zone"}}
+			}
+			addr4, err := NewIPv4AddressFromBytes(byts)
+			if err != nil {
+				return err
+			}
+			result = addr4.ToIP()
+		case IPv6ByteCount:
+			addr6, err := NewIPv6AddressFromZonedBytes(byts, zone)
+			if err != nil {
+				return err
+			}
+			result = addr6.ToIP()
+		default:
+			return &addressValueError{addressError: addressError{key: "ipaddress.error.invalid.size"}}
+		}
+	}
+	*addr = *result
+	return nil
+}
+
 // IPVersion is the version type used by IP address types.
 type IPVersion int
 
@@ -2667,6 +3516,210 @@ func NewIPAddressFromNetIP(ip net.IP) (*IPAddress, address_error.AddressValueErr
 	return addrFromIP(ip)
 }
 
+// NewIPAddressFromBase64 constructs an address of the given version from the base64 encoding of its raw bytes,
+// the inverse of ToBase64String, using the URL-safe alphabet of RFC 4648 when urlSafe is true.
+//
+// An error is returned if the string is not valid base64,
+// or if the number of decoded bytes does not match the byte count of the given version.
+func NewIPAddressFromBase64(s string, version IPVersion, urlSafe bool) (*IPAddress, address_error.AddressValueError) {
+	bytes, err := getBase64Encoding(urlSafe).DecodeString(s)
+	if err != nil {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.base64"}}
+	}
+
+	if version.IsIPv4() {
+		if len(bytes) != IPv4ByteCount {
+			return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipv4.invalid.byte.count"}}
+		}
+		addr, err := NewIPv4AddressFromBytes(bytes)
+		return addr.ToIP(), err
+	} else if version.IsIPv6() {
+		if len(bytes) != IPv6ByteCount {
+			return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipv6.invalid.byte.count"}}
+		}
+		addr, err := NewIPv6AddressFromBytes(bytes)
+		return addr.ToIP(), err
+	}
+	return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipVersionIndeterminate"}}
+}
+
+func getBase64Encoding(urlSafe bool) *base64.Encoding {
+	if urlSafe {
+		return base64.URLEncoding
+	}
+	return base64.StdEncoding
+}
+
+// NewIPAddressFromBitString parses a string of 32 or 128 binary digits into an IPv4 or IPv6 address respectively,
+// optionally followed by a prefix length in CIDR notation, the inverse of ToBitString and ToBitStringWithPrefixLen.
+//
+// An error is returned if the string has a different number of digits, has characters other than '0' and '1',
+// or has an invalid prefix length.
+func NewIPAddressFromBitString(bits string) (*IPAddress, address_error.AddressStringError) {
+	digits, prefixStr, isPrefixed := strings.Cut(bits, PrefixLenSeparatorStr)
+	var version IPVersion
+	switch len(digits) {
+	case IPv4BitCount:
+		version = IPv4
+	case IPv6BitCount:
+		version = IPv6
+	default:
+		return nil, &addressStringError{addressError{str: bits, key: "ipaddress.error.bit.string"}}
+	}
+
+	bytes := make([]byte, len(digits)>>3)
+	for i := 0; i < len(digits); i++ {
+		switch digits[i] {
+		case '1':
+			bytes[i>>3] |= 0x80 >> (i & 7)
+		case '0':
+		default:
+			return nil, &addressStringError{addressError{str: bits, key: "ipaddress.error.invalid.character"}}
+		}
+	}
+
+	var prefixLen PrefixLen
+	if isPrefixed {
+		var err address_error.AddressStringError
+		if prefixLen, err = ValidatePrefixLenStr(prefixStr, version); err != nil {
+			return nil, err
+		}
+	}
+
+	if version.IsIPv4() {
+		addr, _ := NewIPv4AddressFromPrefixedBytes(bytes, prefixLen)
+		return addr.ToIP(), nil
+	}
+	addr, _ := NewIPv6AddressFromPrefixedBytes(bytes, prefixLen)
+	return addr.ToIP(), nil
+}
+
+// NewIPAddressFromReverseDNS parses a reverse DNS PTR name, the inverse of ToReverseDNSString.
+// Names ending with ".in-addr.arpa" are IPv4 and names ending with ".ip6.arpa", or the deprecated ".ip6.int", are IPv6.
+// The suffix is case-insensitive, and a trailing '.' for the root domain is allowed.
+//
+// A full name produces a single address, such as "4.3.2.1.in-addr.arpa." for "1.2.3.4".
+// A partial name, as used when delegating a subnet, produces the prefix block for the labels present,
+// such as "0.168.192.in-addr.arpa." for "192.168.0.0/24" or "8.b.d.0.1.0.0.2.ip6.arpa" for "2001:db8::/32".
+//
+// An error is returned if the suffix is not recognized,
+// or if the labels are not 1 to 4 decimal values no larger than 255 for IPv4, or 1 to 32 single hexadecimal digits for IPv6.
+func NewIPAddressFromReverseDNS(ptrName string) (*IPAddress, address_error.AddressStringError) {
+	name := strings.TrimSuffix(ptrName, ".")
+	if labels, found := cutSuffixFold(name, IPv4ReverseDnsSuffix); found {
+		if bytes, prefLen, ok := parseReverseDNSLabels(labels, IPv4BitCount, 8, 10); ok {
+			addr, _ := NewIPv4AddressFromPrefixedBytes(bytes, prefLen)
+			return addr.ToIP(), nil
+		}
+	} else if labels, found := cutSuffixFold(name, IPv6ReverseDnsSuffix); found {
+		if bytes, prefLen, ok := parseReverseDNSLabels(labels, IPv6BitCount, 4, 16); ok {
+			addr, _ := NewIPv6AddressFromPrefixedBytes(bytes, prefLen)
+			return addr.ToIP(), nil
+		}
+	} else if labels, found := cutSuffixFold(name, IPv6ReverseDnsSuffixDeprecated); found {
+		if bytes, prefLen, ok := parseReverseDNSLabels(labels, IPv6BitCount, 4, 16); ok {
+			addr, _ := NewIPv6AddressFromPrefixedBytes(bytes, prefLen)
+			return addr.ToIP(), nil
+		}
+	}
+	return nil, &addressStringError{addressError{str: ptrName, key: "ipaddress.error.reverse.dns"}}
+}
+
+// cutSuffixFold is like strings.CutSuffix but case-insensitive.
+func cutSuffixFold(str, suffix string) (before string, found bool) {
+	if start := len(str) - len(suffix); start >= 0 && strings.EqualFold(str[start:], suffix) {
+		return str[:start], true
+	}
+	return str, false
+}
+
+// parseReverseDNSLabels parses the labels of a reverse DNS name, each of which holds labelBits bits in the given radix,
+// with the least significant label first.
+// The prefix length is nil when all bits are present, otherwise it covers the bits that are present.
+func parseReverseDNSLabels(labels string, bitCount, labelBits BitCount, radix uint64) (bytes []byte, prefLen PrefixLen, ok bool) {
+	if labels == "" {
+		return
+	}
+
+	split := strings.Split(labels, ".")
+	labelCount := BitCount(len(split))
+	if labelCount*labelBits > bitCount {
+		return
+	}
+
+	maxDigits := 3
+	if radix == 16 {
+		maxDigits = 1
+	}
+
+	bytes = make([]byte, bitCount>>3)
+	for i, label := range split {
+		if label == "" || len(label) > maxDigits {
+			return nil, nil, false
+		}
+
+		val, err := strconv.ParseUint(label, int(radix), int(labelBits))
+		if err != nil {
+			return nil, nil, false
+		}
+
+		// the last label is the most significant
+		bitIndex := (labelCount - 1 - BitCount(i)) * labelBits
+		byteIndex := bitIndex >> 3
+		bytes[byteIndex] |= byte(val << (8 - labelBits - (bitIndex & 7)))
+	}
+
+	if totalBits := labelCount * labelBits; totalBits < bitCount {
+		prefLen = cacheBitCount(totalBits)
+	}
+	return bytes, prefLen, true
+}
+
+// NewIPAddressFromSocks5 constructs an address from the SOCKS5 wire format of RFC 1928,
+// the address type byte followed by the address bytes, the inverse of ToSocks5Address.
+//
+// An error is returned if the address type is not IPv4 (0x01) or IPv6 (0x04),
+// which includes the domain name type (0x03), or if the number of address bytes does not match the type.
+func NewIPAddressFromSocks5(data []byte) (*IPAddress, address_error.AddressValueError) {
+	if len(data) == 0 {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.invalid.size"}}
+	}
+
+	switch data[0] {
+	case socks5AddrTypeIPv4:
+		if len(data) != 1+IPv4ByteCount {
+			return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipv4.invalid.byte.count"}}
+		}
+		addr, err := NewIPv4AddressFromBytes(data[1:])
+		return addr.ToIP(), err
+	case socks5AddrTypeIPv6:
+		if len(data) != 1+IPv6ByteCount {
+			return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipv6.invalid.byte.count"}}
+		}
+		addr, err := NewIPv6AddressFromBytes(data[1:])
+		return addr.ToIP(), err
+	}
+	// includes the domain name type 0x03
+	return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.socks5.address.type"}}
+}
+
+// NewIPAddressFromProtoBytes constructs an address from the contents of a proto3 bytes field,
+// either 4 bytes for IPv4 or 16 bytes for IPv6 in network byte order, the inverse of ToProtoBytes.
+// Sixteen bytes are always an IPv6 address, including the IPv4-mapped addresses such as "::ffff:1.2.3.4".
+//
+// An error is returned if the number of bytes is neither 4 nor 16.
+func NewIPAddressFromProtoBytes(data []byte) (*IPAddress, address_error.AddressValueError) {
+	switch len(data) {
+	case IPv4ByteCount:
+		addr, err := NewIPv4AddressFromBytes(data)
+		return addr.ToIP(), err
+	case IPv6ByteCount:
+		addr, err := NewIPv6AddressFromBytes(data)
+		return addr.ToIP(), err
+	}
+	return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.invalid.byte.count"}}
+}
+
 // NewIPAddressFromNetIPAddr constructs an address or subnet from a net.IPAddr.
 // An error is returned when the IP has an invalid number of bytes.  IPv4 should have 4 bytes or less, IPv6 16 bytes or less, although extra leading zeros are tolerated.
 func NewIPAddressFromNetIPAddr(addr *net.IPAddr) (*IPAddress, address_error.AddressValueError) {
@@ -65,6 +65,8 @@ type ipAddressProvider interface {
 	isProvidingEmpty() bool
 	isProvidingMixedIPv6() bool
 	isProvidingBase85IPv6() bool
+	isProvidingWildcard() bool // providing a string with wildcard or range segments, or the "*" string
+	isProvidingRange() bool    // providing a string with range segments
 	getProviderNetworkPrefixLen() PrefixLen
 	isInvalid() bool
 	// If the address was created by parsing, this provides the parameters used when creating the address,
@@ -155,6 +157,14 @@ func (p *ipAddrProvider) isProvidingBase85IPv6() bool {
 	return false
 }
 
+func (p *ipAddrProvider) isProvidingWildcard() bool {
+	return false
+}
+
+func (p *ipAddrProvider) isProvidingRange() bool {
+	return false
+}
+
 func (p *ipAddrProvider) getProviderNetworkPrefixLen() PrefixLen {
 	return nil
 }
@@ -433,6 +443,10 @@ func (all *allCreator) isProvidingAllAddresses() bool {
 	return all.adjustedVersion == IndeterminateIPVersion
 }
 
+func (all *allCreator) isProvidingWildcard() bool {
+	return true
+}
+
 func (all *allCreator) isSequential() bool {
 	addr, _ := all.getProviderAddress()
 	if addr != nil {
@@ -146,6 +146,39 @@ var keyStrMap = map[string]int{
 	`ipaddress.host.error.invalid`:                             133,
 	`ipaddress.host.error.invalid.port.service`:                138,
 	`ipaddress.error.invalid.size`:                             25,
+	`ipaddress.error.host.bits.set`:                            145,
+	`ipaddress.error.not.single.address`:                       146,
+	`ipaddress.error.socks5.address.type`:                      147,
+	`ipaddress.error.bgp.large.community`:                      148,
+	`ipaddress.error.url.scheme`:                               149,
+	`ipaddress.error.joined.segment.count`:                     150,
+	`ipaddress.error.trie.data`:                                151,
+	`ipaddress.error.trie.version`:                             152,
+	`ipaddress.error.range.count.exceeds.max`:                  153,
+	`ipaddress.mac.error.invalid.separator`:                    154,
+	`ipaddress.error.no.prefix.length`:                         155,
+	`ipaddress.error.no.available.address`:                     156,
+	`ipaddress.error.base64`:                                   157,
+	`ipaddress.error.packed.decimal`:                           158,
+	`ipaddress.error.onion`:                                    159,
+	`ipaddress.error.bit.string`:                               160,
+	`ipaddress.error.granularity`:                              161,
+	`ipaddress.error.bitmap.size`:                              162,
+	`ipaddress.error.reverse.dns`:                              163,
+	`ipaddress.error.nat.not.in.subnet`:                        164,
+	`ipaddress.error.nat.size.mismatch`:                        165,
+	`ipaddress.error.nat64.prefix.length`:                      166,
+	`ipaddress.error.invalid.byte.count`:                       167,
+	`ipaddress.error.cidr.with.mask`:                           168,
+	`ipaddress.error.mask.prefix.mismatch`:                     169,
+	`ipaddress.error.solicited.node`:                           170,
+	`ipaddress.error.scan.type`:                                171,
+	`ipaddress.error.binary.format`:                            172,
+	`ipaddress.error.invalid.stride`:                           173,
+	`ipaddress.error.split.count`:                              174,
+	`ipaddress.error.split.too.small`:                          175,
+	`ipaddress.error.split.prefix.length`:                      176,
+	`ipaddress.error.scan.parse`:                               177,
 }
 
 var strIndices = []int{
@@ -163,7 +196,10 @@ var strIndices = []int{
 	4339, 4377, 4435, 4465, 4500, 4546, 4611, 4641, 4669, 4715,
 	4736, 4784, 4952, 4973, 5023, 5046, 5081, 5146, 5175, 5229,
 	5246, 5272, 5336, 5367, 5379, 5427, 5465, 5572, 5629, 5677,
-	5692, 5733, 5808, 6003, 6045,
+	5692, 5733, 5808, 6003, 6045, 6089, 6139, 6181, 6226, 6316,
+	6421, 6467, 6495, 6537, 6598, 6683, 6715, 6752, 6775, 6837,
+	6864, 6918, 7038, 7089, 7113, 7182, 7278, 7334, 7367, 7426,
+	7471, 7568, 7615, 7644, 7671, 7723, 7778, 7878, 7919,
 }
 
 var strVals = `service name is empty` +
@@ -310,7 +346,40 @@ var strVals = `service name is empty` +
 	`validation options do not allow you to specify a non-segmented single value` +
 	`A mask must be a single IP address, while a CIDR prefix length must indicate the count of subnet bits, between 0 and 32 for IP version 4 addresses and between 0 and 128 for IP version 6 addresses` +
 	`service name must have at least one letter` +
-	`service name cannot have consecutive hyphens`
+	`service name cannot have consecutive hyphens` +
+	`address has host bits set beyond the prefix length` +
+	`a single address is required, not a subnet` +
+	`SOCKS5 address type is not an IP address type` +
+	`invalid BGP large community, expected three colon-separated unsigned 32-bit decimal values` +
+	`invalid URL scheme, a scheme must start with a letter followed by letters, digits, plus, hyphen or period` +
+	`the count of joined segments must be 1, 2 or 3` +
+	`invalid serialized trie data` +
+	`unsupported serialized trie format version` +
+	`the count of addresses in the range exceeds the maximum count` +
+	`invalid MAC address separator, the separator must be a colon, hyphen, period or space` +
+	`the address has no prefix length` +
+	`no address is available in the subnet` +
+	`invalid base64 encoding` +
+	`packed decimal segments must have exactly three decimal digits` +
+	`invalid onion style address` +
+	`a bit string address must have 32 or 128 binary digits` +
+	`the granularity bit-length is less than the prefix length of the covering prefix block or exceeds the address bit-length` +
+	`the bitmap size does not match the number of blocks` +
+	`invalid reverse DNS name` +
+	`the address is not within the original subnet of the translation rule` +
+	`the original and translated subnets of the translation rule must be sequential and of equal size` +
+	`the NAT64 prefix length must be 32, 40, 48, 56, 64 or 96` +
+	`IP address has invalid byte count` +
+	`expected a prefixed IPv4 address followed by a network mask` +
+	`network mask does not match the prefix length` +
+	`a solicited-node multicast address requires a unicast address that is not loopback or unspecified` +
+	`unsupported source type for scanning an address` +
+	`invalid binary address format` +
+	`the stride must be positive` +
+	`the number of blocks must be a positive power of two` +
+	`the block has fewer addresses than the number of blocks` +
+	`the prefix length must be at least the prefix length of the block and at most the address bit-length` +
+	`the database value is not a valid address`
 
 func lookupStr(key string) (result string) {
 	if index, ok := keyStrMap[key]; ok {
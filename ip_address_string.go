@@ -3,6 +3,7 @@ package goip
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/pchchv/goip/address_error"
 	"github.com/pchchv/goip/address_string_param"
@@ -134,6 +135,22 @@ func (addrStr *IPAddressString) String() string {
 	return addrStr.str
 }
 
+// Set implements the [flag.Value] interface, allowing an IPAddressString to be used as a command-line flag with flag.Var.
+// It parses the given string using the validation options of this IPAddressString, or the default options for the zero value,
+// and replaces this IPAddressString with the result.
+// If the string is not a valid address string, the error is returned and this IPAddressString is unchanged.
+//
+// Set breaks the immutability of IPAddressString and so is intended only for flag parsing,
+// before the IPAddressString is shared or used concurrently.
+func (addrStr *IPAddressString) Set(str string) error {
+	res := NewIPAddressStringParams(str, addrStr.GetValidationOptions())
+	if err := res.Validate(); err != nil {
+		return err
+	}
+	*addrStr = *res
+	return nil
+}
+
 // Format implements the [fmt.Formatter] interface.
 // It accepts the verbs hat are applicable to strings,
 // namely the verbs %s, %q, %x and %X.
@@ -250,6 +267,25 @@ func (addrStr *IPAddressString) IsIPv6() bool {
 	return addrStr.IsValid() && addrStr.addressProvider.isProvidingIPv6()
 }
 
+// IsWildcard returns true if the address string has wildcard or range segments,
+// such as "1.2.*.4", "1.2.3._", "1.2.3-4.5" or "*:*", or is the string "*" representing all addresses.
+// Prefix lengths and masks are not considered, so "1.2.0.0/16" is not a wildcard string.
+//
+// The result is determined from the parsed structure of the string, without constructing the address.
+func (addrStr *IPAddressString) IsWildcard() bool {
+	addrStr = addrStr.init()
+	return addrStr.IsValid() && addrStr.addressProvider.isProvidingWildcard()
+}
+
+// IsRange returns true if the address string has range segments, such as "1.2.3-4.5".
+// Segments with the wildcards '*' or '_' are not considered ranges.
+//
+// The result is determined from the parsed structure of the string, without constructing the address.
+func (addrStr *IPAddressString) IsRange() bool {
+	addrStr = addrStr.init()
+	return addrStr.IsValid() && addrStr.addressProvider.isProvidingRange()
+}
+
 // IsMixedIPv6 returns whether the lower 4 bytes of the address string are represented as IPv4,
 // if this address string represents an IPv6 address.
 func (addrStr *IPAddressString) IsMixedIPv6() bool {
@@ -296,6 +332,34 @@ func (addrStr *IPAddressString) GetAddress() *IPAddress {
 	return addr
 }
 
+// Mask parses this address string and the given mask string, and applies the mask to the resulting address or subnet,
+// returning the result of IPAddress.Mask.  For example, for "192.168.1.255" and the mask "255.255.255.0", it returns "192.168.1.0".
+//
+// An error is returned if either string is invalid or does not represent an address,
+// if the mask is a different IP version than the address, or if applying the mask to a subnet
+// results in a set of addresses that cannot be represented as a sequential range within each segment.
+func (addrStr *IPAddressString) Mask(maskStr *IPAddressString) (*IPAddress, address_error.AddressError) {
+	addr, err := addrStr.ToAddress()
+	if err != nil {
+		return nil, err
+	} else if addr == nil {
+		return nil, &addressStringError{addressError{str: addrStr.String(), key: "ipaddress.error.empty"}}
+	}
+
+	mask, err := maskStr.ToAddress()
+	if err != nil {
+		return nil, err
+	} else if mask == nil {
+		return nil, &addressStringError{addressError{str: maskStr.String(), key: "ipaddress.error.invalid.mask.empty"}}
+	}
+
+	res, err := addr.Mask(mask)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetIPVersion returns the IP address version if this represents a valid IP address, otherwise it returns nil.
 func (addrStr *IPAddressString) GetIPVersion() IPVersion {
 	if addrStr.IsValid() {
@@ -634,6 +698,29 @@ func (addrStr *IPAddressString) Contains(other *IPAddressString) bool {
 	return false
 }
 
+// ContainsString returns whether the address or subnet identified by this address string contains the address or subnet identified by the given string,
+// as with Contains, but returns the validation error when either string is invalid rather than false.
+func (addrStr *IPAddressString) ContainsString(other *IPAddressString) (bool, address_error.AddressStringError) {
+	if err := addrStr.Validate(); err != nil {
+		return false, err
+	} else if err = other.Validate(); err != nil {
+		return false, err
+	}
+	return addrStr.Contains(other), nil
+}
+
+// ContainsAddress returns whether the address or subnet identified by this address string contains the given address or subnet,
+// returning the validation error when this address string is invalid.
+// It returns false with no error when the given address is nil.
+func (addrStr *IPAddressString) ContainsAddress(addr *IPAddress) (bool, address_error.AddressStringError) {
+	if err := addrStr.Validate(); err != nil {
+		return false, err
+	} else if addr == nil {
+		return false, nil
+	}
+	return addrStr.Contains(addr.ToAddressString()), nil
+}
+
 // PrefixContains is similar to PrefixEqual,
 // but instead returns whether the prefix of this address contains the same of the given address,
 // using the prefix length of this address.
@@ -740,6 +827,13 @@ func NewIPAddressStringParams(str string, params address_string_param.IPAddressS
 	return parseIPAddressString(str, p)
 }
 
+// NewIPAddressStringFlag constructs an IPAddressString for use as a command-line flag with flag.Var,
+// holding the given default value and parsing both the default value and any value supplied with Set according to the given parameters.
+// If params is nil, the default parameters are used.
+func NewIPAddressStringFlag(defaultValue string, params address_string_param.IPAddressStringParams) *IPAddressString {
+	return NewIPAddressStringParams(defaultValue, params)
+}
+
 // NewIPAddressString constructs an IPAddressString.
 func NewIPAddressString(str string) *IPAddressString {
 	return parseIPAddressString(str, defaultIPAddrParameters)
@@ -753,3 +847,161 @@ func NewIPAddressString(str string) *IPAddressString {
 func ValidatePrefixLenStr(str string, version IPVersion) (prefixLen PrefixLen, err address_error.AddressStringError) {
 	return validator.validatePrefixLenStr(str, version)
 }
+
+// CompareIPAddressStringSemantics parses both address strings and compares the resulting addresses or subnets.
+// It returns zero if the two strings represent the same set of addresses, such as "::1" and "0:0:0:0:0:0:0:1".
+// Otherwise, it returns a negative or positive integer if the first is less than or greater than the second, according to CountComparator.
+//
+// Unlike Compare, invalid strings are not compared by their string representations,
+// instead the parsing error of the first invalid string is returned.
+// An error is also returned for strings with no IP version, such as "*" or the empty string,
+// since they do not represent a single set of addresses that can be ordered with CountComparator.
+func CompareIPAddressStringSemantics(one, two *IPAddressString) (int, address_error.AddressError) {
+	oneAddr, err := toVersionedAddress(one)
+	if err != nil {
+		return 0, err
+	}
+
+	twoAddr, err := toVersionedAddress(two)
+	if err != nil {
+		return 0, err
+	}
+
+	if oneAddr.Equal(twoAddr) {
+		return 0, nil
+	}
+	return CountComparator.CompareAddresses(oneAddr, twoAddr), nil
+}
+
+func toVersionedAddress(addrStr *IPAddressString) (addr *IPAddress, err address_error.AddressError) {
+	if addr, err = addrStr.ToAddress(); err == nil && addr == nil { // the string represents all addresses of either version, or none
+		err = &addressStringError{addressError{str: addrStr.String(), key: "ipaddress.error.ipVersionIndeterminate"}}
+	}
+	return
+}
+
+// ParseIPRangeString parses the hyphenated range notation "A-B", such as "10.0.0.1-10.0.0.255", into a sequential range.
+// The lower and upper addresses may be supplied in either order, but must have the same IP version.
+//
+// Strings that are valid address strings on their own, such as "1.2.3.4-5" or "1.2.*.*",
+// are parsed as an IPAddressString and converted with ToSequentialRange.
+// See IPAddressString.GetSequentialRange for how non-sequential subnets are converted.
+func ParseIPRangeString(str string) (*SequentialRange[*IPAddress], address_error.AddressStringError) {
+	addrStr := NewIPAddressString(str)
+	if addrStr.IsValid() {
+		return addrStr.ToSequentialRange()
+	}
+
+	if lowerStr, upperStr, found := strings.Cut(addrStr.String(), RangeSeparatorStr); found {
+		lower := NewIPAddressString(lowerStr).GetAddress()
+		upper := NewIPAddressString(upperStr).GetAddress()
+		if lower != nil && upper != nil && !lower.IsMultiple() && !upper.IsMultiple() {
+			if rng := NewSequentialRange(lower.WithoutPrefixLen(), upper.WithoutPrefixLen()); rng != nil {
+				return rng, nil
+			}
+		}
+	}
+	return nil, addrStr.Validate()
+}
+
+// ParseAddressList parses a list of addresses and subnets separated by whitespace and/or commas,
+// such as "10.0.0.1 10.0.0.2, 192.168.0.0/24", using the default parameters of NewIPAddressString.
+// IPv4 and IPv6 may be mixed, and each element may use any of the formats supported by IPAddressString,
+// such as CIDR prefix lengths, wildcards, and ranges.
+//
+// The successfully parsed addresses are returned in the order they appear in the input.
+// Elements that could not be parsed are returned in a map from the element string to its parsing error,
+// which is nil if all elements were parsed.
+// The all-addresses string "*" is treated as an error, since it has no single IP version.
+func ParseAddressList(input string) ([]*IPAddress, map[string]error) {
+	tokens := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	var errs map[string]error
+	addrs := make([]*IPAddress, 0, len(tokens))
+	for _, token := range tokens {
+		addr, err := NewIPAddressString(token).ToAddress()
+		if err == nil && addr == nil {
+			// the version of the all-addresses string "*" is indeterminate
+			err = &addressValueError{addressError: addressError{key: "ipaddress.error.ipVersionIndeterminate"}}
+		}
+
+		if err != nil {
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[token] = err
+		} else {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, errs
+}
+
+// ToCIDRList normalizes a list of address strings into the smallest list of CIDR prefix block strings covering them.
+// Each string is parsed with the default parameters of NewIPAddressString,
+// and may be an individual address or a subnet in any of the formats supported by IPAddressString.
+// Overlapping and adjacent subnets are merged,
+// and blocks with a prefix length longer than maxPrefixLen are widened to the containing block of length maxPrefixLen, then merged again.
+// Use a maxPrefixLen of 128 or more to avoid widening any blocks.
+//
+// The result has the IPv4 blocks followed by the IPv6 blocks, each sorted by address value, as canonical strings,
+// such as "10.0.0.0/23" or "2001:db8::/32".
+//
+// An error is returned if maxPrefixLen is negative, or for the first string that cannot be parsed as an address or subnet of a single IP version.
+func ToCIDRList(addresses []string, maxPrefixLen BitCount) ([]string, address_error.AddressError) {
+	if maxPrefixLen < 0 {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.prefixSize"}, val: int(maxPrefixLen)}
+	}
+
+	var ipv4Addrs, ipv6Addrs []*IPAddress
+	for _, str := range addresses {
+		addr, err := NewIPAddressString(strings.TrimSpace(str)).ToAddress()
+		if err != nil {
+			return nil, err
+		} else if addr == nil {
+			// the version of the all-addresses string "*" is indeterminate
+			return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.ipVersionIndeterminate"}}
+		}
+
+		if addr.IsIPv4() {
+			ipv4Addrs = append(ipv4Addrs, addr)
+		} else {
+			ipv6Addrs = append(ipv6Addrs, addr)
+		}
+	}
+
+	ipv4Blocks := mergeToCIDRBlocks(ipv4Addrs, maxPrefixLen)
+	ipv6Blocks := mergeToCIDRBlocks(ipv6Addrs, maxPrefixLen)
+	res := make([]string, 0, len(ipv4Blocks)+len(ipv6Blocks))
+	for _, block := range append(ipv4Blocks, ipv6Blocks...) {
+		res = append(res, block.ToCanonicalString())
+	}
+	return res, nil
+}
+
+// mergeToCIDRBlocks merges addresses of the same version to prefix blocks, widening those with prefix lengths longer than maxPrefixLen.
+func mergeToCIDRBlocks(addrs []*IPAddress, maxPrefixLen BitCount) []*IPAddress {
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	blocks := addrs[0].MergeToPrefixBlocks(addrs[1:]...)
+	if maxPrefixLen >= addrs[0].GetBitCount() {
+		return blocks
+	}
+
+	widened := false
+	for i, block := range blocks {
+		if block.GetPrefixLen().bitCount() > maxPrefixLen {
+			blocks[i] = block.ToPrefixBlockLen(maxPrefixLen)
+			widened = true
+		}
+	}
+
+	if widened {
+		blocks = blocks[0].MergeToPrefixBlocks(blocks[1:]...)
+	}
+	return blocks
+}
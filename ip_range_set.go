@@ -0,0 +1,231 @@
+package goip
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// The range set type names are aliases, not distinct types, in the same way as the sequential range type names.
+type (
+	// IPRangeSet is an alias for RangeSet[*IPAddress], a set of IPv4 or IPv6 addresses.
+	IPRangeSet = RangeSet[*IPAddress]
+	// IPv4RangeSet is an alias for RangeSet[*IPv4Address], a set of IPv4 addresses.
+	IPv4RangeSet = RangeSet[*IPv4Address]
+	// IPv6RangeSet is an alias for RangeSet[*IPv6Address], a set of IPv6 addresses.
+	IPv6RangeSet = RangeSet[*IPv6Address]
+)
+
+// RangeSet is a set of IP addresses, stored as a list of sequential ranges.
+// The ranges are kept sorted by ascending lowest value, with IPv4 ranges ahead of IPv6 ranges,
+// and the ranges are kept merged, so that no two ranges overlap or are adjacent.
+//
+// The zero value is an empty set ready to use.
+// Add and Remove modify the set, while the set operations Union, Intersect, and Subtract produce new sets,
+// so a set is not safe for concurrent use while it is being modified.
+type RangeSet[T SequentialRangeConstraint[T]] struct {
+	ranges []*SequentialRange[T]
+}
+
+// NewRangeSet constructs a set containing the addresses of the given ranges, which may overlap.
+// Nil ranges are ignored.
+func NewRangeSet[T SequentialRangeConstraint[T]](ranges ...*SequentialRange[T]) *RangeSet[T] {
+	return &RangeSet[T]{ranges: joinRanges(append(make([]*SequentialRange[T], 0, len(ranges)), ranges...))}
+}
+
+func (set *RangeSet[T]) getRanges() []*SequentialRange[T] {
+	if set == nil {
+		return nil
+	}
+	return set.ranges
+}
+
+// versionRank orders the address versions in the same way as the sorted ranges, with IPv4 ahead of IPv6.
+func versionRank(addr AddressType) int {
+	if addr.ToAddressBase().IsIPv6() {
+		return 1
+	}
+	return 0
+}
+
+// Add adds the addresses of the given range to this set.
+// A nil range is ignored.
+func (set *RangeSet[T]) Add(rng *SequentialRange[T]) {
+	if rng != nil {
+		set.ranges = joinRanges(append(append(make([]*SequentialRange[T], 0, len(set.ranges)+1), set.ranges...), rng))
+	}
+}
+
+// Remove removes the addresses of the given range from this set.
+// A nil range is ignored.
+func (set *RangeSet[T]) Remove(rng *SequentialRange[T]) {
+	if rng != nil {
+		set.ranges = subtractRanges(set.ranges, []*SequentialRange[T]{rng.init()})
+	}
+}
+
+// Contains returns whether this set contains all the addresses of the given address or subnet.
+func (set *RangeSet[T]) Contains(addr T) bool {
+	var t T
+	if addr == t { // nil for pointers
+		return false
+	} else if !addr.IsSequential() {
+		// each sequential block of the subnet may be found in a different range
+		if blockIterable, ok := any(addr).(interface{ SequentialBlockIterator() Iterator[T] }); ok {
+			for iter := blockIterable.SequentialBlockIterator(); iter.HasNext(); {
+				if !set.containsSequential(iter.Next()) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return set.containsSequential(addr)
+}
+
+func (set *RangeSet[T]) containsSequential(addr T) bool {
+	ranges := set.getRanges()
+	lower, upper := addr.GetLower(), addr.GetUpper()
+	rank := versionRank(addr)
+
+	// find the first range that does not precede the lower address
+	i := sort.Search(len(ranges), func(i int) bool {
+		rng := ranges[i]
+		if rngRank := versionRank(rng.lower); rngRank != rank {
+			return rngRank > rank
+		}
+		return compareLowIPAddressValues(rng.upper, lower) >= 0
+	})
+	if i == len(ranges) {
+		return false
+	}
+	rng := ranges[i]
+	return versionRank(rng.lower) == rank &&
+		compareLowIPAddressValues(rng.lower, lower) <= 0 &&
+		compareLowIPAddressValues(rng.upper, upper) >= 0
+}
+
+// Union returns a new set with the addresses found in either this set or the given set.
+func (set *RangeSet[T]) Union(other *RangeSet[T]) *RangeSet[T] {
+	ranges, otherRanges := set.getRanges(), other.getRanges()
+	joined := append(append(make([]*SequentialRange[T], 0, len(ranges)+len(otherRanges)), ranges...), otherRanges...)
+	return &RangeSet[T]{ranges: joinRanges(joined)}
+}
+
+// Intersect returns a new set with the addresses found in both this set and the given set.
+func (set *RangeSet[T]) Intersect(other *RangeSet[T]) *RangeSet[T] {
+	ranges, otherRanges := set.getRanges(), other.getRanges()
+	var result []*SequentialRange[T]
+	for i, j := 0, 0; i < len(ranges) && j < len(otherRanges); {
+		rng, otherRng := ranges[i], otherRanges[j]
+		if rank, otherRank := versionRank(rng.lower), versionRank(otherRng.lower); rank != otherRank {
+			if rank < otherRank {
+				i++
+			} else {
+				j++
+			}
+			continue
+		}
+		if intersection := rng.Intersect(otherRng); intersection != nil {
+			result = append(result, intersection)
+		}
+		// the range ending first cannot intersect any later range of the other set
+		if compareLowIPAddressValues(rng.upper, otherRng.upper) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return &RangeSet[T]{ranges: result}
+}
+
+// Subtract returns a new set with the addresses found in this set but not in the given set.
+func (set *RangeSet[T]) Subtract(other *RangeSet[T]) *RangeSet[T] {
+	return &RangeSet[T]{ranges: subtractRanges(set.getRanges(), other.getRanges())}
+}
+
+// subtractRanges subtracts the sorted and merged ranges of the second list from those of the first.
+func subtractRanges[T SequentialRangeConstraint[T]](ranges, otherRanges []*SequentialRange[T]) (result []*SequentialRange[T]) {
+	j := 0
+	for _, rng := range ranges {
+		rank := versionRank(rng.lower)
+		// skip the ranges to subtract that precede this range, which also precede any later range
+		for j < len(otherRanges) {
+			otherRng := otherRanges[j]
+			if otherRank := versionRank(otherRng.lower); otherRank != rank {
+				if otherRank > rank {
+					break
+				}
+			} else if compareLowIPAddressValues(otherRng.upper, rng.lower) >= 0 {
+				break
+			}
+			j++
+		}
+
+		remaining := rng
+		for k := j; remaining != nil && k < len(otherRanges); k++ {
+			otherRng := otherRanges[k]
+			if versionRank(otherRng.lower) != rank || compareLowIPAddressValues(otherRng.lower, remaining.upper) > 0 {
+				break
+			}
+			diff := remaining.Subtract(otherRng)
+			switch len(diff) {
+			case 0:
+				remaining = nil
+			case 1:
+				if compareLowIPAddressValues(diff[0].lower, otherRng.lower) < 0 {
+					// only the part below the subtracted range remains, so no later range to subtract can apply
+					result = append(result, diff[0])
+					remaining = nil
+				} else {
+					remaining = diff[0]
+				}
+			default:
+				result = append(result, diff[0])
+				remaining = diff[1]
+			}
+		}
+		if remaining != nil {
+			result = append(result, remaining)
+		}
+	}
+	return
+}
+
+// GetRanges returns the sorted and merged ranges of this set.
+func (set *RangeSet[T]) GetRanges() []*SequentialRange[T] {
+	return append([]*SequentialRange[T](nil), set.getRanges()...)
+}
+
+// IsEmpty returns whether this set contains no addresses.
+func (set *RangeSet[T]) IsEmpty() bool {
+	return len(set.getRanges()) == 0
+}
+
+// Count returns the number of individual addresses in this set.
+func (set *RangeSet[T]) Count() *big.Int {
+	count := bigZero()
+	for _, rng := range set.getRanges() {
+		count.Add(count, rng.GetCount())
+	}
+	return count
+}
+
+// ToPrefixBlocks returns the minimal list of prefix blocks that cover exactly the addresses of this set,
+// sorted from lowest address value to highest, with IPv4 blocks ahead of IPv6 blocks.
+func (set *RangeSet[T]) ToPrefixBlocks() (blocks []T) {
+	for _, rng := range set.getRanges() {
+		blocks = append(blocks, rng.SpanWithPrefixBlocks()...)
+	}
+	return
+}
+
+// String returns the ranges of this set, separated by commas and enclosed in square brackets.
+func (set *RangeSet[T]) String() string {
+	ranges := set.getRanges()
+	strs := make([]string, len(ranges))
+	for i, rng := range ranges {
+		strs[i] = rng.String()
+	}
+	return "[" + strings.Join(strs, ", ") + "]"
+}
@@ -9,6 +9,8 @@ import (
 	"sort"
 	"strings"
 	"unsafe"
+
+	"github.com/pchchv/goip/address_error"
 )
 
 // DefaultSeqRangeSeparator is the low to high value separator used when creating strings for IP ranges.
@@ -20,9 +22,15 @@ var (
 	_ SequentialRange[*IPv6Address]
 )
 
+// The sequential range type names are aliases, not distinct types,
+// so an *IPAddressSeqRange, such as that returned by IPAddress.ToSequentialRange, is a *SequentialRange[*IPAddress]
+// and can be used wherever the generic type is expected.
 type (
-	IPAddressSeqRange   = SequentialRange[*IPAddress]
+	// IPAddressSeqRange is an alias for SequentialRange[*IPAddress], a range of IPv4 or IPv6 addresses.
+	IPAddressSeqRange = SequentialRange[*IPAddress]
+	// IPv4AddressSeqRange is an alias for SequentialRange[*IPv4Address], a range of IPv4 addresses.
 	IPv4AddressSeqRange = SequentialRange[*IPv4Address]
+	// IPv6AddressSeqRange is an alias for SequentialRange[*IPv6Address], a range of IPv6 addresses.
 	IPv6AddressSeqRange = SequentialRange[*IPv6Address]
 )
 
@@ -495,6 +503,27 @@ func (rng *SequentialRange[T]) Iterator() Iterator[T] {
 		nil)
 }
 
+// Enumerate returns all the individual addresses of this address range as a slice, in increasing order.
+// For instance, for the range "10.0.0.1 -> 10.0.0.10" it returns the 10 addresses from "10.0.0.1" to "10.0.0.10".
+//
+// To guard against accidentally allocating a very large slice, an error is returned if the count of addresses exceeds maxCount.
+func (rng *SequentialRange[T]) Enumerate(maxCount int) ([]T, address_error.AddressValueError) {
+	if rng == nil {
+		return []T{}, nil
+	}
+
+	count := rng.GetCount()
+	if !count.IsInt64() || count.Int64() > int64(maxCount) {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.range.count.exceeds.max"}, val: maxCount}
+	}
+
+	res := make([]T, 0, int(count.Int64()))
+	for iter := rng.Iterator(); iter.HasNext(); {
+		res = append(res, iter.Next())
+	}
+	return res, nil
+}
+
 // PrefixBlockIterator provides an iterator to iterate through the individual prefix blocks of the given prefix length,
 // one for each prefix of that length in the address range.
 func (rng *SequentialRange[T]) PrefixBlockIterator(prefLength BitCount) Iterator[T] {
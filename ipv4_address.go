@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"net"
 	"net/netip"
+	"strings"
 	"unsafe"
 
 	"github.com/pchchv/goip/address_error"
@@ -30,6 +31,9 @@ const (
 var (
 	zeroIPv4 = initZeroIPv4()
 	ipv4All  = zeroIPv4.ToPrefixBlockLen(0)
+
+	// the blocks which are not routable in the global internet, derived from the IANA special-purpose registry
+	ipv4NonGlobalBlocks = ipv4NonGlobalRegistryBlocks()
 )
 
 // IPv4Address is an IPv4 address, or a subnet of multiple IPv4 addresses.
@@ -302,6 +306,17 @@ func (addr *IPv4Address) Bytes() []byte {
 	return addr.init().section.Bytes()
 }
 
+// ToIGMPv3Bytes returns the 4-byte network byte order representation of this address used in the source address lists of IGMPv3 source records, as in RFC 3376.
+// It is the inverse of NewIPv4AddressFromIGMPv3Bytes.
+//
+// An error is returned if this is a subnet with multiple addresses.
+func (addr *IPv4Address) ToIGMPv3Bytes() ([]byte, address_error.AddressError) {
+	if addr.IsMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	}
+	return addr.Bytes(), nil
+}
+
 // UpperBytes returns the highest address in this subnet or address as a byte slice.
 func (addr *IPv4Address) UpperBytes() []byte {
 	return addr.init().section.UpperBytes()
@@ -364,6 +379,23 @@ func (addr *IPv4Address) IsPrivate() bool {
 		(seg0.Matches(192) && seg1.Matches(168))
 }
 
+// IsSharedAddressSpace returns whether this address, or all addresses in the subnet, are within the shared address space "100.64.0.0/10",
+// as defined by RFC 6598 for use by carrier-grade NAT (CGNAT).
+// These addresses are distinct from the private addresses of RFC 1918 for which IsPrivate returns true,
+// and are not considered local by IsLocal, since they are reserved for use within the network of a service provider.
+// See IsOperatorReserved.
+func (addr *IPv4Address) IsSharedAddressSpace() bool {
+	return addr.GetSegment(0).Matches(100) && addr.GetSegment(1).MatchesWithPrefixMask(64, 2)
+}
+
+// IsOperatorReserved returns whether this address, or all addresses in the subnet, are reserved for use by network operators
+// within their own networks rather than by their customers, which for IPv4 is the shared address space "100.64.0.0/10" of RFC 6598.
+// Such addresses are neither private as defined by IsPrivate nor local as defined by IsLocal,
+// so this allows tools to distinguish carrier-grade NAT addresses from those of customer networks.
+func (addr *IPv4Address) IsOperatorReserved() bool {
+	return addr.IsSharedAddressSpace()
+}
+
 // IsMulticast returns whether this address or subnet is entirely multicast.
 func (addr *IPv4Address) IsMulticast() bool {
 	// 1110...
@@ -376,6 +408,21 @@ func (addr *IPv4Address) IsLoopback() bool {
 	return addr.section != nil && addr.GetSegment(0).Matches(127)
 }
 
+// IsGloballyRoutable returns whether this address, or every address in this subnet, can be routed in the global internet.
+// It returns false if any address is multicast, or is within a block of the IANA IPv4 Special-Purpose Address Registry that is not globally reachable,
+// such as private (RFC 1918), shared address space (RFC 6598), benchmarking (RFC 2544), documentation (RFC 5737), loopback, link local, or reserved.
+// The globally reachable blocks nested within such blocks, such as the anycast addresses "192.0.0.9" and "192.0.0.10", are routable.
+// See GetIANARegistration for the registry entries.
+func (addr *IPv4Address) IsGloballyRoutable() bool {
+	addr = addr.init()
+	for _, block := range ipv4NonGlobalBlocks {
+		if addr.Intersect(block) != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // GetNetwork returns the singleton IPv4 network instance.
 func (addr *IPv4Address) GetNetwork() IPAddressNetwork {
 	return ipv4Network
@@ -398,6 +445,26 @@ func (addr *IPv4Address) GetIPv4Count() uint64 {
 	return addr.GetSection().GetIPv4Count()
 }
 
+// UsableHostCount returns the number of usable host addresses in the prefix block of this address or subnet,
+// or in this subnet if it has no prefix length.
+// The network and broadcast addresses, the lowest and highest addresses, are excluded from the count,
+// so the count for a /24 block is 254.
+//
+// When there are at most two addresses, all addresses are usable hosts,
+// so the count is 2 for a /31 block used with point-to-point links as in RFC 3021, and 1 for a single address such as a /32 block.
+// This is consistent with HostIterator.
+func (addr *IPv4Address) UsableHostCount() int64 {
+	if addr == nil {
+		return 0
+	}
+
+	count := int64(addr.init().ToPrefixBlock().GetIPv4Count())
+	if count > 2 {
+		count -= 2
+	}
+	return count
+}
+
 // GetIPv4PrefixCount returns the number of distinct prefix values in this section.
 // It is the same as GetPrefixCount but returns the value as a uint64 instead of a big integer.
 //
@@ -495,6 +562,67 @@ func (addr *IPv4Address) GetHostMask() *IPv4Address {
 	return addr.getHostMask(ipv4Network).ToIPv4()
 }
 
+// ToMaskPair returns both the network mask and the wildcard mask, also known as the host mask,
+// for the prefix length of this address or subnet.
+// For example, for "192.168.0.0/16" it returns "255.255.0.0" and "0.0.255.255",
+// the pair used by Cisco access lists such as "access-list 1 permit 192.168.0.0 0.0.255.255".
+//
+// An error is returned if this address has no prefix length.
+func (addr *IPv4Address) ToMaskPair() (networkMask, wildcardMask *IPv4Address, err address_error.AddressValueError) {
+	if !addr.IsPrefixed() {
+		err = &addressValueError{addressError: addressError{key: "ipaddress.error.no.prefix.length"}}
+		return
+	}
+	return addr.GetNetworkMask(), addr.GetHostMask(), nil
+}
+
+// CIDRSummary returns a subnet calculator report for the prefix block of the given prefix length containing this address,
+// such as the network, broadcast, first and last host addresses, the host count and the masks.
+// The prefix length is adjusted to lie between 0 and 32.
+//
+// For prefix lengths of 30 and below, the network and broadcast addresses are excluded from the hosts.
+// With prefix length 31, both addresses are hosts, as with point-to-point links in RFC 3021.
+// With prefix length 32, the single address is the only host.
+// For a subnet, the report is for the prefix block containing the lowest address.
+func (addr *IPv4Address) CIDRSummary(prefixLen BitCount) CIDRReport {
+	prefixLen = checkBitCount(prefixLen, IPv4BitCount)
+	block := addr.init().GetLower().ToPrefixBlockLen(prefixLen)
+	network := block.GetLower().WithoutPrefixLen()
+	broadcast := block.GetUpper().WithoutPrefixLen()
+	report := CIDRReport{
+		NetworkAddress:   network,
+		BroadcastAddress: broadcast,
+		FirstHost:        network,
+		LastHost:         broadcast,
+		HostCount:        int64(1) << uint(IPv4BitCount-prefixLen),
+		PrefixLen:        prefixLen,
+		NetworkMask:      IPv4Network.GetNetworkMask(prefixLen).String(),
+		WildcardMask:     IPv4Network.GetHostMask(prefixLen).String(),
+	}
+	if prefixLen < IPv4BitCount-1 {
+		report.FirstHost = network.Increment(1)
+		report.LastHost = broadcast.Increment(-1)
+		report.HostCount -= 2
+	}
+	return report
+}
+
+// CIDRReport is the subnet calculator report for an IPv4 prefix block, as returned by IPv4Address.CIDRSummary.
+type CIDRReport struct {
+	// NetworkAddress is the lowest address of the block.
+	NetworkAddress *IPv4Address
+	// BroadcastAddress is the highest address of the block.
+	BroadcastAddress *IPv4Address
+	// FirstHost and LastHost are the lowest and highest usable host addresses of the block.
+	FirstHost, LastHost *IPv4Address
+	// HostCount is the number of usable host addresses.
+	HostCount int64
+	// PrefixLen is the prefix length of the block.
+	PrefixLen BitCount
+	// NetworkMask and WildcardMask are the network and host masks for the prefix length, such as "255.255.255.0" and "0.0.0.255".
+	NetworkMask, WildcardMask string
+}
+
 // Mask applies the given mask to all addresses represented by this IPv4Address.
 // The mask is applied to all individual addresses.
 //
@@ -600,6 +728,29 @@ func (addr *IPv4Address) Uint32Value() uint32 {
 	return addr.GetSection().Uint32Value()
 }
 
+// ToGeoIPKey returns the key for looking up this address in GeoIP databases keyed by the 32-bit integer value of IPv4 addresses.
+// For a subnet, the key of the lowest address is returned.
+// It is equivalent to Uint32Value.
+func (addr *IPv4Address) ToGeoIPKey() uint32 {
+	return addr.Uint32Value()
+}
+
+// ToUint32NetworkMask returns the network mask as a uint32 bit mask, such as 0xffffff00 for prefix length 24.
+//
+// If this address has a prefix length, the mask is the network mask for that prefix length.
+// Otherwise, if this address is itself a network mask, as determined by GetBlockMaskPrefixLen, the mask is the value of this address.
+// Otherwise, an error is returned.
+func (addr *IPv4Address) ToUint32NetworkMask() (uint32, address_error.AddressValueError) {
+	addr = addr.init()
+	prefLen := addr.GetPrefixLen()
+	if prefLen == nil {
+		if prefLen = addr.GetBlockMaskPrefixLen(true); prefLen == nil {
+			return 0, &addressValueError{addressError: addressError{key: "ipaddress.error.notNetworkMask"}}
+		}
+	}
+	return ipv4NetworkMaskUint32(prefLen.bitCount()), nil
+}
+
 // GetNetIP returns the lowest address in this subnet or address as a net.IP.
 func (addr *IPv4Address) GetNetIP() net.IP {
 	return addr.Bytes()
@@ -714,6 +865,18 @@ func (addr *IPv4Address) IsLinkLocal() bool {
 	return addr.GetSegment(0).Matches(169) && addr.GetSegment(1).Matches(254)
 }
 
+// IsAdminLocalMulticast returns whether this address, or all addresses in the subnet, are administratively scoped multicast,
+// within "239.0.0.0/8" as in RFC 2365.
+func (addr *IPv4Address) IsAdminLocalMulticast() bool {
+	return addr.GetSegment(0).Matches(239)
+}
+
+// IsOrganizationLocalMulticast returns whether this address, or all addresses in the subnet, are organization local scope multicast,
+// within "239.192.0.0/14" as in RFC 2365.
+func (addr *IPv4Address) IsOrganizationLocalMulticast() bool {
+	return addr.IsAdminLocalMulticast() && addr.GetSegment(1).MatchesWithPrefixMask(192, 6)
+}
+
 // IsAnyLocal returns whether this address is the address which binds to any address on the local host.
 // This is the address that has the value of 0, aka the unspecified address.
 func (addr *IPv4Address) IsAnyLocal() bool {
@@ -747,6 +910,16 @@ func (addr *IPv4Address) IsLocal() bool {
 	return addr.IsLinkLocal() || addr.IsPrivate() || addr.IsAnyLocal()
 }
 
+// IsNetworkAddress returns whether this address has a prefix length and is the network address of its subnet,
+// the address whose host bits are all zero, such as "10.0.0.0" with prefix length 24.
+//
+// For a subnet, this returns true only if the host is zero for all addresses in the subnet,
+// so it returns false for a prefix block like "10.0.0.0/24" that spans all the hosts.
+// If there is no prefix length, this returns false.
+func (addr *IPv4Address) IsNetworkAddress() bool {
+	return addr.IsPrefixed() && addr.IsZeroHost()
+}
+
 // IsUnspecified returns whether this is the unspecified address.  The unspecified address is the address that is all zeros.
 func (addr *IPv4Address) IsUnspecified() bool {
 	return addr.section == nil || addr.IsZero()
@@ -765,6 +938,33 @@ func (addr *IPv4Address) Iterator() Iterator[*IPv4Address] {
 	return ipv4AddressIterator{addr.init().addrIterator(nil)}
 }
 
+// HostIterator provides an iterator to iterate through the usable host addresses of this subnet,
+// which excludes the lowest and highest addresses of the subnet, the network and broadcast addresses.
+//
+// When this represents at most two addresses, such as a /31 subnet used with point-to-point links as in RFC 3021,
+// or a single address such as a /32 subnet, all addresses are usable hosts and this is equivalent to Iterator.
+//
+// When iterating, the prefix length is preserved.
+func (addr *IPv4Address) HostIterator() Iterator[*IPv4Address] {
+	if addr.GetIPv4Count() <= 2 {
+		return addr.Iterator()
+	}
+	return newInnerIterator(addr.Iterator())
+}
+
+// PrefixLengthIterator provides an iterator to iterate through the prefix blocks of this address or subnet for each prefix length,
+// from prefix length 0 to the bit count, 33 addresses in total.
+// Each iterated element is the result of ToPrefixBlockLen for the corresponding prefix length.
+//
+// This is useful for algorithms that try all prefix lengths, such as finding the smallest containing block.
+func (addr *IPv4Address) PrefixLengthIterator() Iterator[*IPv4Address] {
+	if addr == nil {
+		return nilIterator[*IPv4Address]()
+	}
+	addr = addr.init()
+	return &prefixBlockLenIterator[*IPv4Address]{original: addr, bitCount: addr.GetBitCount()}
+}
+
 // PrefixIterator provides an iterator to iterate through the individual prefixes of this subnet,
 // each iterated element spanning the range of values for its prefix.
 //
@@ -1318,6 +1518,42 @@ func (addr *IPv4Address) CoverWithPrefixBlock() *IPv4Address {
 	return addr.init().coverWithPrefixBlock().ToIPv4()
 }
 
+// ToReachabilityBitmap produces a bitmap over the prefix blocks of length granularityBits
+// within the minimal prefix block covering this subnet, as returned by CoverWithPrefixBlock,
+// such as the reachability bitmaps used with link-state routing protocols.
+// The bit for each block, in order of increasing address with the most significant bit of the first byte first,
+// is 1 if the block is entirely within this subnet, and 0 otherwise.
+// So when this subnet does not consist of whole granular blocks, the partially included blocks are 0.
+//
+// For instance, for "10.0.0-2.*" with granularity 24, the covering block is "10.0.0.0/22",
+// there are 4 blocks of length 24, and the bitmap is the single byte 0xe0.
+//
+// An error is returned if granularityBits is less than the prefix length of the covering block, or exceeds 32.
+// Use NewIPv4AddressesFromReachabilityBitmap for the inverse.
+func (addr *IPv4Address) ToReachabilityBitmap(granularityBits BitCount) ([]byte, address_error.AddressValueError) {
+	addr = addr.init()
+	cover := addr.CoverWithPrefixBlock()
+	blockCount, err := getReachabilityBlockCount(cover, granularityBits)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap := make([]byte, (blockCount+7)>>3)
+	base := uint64(cover.Uint32Value())
+	blockShift := uint(IPv4BitCount - granularityBits)
+	blockSize := uint64(1) << blockShift
+	for iter := addr.SequentialBlockIterator(); iter.HasNext(); {
+		block := iter.Next()
+		lower := uint64(block.Uint32Value()) - base
+		upper := uint64(block.UpperUint32Value()) - base + 1
+		// only the blocks entirely within the sequential block are included
+		for i, end := (lower+blockSize-1)>>blockShift, upper>>blockShift; i < end; i++ {
+			bitmap[i>>3] |= 0x80 >> (i & 7)
+		}
+	}
+	return bitmap, nil
+}
+
 // Format implements [fmt.Formatter] interface. It accepts the formats
 //   - 'v' for the default address and section format (either the normalized or canonical string),
 //   - 's' (string) for the same,
@@ -1598,6 +1834,33 @@ func (addr *IPv4Address) ToFullString() string {
 	return addr.init().toFullString()
 }
 
+// ToPackedDecimalString produces the packed decimal string used by LDAP and X.500 directory services,
+// in which each segment is three decimal digits with leading zeros, such as "192.168.001.001".
+// The prefix length is omitted, and for a subnet the lowest address in the subnet is used.
+//
+// Use NewIPv4AddressFromPackedDecimal to parse the string.
+func (addr *IPv4Address) ToPackedDecimalString() string {
+	if addr == nil {
+		return nilString()
+	}
+	return addr.init().GetLower().WithoutPrefixLen().toFullString()
+}
+
+// ToPaddedDottedDecimalString produces a dotted decimal string in which each segment is three decimal digits with leading zeros,
+// such as "010.002.003.004", so that the strings of IPv4 addresses sort in the same order as the addresses with standard string comparison.
+// The prefix length is omitted, and for a subnet the lowest address in the subnet is used.
+// It is equivalent to ToPackedDecimalString.
+//
+// ToFullString produces the same digits, but includes any prefix length or range.
+func (addr *IPv4Address) ToPaddedDottedDecimalString() string {
+	return addr.ToPackedDecimalString()
+}
+
+// ToZeroPaddedDottedDecimalString is an alias for ToPaddedDottedDecimalString.
+func (addr *IPv4Address) ToZeroPaddedDottedDecimalString() string {
+	return addr.ToPaddedDottedDecimalString()
+}
+
 // ToInetAtonString returns a string with a format that is styled from the inet_aton routine.
 // The string can have an octal or hexadecimal radix rather than decimal.
 // When using octal,
@@ -1626,6 +1889,20 @@ func (addr *IPv4Address) ToInetAtonJoinedString(radix InetAtonRadix, joinedCount
 	return addr.GetSection().ToInetAtonJoinedString(radix, joinedCount)
 }
 
+// ToCondensedIPv4Notation returns the condensed decimal inet_aton form of this address,
+// joining the given number of the least significant segments with the preceding segment.
+// Joining 1, 2 or 3 segments results in 3, 2 or 1 divisions respectively,
+// so for "10.0.0.1" it returns "10.0.1", "10.1" or "167772161".
+//
+// An error is returned if the joined segment count is not 1, 2 or 3, or if this represents a subnet
+// and the joined segments cannot be represented as a sequential range of values.
+func (addr *IPv4Address) ToCondensedIPv4Notation(joinedSegments int) (string, address_error.AddressError) {
+	if addr == nil {
+		return nilString(), nil
+	}
+	return addr.GetSection().ToCondensedIPv4Notation(joinedSegments)
+}
+
 // ToCanonicalWildcardString produces a string similar to the canonical string and avoids the CIDR prefix length.
 // Addresses and subnets with a network prefix length will be shown with wildcards and ranges (denoted by '*' and '-')
 // instead of using the CIDR prefix length notation.
@@ -1684,6 +1961,21 @@ func (addr *IPv4Address) ToPrefixLenString() string {
 	return addr.init().toPrefixLenString()
 }
 
+// ToCIDRWithMaskString returns a string with both the CIDR prefix length and the equivalent network mask,
+// separated by a space, such as "192.168.1.0/24 255.255.255.0",
+// a format used in the configuration of many routing platforms.
+// Use NewIPv4AddressFromCIDRWithMask to parse the string.
+//
+// An error is returned if this address has no prefix length.
+func (addr *IPv4Address) ToCIDRWithMaskString() (string, address_error.AddressValueError) {
+	addr = addr.init()
+	prefLen := addr.GetPrefixLen()
+	if prefLen == nil {
+		return "", &addressValueError{addressError: addressError{key: "ipaddress.error.no.prefix.length"}}
+	}
+	return addr.ToCanonicalString() + " " + IPv4Network.GetNetworkMask(prefLen.bitCount()).ToCanonicalString(), nil
+}
+
 // ToSubnetString produces a string with specific formats for subnets.
 // The subnet string looks like "1.2.*.*" or "1:2::/16".
 //
@@ -1796,6 +2088,17 @@ func initZeroIPv4() *IPv4Address {
 	return newIPv4Address(section)
 }
 
+// NewIPv4AddressFromIGMPv3Bytes constructs an address from the 4-byte network byte order representation used in IGMPv3 source records, as in RFC 3376.
+// It is the inverse of ToIGMPv3Bytes.
+//
+// An error is returned if the data is not exactly 4 bytes.
+func NewIPv4AddressFromIGMPv3Bytes(data []byte) (*IPv4Address, address_error.AddressValueError) {
+	if len(data) != IPv4ByteCount {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipv4.invalid.byte.count"}, val: len(data)}
+	}
+	return NewIPv4AddressFromBytes(data)
+}
+
 // NewIPv4AddressFromBytes constructs an IPv4 address from the given byte slice.
 // An error is returned when the byte slice has too many bytes to match the IPv4 segment count of 4.
 // There should be 4 bytes or less, although extra leading zeros are tolerated.
@@ -1881,6 +2184,129 @@ func NewIPv4AddressFromUint32(val uint32) *IPv4Address {
 	return createAddress(section.ToSectionBase(), NoZone).ToIPv4()
 }
 
+// NewIPv4AddressFromPackedDecimal parses the packed decimal string used by LDAP and X.500 directory services,
+// in which each of the four segments is exactly three decimal digits with leading zeros, such as "192.168.001.001".
+// It is the inverse of ToPackedDecimalString.
+//
+// An error is returned if the string is not in that format or a segment value exceeds 255.
+func NewIPv4AddressFromPackedDecimal(str string) (*IPv4Address, address_error.AddressStringError) {
+	segs := strings.Split(str, IPv4SegmentSeparatorStr)
+	if len(segs) != IPv4SegmentCount {
+		return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.ipv4.invalid.segment.count"}}
+	}
+
+	bytes := make([]byte, IPv4ByteCount)
+	for i, seg := range segs {
+		if len(seg) != 3 {
+			return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.packed.decimal"}}
+		}
+
+		val := 0
+		for j := 0; j < len(seg); j++ {
+			c := seg[j]
+			if c < '0' || c > '9' {
+				return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.invalid.character"}}
+			}
+			val = val*10 + int(c-'0')
+		}
+
+		if val > IPv4MaxValuePerSegment {
+			return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.ipv4.segment.too.large"}}
+		}
+		bytes[i] = byte(val)
+	}
+	return NewIPv4AddressFromBytes(bytes)
+}
+
+// NewIPv4AddressFromCIDRWithMask parses a string with both a CIDR prefix length and the equivalent network mask,
+// separated by white space, such as "192.168.1.0/24 255.255.255.0".
+// It is the inverse of ToCIDRWithMaskString.
+//
+// An error is returned if the string does not consist of a prefixed IPv4 address followed by an IPv4 address,
+// or if that second address is not the network mask for the prefix length.
+func NewIPv4AddressFromCIDRWithMask(str string) (*IPv4Address, address_error.AddressStringError) {
+	fields := strings.Fields(str)
+	if len(fields) != 2 {
+		return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.cidr.with.mask"}}
+	}
+
+	addr, mask := NewIPAddressString(fields[0]).GetAddress(), NewIPAddressString(fields[1]).GetAddress()
+	if !addr.IsIPv4() || !mask.IsIPv4() || !addr.IsPrefixed() || mask.IsPrefixed() {
+		return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.cidr.with.mask"}}
+	}
+
+	if !mask.Equal(IPv4Network.GetNetworkMask(addr.GetPrefixLen().bitCount())) {
+		return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.mask.prefix.mismatch"}}
+	}
+	return addr.ToIPv4(), nil
+}
+
+// NewIPv4AddressesFromReachabilityBitmap constructs the prefix blocks represented by a bitmap produced by ToReachabilityBitmap,
+// given the same granularity and a subnet with the same covering prefix block as the subnet used to produce the bitmap.
+// The blocks for the bits that are 1 are merged into the minimal list of prefix blocks, in sorted order.
+//
+// An error is returned if granularityBits is less than the prefix length of the covering block of the given subnet, or exceeds 32,
+// or if the bitmap size does not match the number of blocks.
+func NewIPv4AddressesFromReachabilityBitmap(subnet *IPv4Address, granularityBits BitCount, bitmap []byte) ([]*IPv4Address, address_error.AddressValueError) {
+	cover := subnet.init().CoverWithPrefixBlock()
+	blockCount, err := getReachabilityBlockCount(cover, granularityBits)
+	if err != nil {
+		return nil, err
+	} else if uint64(len(bitmap)) != (blockCount+7)>>3 {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.bitmap.size"}, val: len(bitmap)}
+	}
+
+	var blocks []*IPv4Address
+	base := uint64(cover.Uint32Value())
+	blockShift := uint(IPv4BitCount - granularityBits)
+	prefLen := cacheBitCount(granularityBits)
+	for i := uint64(0); i < blockCount; i++ {
+		if bitmap[i>>3]&(0x80>>(i&7)) != 0 {
+			blocks = append(blocks, NewIPv4AddressFromPrefixedUint32(uint32(base+(i<<blockShift)), prefLen))
+		}
+	}
+
+	if len(blocks) == 0 {
+		return []*IPv4Address{}, nil
+	}
+	return blocks[0].MergeToPrefixBlocks(blocks[1:]...), nil
+}
+
+func getReachabilityBlockCount(cover *IPv4Address, granularityBits BitCount) (uint64, address_error.AddressValueError) {
+	coverLen := cover.GetPrefixLenForSingleBlock().bitCount()
+	if granularityBits < coverLen || granularityBits > IPv4BitCount {
+		return 0, &addressValueError{addressError: addressError{key: "ipaddress.error.granularity"}, val: granularityBits}
+	}
+	return uint64(1) << uint(granularityBits-coverLen), nil
+}
+
+// NewIPv4NetworkMask returns the IPv4 network mask for the given prefix length, such as "255.255.255.0" for 24.
+// The mask has no prefix length.  It returns an error if the prefix length is negative or exceeds 32.
+func NewIPv4NetworkMask(prefixLen BitCount) (*IPv4Address, address_error.AddressValueError) {
+	if prefixLen < 0 || prefixLen > IPv4BitCount {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.prefixSize"}, val: prefixLen}
+	}
+	return IPv4Network.GetNetworkMask(prefixLen), nil
+}
+
+// NewIPv4HostMask returns the IPv4 host mask for the given prefix length, such as "0.0.0.255" for 24.
+// The mask has no prefix length.  It returns an error if the prefix length is negative or exceeds 32.
+func NewIPv4HostMask(prefixLen BitCount) (*IPv4Address, address_error.AddressValueError) {
+	if prefixLen < 0 || prefixLen > IPv4BitCount {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.prefixSize"}, val: prefixLen}
+	}
+	return IPv4Network.GetHostMask(prefixLen), nil
+}
+
+// NewIPv4AddressFromUint32NetworkMask constructs an IPv4 network mask address from the given uint32 bit mask, such as "255.255.255.0" from 0xffffff00.
+// It returns an error if the value is not a CIDR network mask, all one-bits followed by all zero-bits.
+func NewIPv4AddressFromUint32NetworkMask(mask uint32) (*IPv4Address, address_error.AddressValueError) {
+	if hostMask := ^mask; hostMask&(hostMask+1) != 0 {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.notNetworkMask"}}
+	}
+	return NewIPv4AddressFromUint32(mask), nil
+}
+
 // NewIPv4AddressFromPrefixedUint32 constructs an IPv4 address or prefix block from the given value and prefix length.
 // If the address has a zero host for the given prefix length, the returned address will be the prefix block.
 func NewIPv4AddressFromPrefixedUint32(val uint32, prefixLength PrefixLen) *IPv4Address {
@@ -1970,3 +2396,10 @@ func fromIPv4IPKey(key *keyContents) *IPv4Address {
 		},
 	)
 }
+
+func ipv4NetworkMaskUint32(prefLen BitCount) uint32 {
+	if prefLen <= 0 {
+		return 0
+	}
+	return ^uint32(0) << uint(IPv4BitCount-prefLen)
+}
@@ -1307,6 +1307,28 @@ func (section *IPv4AddressSection) ToInetAtonJoinedString(radix InetAtonRadix, j
 	return section.ToNormalizedJoinedString(stringParams, joinedCount)
 }
 
+// ToCondensedIPv4Notation returns the condensed decimal inet_aton form of this section,
+// joining the given number of the least significant segments with the preceding segment.
+// Joining 1, 2 or 3 segments results in 3, 2 or 1 divisions respectively,
+// so for "10.0.0.1" it returns "10.0.1", "10.1" or "167772161".
+//
+// This is equivalent to ToInetAtonJoinedString with the decimal radix, but with the count of joined segments validated.
+// An error is returned if the joined segment count is not 1, 2 or 3, or if this represents a subnet section
+// and the joined segments cannot be represented as a sequential range of values.
+func (section *IPv4AddressSection) ToCondensedIPv4Notation(joinedSegments int) (string, address_error.AddressError) {
+	if section == nil {
+		return nilString(), nil
+	} else if joinedSegments < 1 || joinedSegments >= IPv4SegmentCount {
+		return "", &addressValueError{addressError: addressError{key: "ipaddress.error.joined.segment.count"}, val: joinedSegments}
+	}
+
+	str, err := section.ToInetAtonJoinedString(InetAtonRadix_decimal, joinedSegments)
+	if err != nil {
+		return "", err
+	}
+	return str, nil
+}
+
 func (section *IPv4AddressSection) toNormalizedString(stringOptions address_string.IPStringOptions) string {
 	return toNormalizedIPString(stringOptions, section)
 }
@@ -1,10 +1,12 @@
 package goip
 
 import (
+	"encoding/base32"
 	"fmt"
 	"math/big"
 	"net"
 	"net/netip"
+	"strings"
 	"unsafe"
 
 	"github.com/pchchv/goip/address_error"
@@ -41,11 +43,38 @@ const (
 	IPv6SegmentMaxChars              = 4
 	ipv6BitsToSegmentBitshift        = 4
 	IPv6AlternativeRangeSeparatorStr = AlternativeRangeSeparatorStr
+	IPv6OnionSuffix                  = ".onion"
 )
 
 var (
 	zeroIPv6 = initZeroIPv6()
 	ipv6All  = zeroIPv6.ToPrefixBlockLen(0)
+
+	onionEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+	// the blocks which are not routable in the global internet,
+	// less the globally reachable blocks within them, from the IANA IPv6 Special-Purpose Address Registry
+	ipv6NonGlobalBlocks = spanNonGlobalBlocks([]*IPv6Address{
+		NewIPv6AddressFromPrefixedUint64(0, 0, cacheBitCount(128)),                 // ::/128 unspecified
+		NewIPv6AddressFromPrefixedUint64(0, 1, cacheBitCount(128)),                 // ::1/128 loopback
+		NewIPv6AddressFromPrefixedUint64(0, 0xffff00000000, cacheBitCount(96)),     // ::ffff:0:0/96 IPv4-mapped
+		NewIPv6AddressFromPrefixedUint64(0x0064ff9b00010000, 0, cacheBitCount(48)), // 64:ff9b:1::/48 local-use IPv4/IPv6 translation, RFC 8215
+		NewIPv6AddressFromPrefixedUint64(0x0100000000000000, 0, cacheBitCount(64)), // 100::/64 discard-only, RFC 6666
+		NewIPv6AddressFromPrefixedUint64(0x2001000000000000, 0, cacheBitCount(23)), // 2001::/23 IETF protocol assignments, RFC 2928
+		NewIPv6AddressFromPrefixedUint64(0x20010db800000000, 0, cacheBitCount(32)), // 2001:db8::/32 documentation, RFC 3849
+		NewIPv6AddressFromPrefixedUint64(0xfc00000000000000, 0, cacheBitCount(7)),  // fc00::/7 unique local, RFC 4193
+		NewIPv6AddressFromPrefixedUint64(0xfe80000000000000, 0, cacheBitCount(10)), // fe80::/10 link local
+		NewIPv6AddressFromPrefixedUint64(0xfec0000000000000, 0, cacheBitCount(10)), // fec0::/10 site local, deprecated by RFC 3879
+		NewIPv6AddressFromPrefixedUint64(0xff00000000000000, 0, cacheBitCount(8)),  // ff00::/8 multicast
+	}, []*IPv6Address{
+		NewIPv6AddressFromPrefixedUint64(0x2001000100000000, 1, cacheBitCount(128)), // 2001:1::1/128 port control protocol anycast, RFC 7723
+		NewIPv6AddressFromPrefixedUint64(0x2001000100000000, 2, cacheBitCount(128)), // 2001:1::2/128 TURN anycast, RFC 8155
+		NewIPv6AddressFromPrefixedUint64(0x2001000100000000, 3, cacheBitCount(128)), // 2001:1::3/128 DNS-SD service registration protocol anycast, RFC 9665
+		NewIPv6AddressFromPrefixedUint64(0x2001000300000000, 0, cacheBitCount(32)),  // 2001:3::/32 AMT, RFC 7450
+		NewIPv6AddressFromPrefixedUint64(0x2001000401120000, 0, cacheBitCount(48)),  // 2001:4:112::/48 AS112-v6, RFC 7535
+		NewIPv6AddressFromPrefixedUint64(0x2001002000000000, 0, cacheBitCount(28)),  // 2001:20::/28 ORCHIDv2, RFC 7343
+		NewIPv6AddressFromPrefixedUint64(0x2001003000000000, 0, cacheBitCount(28)),  // 2001:30::/28 drone remote ID protocol entity tags, RFC 9374
+	})
 )
 
 // Zone represents an IPv6 address zone or scope.
@@ -63,6 +92,86 @@ func (zone Zone) String() string {
 	return string(zone)
 }
 
+// IPv4in6Type is the kind of 4-in-6 address, an IPv6 address that embeds an IPv4 address.
+type IPv4in6Type int
+
+const (
+	IPv4in6None            IPv4in6Type = iota // not a recognized 4-in-6 address
+	IPv4in6Mapped                             // IPv4-mapped, "::ffff:0:0/96"
+	IPv4in6Compatible                         // IPv4-compatible, "::/96"
+	IPv4in6SixToFour                          // 6to4, "2002::/16", RFC 3056
+	IPv4in6Teredo                             // Teredo, "2001::/32", RFC 4380
+	IPv4in6ISATAP                             // ISATAP, "fe80::5efe:0:0/96" or "fe80::200:5efe:0:0/96", RFC 5214
+	IPv4in6NAT64                              // NAT64 local-use prefix, "64:ff9b:1::/48", RFC 8215
+	IPv4in6WellKnownPrefix                    // NAT64 well-known prefix, "64:ff9b::/96", RFC 6052
+)
+
+// String returns a short name for the 4-in-6 type.
+func (t IPv4in6Type) String() string {
+	switch t {
+	case IPv4in6Mapped:
+		return "IPv4-mapped"
+	case IPv4in6Compatible:
+		return "IPv4-compatible"
+	case IPv4in6SixToFour:
+		return "6to4"
+	case IPv4in6Teredo:
+		return "Teredo"
+	case IPv4in6ISATAP:
+		return "ISATAP"
+	case IPv4in6NAT64:
+		return "NAT64"
+	case IPv4in6WellKnownPrefix:
+		return "well-known prefix"
+	}
+	return "none"
+}
+
+// IPv6MulticastScope is the scope of an IPv6 multicast address, the 4-bit scop field following the flags in the first segment, as in RFC 4291 and RFC 7346.
+// The value of each scope is the value of the scop field.
+type IPv6MulticastScope int
+
+const (
+	MulticastScopeNone              IPv6MulticastScope = -1  // not a multicast address, or a subnet with multiple scopes
+	MulticastScopeReserved          IPv6MulticastScope = 0x0 // reserved
+	MulticastScopeInterfaceLocal    IPv6MulticastScope = 0x1 // interface-local, "ff01::/16"
+	MulticastScopeLinkLocal         IPv6MulticastScope = 0x2 // link-local, "ff02::/16"
+	MulticastScopeRealmLocal        IPv6MulticastScope = 0x3 // realm-local, "ff03::/16", RFC 7346
+	MulticastScopeAdminLocal        IPv6MulticastScope = 0x4 // admin-local, "ff04::/16"
+	MulticastScopeSiteLocal         IPv6MulticastScope = 0x5 // site-local, "ff05::/16"
+	MulticastScopeOrganizationLocal IPv6MulticastScope = 0x8 // organization-local, "ff08::/16"
+	MulticastScopeGlobal            IPv6MulticastScope = 0xe // global, "ff0e::/16"
+)
+
+// String returns a short name for the multicast scope.
+// Scope values without a name are either reserved or unassigned.
+func (scope IPv6MulticastScope) String() string {
+	switch scope {
+	case MulticastScopeNone:
+		return "none"
+	case MulticastScopeInterfaceLocal:
+		return "interface-local"
+	case MulticastScopeLinkLocal:
+		return "link-local"
+	case MulticastScopeRealmLocal:
+		return "realm-local"
+	case MulticastScopeAdminLocal:
+		return "admin-local"
+	case MulticastScopeSiteLocal:
+		return "site-local"
+	case MulticastScopeOrganizationLocal:
+		return "organization-local"
+	case MulticastScopeGlobal:
+		return "global"
+	case MulticastScopeReserved, 0xf:
+		return "reserved"
+	}
+	return "unassigned"
+}
+
+// nat64ReservedByteIndex is the index of the byte holding bits 64 to 71, which are reserved in NAT64 addresses by RFC 6052.
+const nat64ReservedByteIndex = 8
+
 // IPv6Address is an IPv6 address, or a subnet of multiple IPv6 addresses.
 // An IPv6 address is composed of 8 2-byte segments and can optionally have an associated prefix length.
 // Each segment can represent a single value or a range of values.
@@ -140,6 +249,17 @@ func (addr *IPv6Address) GetCount() *big.Int {
 	return addr.getCount()
 }
 
+// UsableHostCount returns the number of usable host addresses in the prefix block of this address or subnet,
+// or in this subnet if it has no prefix length.
+// IPv6 has no broadcast address, so all addresses are usable and the count is the size of the block,
+// such as 2 to the power of 64 for a /64 block.
+func (addr *IPv6Address) UsableHostCount() *big.Int {
+	if addr == nil {
+		return bigZero()
+	}
+	return addr.init().ToPrefixBlock().GetCount()
+}
+
 // IsMultiple returns true if this represents more than a single individual address,
 // whether it is a subnet of multiple addresses.
 func (addr *IPv6Address) IsMultiple() bool {
@@ -354,6 +474,18 @@ func (addr *IPv6Address) Bytes() []byte {
 	return addr.init().section.Bytes()
 }
 
+// ToMLDv2Bytes returns the 16-byte network byte order representation of this address used in the source address lists of MLDv2 source records, as in RFC 3810.
+// Any zone is not included.
+// It is the inverse of NewIPv6AddressFromMLDv2Bytes.
+//
+// An error is returned if this is a subnet with multiple addresses.
+func (addr *IPv6Address) ToMLDv2Bytes() ([]byte, address_error.AddressError) {
+	if addr.IsMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	}
+	return addr.Bytes(), nil
+}
+
 // UpperBytes returns the highest address in this subnet or address as a byte slice.
 func (addr *IPv6Address) UpperBytes() []byte {
 	return addr.init().section.UpperBytes()
@@ -487,6 +619,76 @@ func (addr *IPv6Address) IsIPv4Translatable() bool { //rfc 2765
 		addr.GetSegment(3).IsZero()
 }
 
+// IsNatPt returns whether the address or all addresses in the subnet have the NAT-PT prefix ::ffff:0:0:0/96 as in RFC 2766.
+// NAT-PT is deprecated by RFC 4966, but the prefix is the same as that of IPv4-translatable addresses, so this is equivalent to IsIPv4Translatable.
+func (addr *IPv6Address) IsNatPt() bool { //rfc 2766
+	return addr.IsIPv4Translatable()
+}
+
+// GetNatPtIPv4 returns the IPv4 address embedded in the lowest 4 bytes of this NAT-PT address.
+// It returns false if this address does not have the NAT-PT prefix ::ffff:0:0:0/96,
+// or if the embedded IPv4 segments cannot be represented, which can only happen with subnets.
+func (addr *IPv6Address) GetNatPtIPv4() (*IPv4Address, bool) {
+	if !addr.IsNatPt() {
+		return nil, false
+	}
+
+	ipv4, err := addr.GetEmbeddedIPv4Address()
+	if err != nil {
+		return nil, false
+	}
+	return ipv4, true
+}
+
+// GetNAT64IPv4 returns the IPv4 address embedded in this NAT64 address as in RFC 6052,
+// when this address has the NAT64 well-known prefix "64:ff9b::/96" or the NAT64 local-use prefix "64:ff9b:1::/48" of RFC 8215.
+// Use GetNAT64IPv4WithPrefix for a network-specific prefix.
+// It returns false if this address has neither prefix, or if this is a subnet.
+func (addr *IPv6Address) GetNAT64IPv4() (*IPv4Address, bool) {
+	switch addr.Detect4in6Type() {
+	case IPv4in6WellKnownPrefix:
+		return addr.getNAT64IPv4(IPv6BitCount - IPv4BitCount)
+	case IPv4in6NAT64:
+		return addr.getNAT64IPv4(48)
+	}
+	return nil, false
+}
+
+// GetNAT64IPv4WithPrefix returns the IPv4 address embedded in this NAT64 address as in RFC 6052,
+// when this address is within the given NAT64 prefix, which can be the well-known prefix or a network-specific prefix.
+// The prefix length of the given prefix determines the location of the embedded address.
+// It returns false if the prefix length is not 32, 40, 48, 56, 64 or 96, if this address is not within the given prefix,
+// or if this is a subnet.
+func (addr *IPv6Address) GetNAT64IPv4WithPrefix(prefix *IPv6Address) (*IPv4Address, bool) {
+	if prefix == nil {
+		return nil, false
+	}
+
+	prefLen := prefix.GetPrefixLen()
+	if !isNAT64PrefixLen(prefLen) || !prefix.ToPrefixBlock().Contains(addr) {
+		return nil, false
+	}
+	return addr.getNAT64IPv4(prefLen.bitCount())
+}
+
+func (addr *IPv6Address) getNAT64IPv4(prefLen BitCount) (*IPv4Address, bool) {
+	addr = addr.init()
+	if addr.IsMultiple() {
+		return nil, false
+	}
+
+	bytes := addr.Bytes()
+	ipv4Bytes := make([]byte, 0, IPv4ByteCount)
+	for i := int(prefLen >> 3); len(ipv4Bytes) < IPv4ByteCount; i++ {
+		if i != nat64ReservedByteIndex {
+			ipv4Bytes = append(ipv4Bytes, bytes[i])
+		}
+	}
+
+	ipv4, err := NewIPv4AddressFromBytes(ipv4Bytes)
+	return ipv4, err == nil
+}
+
 // IsWellKnownIPv4Translatable returns whether the address has the well-known prefix for IPv4-translatable addresses as in RFC 6052 and RFC 6144.
 func (addr *IPv6Address) IsWellKnownIPv4Translatable() bool { //rfc 6052 rfc 6144
 	//64:ff9b::/96 prefix for auto ipv4/ipv6 translation
@@ -501,6 +703,34 @@ func (addr *IPv6Address) IsWellKnownIPv4Translatable() bool { //rfc 6052 rfc 614
 	return false
 }
 
+// Detect4in6Type returns the kind of 4-in-6 address this is, an address with an IPv4 address embedded within.
+//
+// The prefixes are checked in the order IPv4-mapped, IPv4-compatible, 6to4, Teredo, ISATAP,
+// the NAT64 local-use prefix and the NAT64 well-known prefix, and the first match is returned.
+// For a subnet, the type is returned only when all addresses in the subnet match.
+// If there is no match, IPv4in6None is returned.
+func (addr *IPv6Address) Detect4in6Type() IPv4in6Type {
+	addr = addr.init()
+	switch {
+	case addr.IsIPv4Mapped():
+		return IPv4in6Mapped
+	case addr.IsIPv4Compatible():
+		return IPv4in6Compatible
+	case addr.Is6To4():
+		return IPv4in6SixToFour
+	case addr.IsTeredo():
+		return IPv4in6Teredo
+	case addr.IsIsatap():
+		return IPv4in6ISATAP
+	case addr.GetSegment(0).Matches(0x64) && addr.GetSegment(1).Matches(0xff9b) && addr.GetSegment(2).Matches(1):
+		// 64:ff9b:1::/48 local-use prefix, rfc 8215
+		return IPv4in6NAT64
+	case addr.IsWellKnownIPv4Translatable():
+		return IPv4in6WellKnownPrefix
+	}
+	return IPv4in6None
+}
+
 // IsMulticast returns whether this address or subnet is entirely multicast.
 func (addr *IPv6Address) IsMulticast() bool {
 	// 11111111...
@@ -660,6 +890,15 @@ func (addr *IPv6Address) Uint64Values() (high, low uint64) {
 	return addr.GetSection().Uint64Values()
 }
 
+// ToGeoIPKey returns the key for looking up this address in GeoIP databases keyed by the 128-bit integer value of IPv6 addresses,
+// as the high and low 64 bits in that order.
+// For a subnet, the key of the lowest address is returned.
+// It is equivalent to Uint64Values.
+func (addr *IPv6Address) ToGeoIPKey() [2]uint64 {
+	high, low := addr.Uint64Values()
+	return [2]uint64{high, low}
+}
+
 // UpperUint64Values returns the highest address in
 // the address section range as a pair of uint64 values.
 func (addr *IPv6Address) UpperUint64Values() (high, low uint64) {
@@ -801,6 +1040,50 @@ func (addr *IPv6Address) IsSiteLocal() bool {
 		firstSeg.MatchesWithPrefixMask(0xfec0, 10) // deprecated RFC 3879
 }
 
+// GetMulticastScope returns the scope of this multicast address, from the scop field of the first segment.
+// For a subnet, the scope is returned only when all addresses in the subnet have the same scope.
+// If this is not multicast, or the addresses of the subnet have different scopes, MulticastScopeNone is returned.
+func (addr *IPv6Address) GetMulticastScope() IPv6MulticastScope {
+	if !addr.IsMulticast() {
+		return MulticastScopeNone
+	}
+
+	firstSeg := addr.GetSegment(0)
+	scope := firstSeg.GetSegmentValue() & 0xf
+	if !firstSeg.MatchesWithMask(scope, 0xf) {
+		return MulticastScopeNone
+	}
+	return IPv6MulticastScope(scope)
+}
+
+// IsAdminLocalMulticast returns whether this address, or all addresses in the subnet, are admin-local multicast, "ffx4::/16".
+func (addr *IPv6Address) IsAdminLocalMulticast() bool {
+	return addr.GetMulticastScope() == MulticastScopeAdminLocal
+}
+
+// IsOrganizationLocalMulticast returns whether this address, or all addresses in the subnet, are organization-local multicast, "ffx8::/16".
+func (addr *IPv6Address) IsOrganizationLocalMulticast() bool {
+	return addr.GetMulticastScope() == MulticastScopeOrganizationLocal
+}
+
+// ToSolicitedNodeMulticast returns the solicited-node multicast address for this unicast address,
+// used by IPv6 Neighbor Discovery, as defined in RFC 4291 section 2.7.1.
+// It is the address in "ff02::1:ff00:0/104" whose low 24 bits are the low 24 bits of this address,
+// so for "fe80::2aa:ff:fe28:9c5a" it is "ff02::1:ff28:9c5a".  The result has no prefix length or zone.
+//
+// An error is returned if this is a subnet with multiple addresses, or if this is a multicast, loopback or unspecified address.
+func (addr *IPv6Address) ToSolicitedNodeMulticast() (*IPv6Address, address_error.AddressError) {
+	addr = addr.init()
+	if addr.IsMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	} else if addr.IsMulticast() || addr.IsLoopback() || addr.IsUnspecified() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.solicited.node"}}
+	}
+
+	_, low := addr.Uint64Values()
+	return NewIPv6AddressFromUint64(0xff02000000000000, 0x00000001ff000000|low&0xffffff), nil
+}
+
 // IsAnyLocal returns whether this address is
 // the address which binds to any address on the local host.
 // This is the address that has the value of 0, aka the unspecified address.
@@ -842,6 +1125,16 @@ func (addr *IPv6Address) IsLocal() bool {
 	return addr.IsLinkLocal() || addr.IsSiteLocal() || addr.IsUniqueLocal() || addr.IsAnyLocal()
 }
 
+// IsSubnetRouterAnycast returns whether this address has a prefix length and is the subnet-router anycast address for its subnet,
+// the address whose host bits are all zero, as defined in RFC 4291 section 2.6.1.
+//
+// For a subnet, this returns true only if the host is zero for all addresses in the subnet,
+// so it returns false for a prefix block like "1:2:3:4::/64" that spans all the hosts.
+// If there is no prefix length, this returns false.
+func (addr *IPv6Address) IsSubnetRouterAnycast() bool {
+	return addr.IsPrefixed() && addr.IsZeroHost()
+}
+
 // IsUnspecified returns whether this is the unspecified address.
 // The unspecified address is the address that is all zeros.
 func (addr *IPv6Address) IsUnspecified() bool {
@@ -864,6 +1157,20 @@ func (addr *IPv6Address) IsLoopback() bool {
 	return addr.GetSegment(i).Matches(1)
 }
 
+// IsGloballyRoutable returns whether this address, or every address in this subnet, can be routed in the global internet.
+// It returns false if any address is unspecified, loopback, IPv4-mapped, local-use IPv4/IPv6 translation (RFC 8215), discard-only (RFC 6666),
+// documentation (RFC 3849), unique local (RFC 4193), link local, site local, or multicast,
+// or is within the IETF protocol assignments block "2001::/23" (RFC 2928) outside of the globally reachable blocks it contains, such as "2001:3::/32".
+func (addr *IPv6Address) IsGloballyRoutable() bool {
+	addr = addr.init()
+	for _, block := range ipv6NonGlobalBlocks {
+		if addr.Intersect(block) != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // Iterator provides an iterator to iterate through the individual addresses of this address or subnet.
 //
 // When iterating, the prefix length is preserved.  Remove it using WithoutPrefixLen prior to iterating if you wish to drop it from all individual addresses.
@@ -876,6 +1183,27 @@ func (addr *IPv6Address) Iterator() Iterator[*IPv6Address] {
 	return ipv6AddressIterator{addr.init().addrIterator(nil)}
 }
 
+// HostIterator provides an iterator to iterate through the host addresses of this subnet.
+// Since IPv6 has no broadcast address, and the lowest address is usable as the subnet-router anycast address,
+// all addresses are included and this is equivalent to Iterator.
+// It is provided for symmetry with IPv4Address.HostIterator.
+func (addr *IPv6Address) HostIterator() Iterator[*IPv6Address] {
+	return addr.Iterator()
+}
+
+// PrefixLengthIterator provides an iterator to iterate through the prefix blocks of this address or subnet for each prefix length,
+// from prefix length 0 to the bit count, 129 addresses in total.
+// Each iterated element is the result of ToPrefixBlockLen for the corresponding prefix length.
+//
+// This is useful for algorithms that try all prefix lengths, such as finding the smallest containing block.
+func (addr *IPv6Address) PrefixLengthIterator() Iterator[*IPv6Address] {
+	if addr == nil {
+		return nilIterator[*IPv6Address]()
+	}
+	addr = addr.init()
+	return &prefixBlockLenIterator[*IPv6Address]{original: addr, bitCount: addr.GetBitCount()}
+}
+
 // BlockIterator iterates through the addresses that can be obtained by iterating through all the upper segments up to the given segment count.
 // The segments following remain the same in all iterated addresses.
 func (addr *IPv6Address) BlockIterator(segmentCount int) Iterator[*IPv6Address] {
@@ -1797,6 +2125,32 @@ func (addr *IPv6Address) ToCompressedString() string {
 	return addr.init().toCompressedString()
 }
 
+// ToCanonicalCompressedString produces a string that follows the zero compression rules of RFC 5952 section 4.2 exactly.
+// Only segments that are zero are compressed, a single zero-segment is never compressed,
+// and when there are multiple runs of zero-segments of the same maximal length, the leftmost run is compressed.
+//
+// This differs from ToCanonicalString only for subnets with a prefix length,
+// for which ToCanonicalString will also compress the host segments of a prefix block.
+// For a single address the two strings are the same.
+func (addr *IPv6Address) ToCanonicalCompressedString() string {
+	if addr == nil {
+		return nilString()
+	}
+	addr = addr.init()
+	return addr.GetSection().toNormalizedZonedString(ipv6RFC5952Params, addr.zone)
+}
+
+// ToMaxCompressedString produces the shortest string that uses the IPv6 compression notation '::',
+// compressing the longest run of zero and/or host segments, even when that run is a single segment,
+// so "1:0:2:3:4:5:6:7" becomes "1::2:3:4:5:6:7".
+// The leftmost run is chosen when there are multiple runs of the same maximal length.
+//
+// Unlike ToCanonicalCompressedString, the result may not follow RFC 5952, which disallows compressing a single zero-segment.
+// The result is the same as that of ToCompressedString.
+func (addr *IPv6Address) ToMaxCompressedString() string {
+	return addr.ToCompressedString()
+}
+
 // ToNormalizedWildcardString produces a string similar to the normalized string but avoids the CIDR prefix length.
 // CIDR addresses will be shown with wildcards and ranges (denoted by '*' and '-') instead of using the CIDR prefix notation.
 func (addr *IPv6Address) ToNormalizedWildcardString() string {
@@ -1922,6 +2276,17 @@ func (addr *IPv6Address) ToFullString() string {
 	return addr.init().toFullString()
 }
 
+// ToZeroPaddedHexString produces a string in which each segment is four hexadecimal digits with leading zeros and no segments are compressed,
+// such as "2001:0db8:0000:0000:0000:0000:0000:0001".
+// The prefix length is omitted, and for a subnet the lowest address in the subnet is used.
+// Any zone is included.
+func (addr *IPv6Address) ToZeroPaddedHexString() string {
+	if addr == nil {
+		return nilString()
+	}
+	return addr.init().GetLower().WithoutPrefixLen().toFullString()
+}
+
 // ToPrefixLenString returns a string with a CIDR network prefix length if this address has a network prefix length.
 // For IPv6, a zero host section will be compressed with "::".
 // For IPv4 the string is equivalent to the canonical string.
@@ -2013,6 +2378,64 @@ func assignIPv6Cache(zoneVal Zone, cache *addressCache) {
 	}
 }
 
+// NewIPv6FromNAT64 constructs the IPv6 address embedding the given IPv4 address within the given NAT64 prefix, as in RFC 6052.
+// The prefix can be the well-known prefix "64:ff9b::/96" or a network-specific prefix,
+// and its prefix length determines the location of the embedded address.
+// Bits 64 to 71 are reserved, so the embedded address skips them, and any bits following the embedded address are zero.
+// The resulting address has no prefix length.
+// Use GetNAT64IPv4WithPrefix for the reverse.
+//
+// An error is returned if the prefix length is not 32, 40, 48, 56, 64 or 96, or if the IPv4 address is nil or a subnet.
+func NewIPv6FromNAT64(prefix *IPv6Address, ipv4 *IPv4Address) (*IPv6Address, address_error.AddressError) {
+	if prefix == nil || !isNAT64PrefixLen(prefix.GetPrefixLen()) {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.nat64.prefix.length"}}
+	} else if ipv4 == nil || ipv4.IsMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	}
+
+	prefixByteCount := int(prefix.GetPrefixLen().bitCount() >> 3)
+	bytes := make([]byte, IPv6ByteCount)
+	copy(bytes, prefix.Bytes()[:prefixByteCount])
+	i := prefixByteCount
+	for _, b := range ipv4.Bytes() {
+		if i == nat64ReservedByteIndex {
+			i++
+		}
+		bytes[i] = b
+		i++
+	}
+
+	res, err := NewIPv6AddressFromBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// isNAT64PrefixLen returns whether the prefix length is allowed for NAT64 prefixes by RFC 6052.
+func isNAT64PrefixLen(prefLen PrefixLen) bool {
+	if prefLen == nil {
+		return false
+	}
+
+	switch prefLen.bitCount() {
+	case 32, 40, 48, 56, 64, 96:
+		return true
+	}
+	return false
+}
+
+// NewIPv6AddressFromMLDv2Bytes constructs an address from the 16-byte network byte order representation used in MLDv2 source records, as in RFC 3810.
+// It is the inverse of ToMLDv2Bytes.
+//
+// An error is returned if the data is not exactly 16 bytes.
+func NewIPv6AddressFromMLDv2Bytes(data []byte) (*IPv6Address, address_error.AddressValueError) {
+	if len(data) != IPv6ByteCount {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipv6.invalid.byte.count"}, val: len(data)}
+	}
+	return NewIPv6AddressFromBytes(data)
+}
+
 // NewIPv6AddressFromBytes constructs an IPv6 address from the given byte slice.
 // An error is returned when the byte slice has too many bytes to match the IPv6 segment count of 8.
 // There should be 16 bytes or less, although extra leading zeros are tolerated.
@@ -2184,6 +2607,24 @@ func NewIPv6AddressFromPrefixedZonedInt(val *big.Int, prefixLength PrefixLen, zo
 	return
 }
 
+// NewIPv6NetworkMask returns the IPv6 network mask for the given prefix length, such as "ffff:ffff:ffff:ffff::" for 64.
+// The mask has no prefix length.  It returns an error if the prefix length is negative or exceeds 128.
+func NewIPv6NetworkMask(prefixLen BitCount) (*IPv6Address, address_error.AddressValueError) {
+	if prefixLen < 0 || prefixLen > IPv6BitCount {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.prefixSize"}, val: prefixLen}
+	}
+	return IPv6Network.GetNetworkMask(prefixLen), nil
+}
+
+// NewIPv6HostMask returns the IPv6 host mask for the given prefix length, such as "::ffff:ffff:ffff:ffff" for 64.
+// The mask has no prefix length.  It returns an error if the prefix length is negative or exceeds 128.
+func NewIPv6HostMask(prefixLen BitCount) (*IPv6Address, address_error.AddressValueError) {
+	if prefixLen < 0 || prefixLen > IPv6BitCount {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.prefixSize"}, val: prefixLen}
+	}
+	return IPv6Network.GetHostMask(prefixLen), nil
+}
+
 // NewIPv6AddressFromUint64 constructs an IPv6 address from the given values.
 func NewIPv6AddressFromUint64(highBytes, lowBytes uint64) *IPv6Address {
 	section := NewIPv6SectionFromUint64(highBytes, lowBytes, IPv6SegmentCount)
@@ -2318,6 +2759,27 @@ func newIPv6AddressFromMAC(prefixSection *IPv6AddressSection, suffix *MACAddress
 	return newIPv6AddressZoned(res, zone), nil
 }
 
+// NewIPv6FromNatPt constructs the NAT-PT IPv6 address as in RFC 2766,
+// the given IPv4 address embedded in the lowest 4 bytes following the prefix ::ffff:0:0:0/96.
+// Any prefix length of the IPv4 address is dropped.
+// If the IPv4 address is a subnet with segment ranges which cannot be converted to two IPv6 segment ranges, then an error is returned.
+// If the IPv4 address is nil, nil is returned.
+func NewIPv6FromNatPt(ipv4 *IPv4Address) (*IPv6Address, address_error.IncompatibleAddressError) {
+	if ipv4 == nil {
+		return nil, nil
+	}
+
+	zero := zeroIPv6Seg.ToDiv()
+	segs := createSegmentArray(IPv6SegmentCount)
+	segs[0], segs[1], segs[2], segs[3], segs[5] = zero, zero, zero, zero, zero
+	segs[4] = NewIPv6Segment(IPv6MaxValuePerSegment).ToDiv()
+	sect, err := createMixedSection(segs, ipv4.WithoutPrefixLen())
+	if err != nil {
+		return nil, err
+	}
+	return newIPv6Address(sect), nil
+}
+
 // NewIPv6AddressFromMACSection constructs an IPv6 address from a modified EUI-64 (Extended Unique Identifier)
 // MAC address section and an IPv6 address section network prefix.
 //
@@ -2355,3 +2817,37 @@ func newIPv6AddressFromZonedMAC(prefix *IPv6AddressSection, suffix *MACAddressSe
 	}
 	return newIPv6AddressFromMAC(prefix, suffix, zone)
 }
+
+// OnionV3FromIPv6 encodes the bytes of the given address in the lower-case base 32 alphabet of RFC 4648 without padding,
+// followed by the ".onion" suffix, producing a string in the style of a Tor onion service name,
+// such as "eaaq3oaaaaaaaaaaaaaaaaaaae.onion" for "2001:db8::1".
+// For a subnet the lowest address in the subnet is used, and any prefix length or zone is not included.
+//
+// Note that real Tor onion service names are derived from cryptographic keys, not from IP addresses.
+// This encoding is intended for tools that need to carry IP addresses within onion-style naming.
+// Use IPv6AddressFromOnionV3Style to decode.
+func OnionV3FromIPv6(addr *IPv6Address) string {
+	if addr == nil {
+		return nilString()
+	}
+	return strings.ToLower(onionEncoding.EncodeToString(addr.Bytes())) + IPv6OnionSuffix
+}
+
+// IPv6AddressFromOnionV3Style decodes a string produced by OnionV3FromIPv6 back to the IPv6 address.
+// The ".onion" suffix is optional, and the base 32 characters are case-insensitive.
+//
+// An error is returned if the string is not valid base 32 or does not decode to 16 bytes.
+func IPv6AddressFromOnionV3Style(str string) (*IPv6Address, address_error.AddressStringError) {
+	encoded := str
+	if len(encoded) >= len(IPv6OnionSuffix) && strings.EqualFold(encoded[len(encoded)-len(IPv6OnionSuffix):], IPv6OnionSuffix) {
+		encoded = encoded[:len(encoded)-len(IPv6OnionSuffix)]
+	}
+
+	bytes, err := onionEncoding.DecodeString(strings.ToUpper(encoded))
+	if err != nil || len(bytes) != IPv6ByteCount {
+		return nil, &addressStringError{addressError{str: str, key: "ipaddress.error.onion"}}
+	}
+
+	addr, _ := NewIPv6AddressFromBytes(bytes)
+	return addr, nil
+}
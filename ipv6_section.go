@@ -28,6 +28,7 @@ var (
 	mixedParams         = new(address_string.IPv6StringOptionsBuilder).SetMixed(true).SetCompressOptions(compressMixed).ToOptions()
 	ipv6FullParams      = new(address_string.IPv6StringOptionsBuilder).SetExpandedSegments(true).SetWildcardOptions(wildcardsRangeOnlyNetworkOnly).ToOptions()
 	ipv6CanonicalParams = new(address_string.IPv6StringOptionsBuilder).SetCompressOptions(compressAllNoSingles).ToOptions()
+	ipv6RFC5952Params   = new(address_string.IPv6StringOptionsBuilder).SetCompressOptions(compressZerosNoSingles).ToOptions()
 	uncParams           = new(address_string.IPv6StringOptionsBuilder).SetSeparator(IPv6UncSegmentSeparator).SetZoneSeparator(IPv6UncZoneSeparatorStr).
 				SetAddressSuffix(IPv6UncSuffix).SetWildcardOptions(uncWildcards).ToOptions()
 	ipv6CompressedParams         = new(address_string.IPv6StringOptionsBuilder).SetCompressOptions(compressAll).ToOptions()
@@ -885,6 +886,24 @@ func (section *IPv6AddressSection) GetIPv4AddressSection(startByteIndex, endByte
 	return res, nil
 }
 
+// ToEmbeddedIPv4Section produces the IPv4 address section corresponding to the last two segments (4 bytes) of this section,
+// the bits in which mixed IPv6 addresses such as "::ffff:1.2.3.4" embed an IPv4 address.
+// Use GetIPv4AddressSection for other byte indices or for sections that are not full-length.
+//
+// An error is returned if this section does not have exactly the segment count of an IPv6 address,
+// or if the last two segments are a range that cannot be represented as a range of IPv4 segments.
+func (section *IPv6AddressSection) ToEmbeddedIPv4Section() (*IPv4AddressSection, address_error.AddressError) {
+	if segCount := section.GetSegmentCount(); segCount != IPv6SegmentCount {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipv6.invalid.segment.count"}, val: segCount}
+	}
+
+	res, err := section.GetIPv4AddressSection(IPv6MixedOriginalSegmentCount<<1, IPv6ByteCount)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetNetworkMask returns the network mask associated with the CIDR network prefix length of this address section.
 // If this section has no prefix length, then the all-ones mask is returned.
 func (section *IPv6AddressSection) GetNetworkMask() *IPv6AddressSection {
@@ -1907,6 +1926,65 @@ func NewIPv6Section(segments []*IPv6AddressSegment) *IPv6AddressSection {
 	return createIPv6SectionFromSegs(segments, nil)
 }
 
+// ConcatenateIPv6Sections constructs a section from the segments of the high section followed by the segments of the low section,
+// such as when joining a network prefix from one address with an interface identifier from another.
+// A nil section is treated as empty.
+// The result has the prefix length of the high section if it has one,
+// otherwise the prefix length of the low section shifted by the bit count of the high section.
+//
+// An error is returned if the combined segment count exceeds the segment count of an IPv6 address.
+func ConcatenateIPv6Sections(high *IPv6AddressSection, low *IPv6AddressSection) (*IPv6AddressSection, address_error.AddressValueError) {
+	if high == nil {
+		high = zeroIPv6AddressSection
+	}
+	if low == nil {
+		low = zeroIPv6AddressSection
+	}
+
+	if segCount := high.GetSegmentCount() + low.GetSegmentCount(); segCount > IPv6SegmentCount {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.exceeds.size"}, val: segCount}
+	}
+	return high.Append(low), nil
+}
+
+// ConcatenateIPv4AndIPv6Sections constructs a section from the given IPv6 section by writing the bits of the given IPv4 section into it,
+// starting at the bit with index ipv4BitsFromMSB counting from the most significant bit.
+// The other bits and the prefix length of the IPv6 section are retained.
+// When the IPv6 section is a subnet, such as the prefix block "64:ff9b::/96", the bits of its lowest section are used.
+//
+// This allows for the construction of addresses with embedded IPv4 bits at any bit position,
+// such as IPv4-embedded IPv6 addresses with a prefix length that is not a multiple of the segment size.
+//
+// An error is returned if either section is nil, if the IPv4 section is not single-valued,
+// or if the IPv4 bits do not fit within the IPv6 section when starting at the given bit index.
+func ConcatenateIPv4AndIPv6Sections(ipv4 *IPv4AddressSection, ipv6 *IPv6AddressSection, ipv4BitsFromMSB int) (*IPv6AddressSection, address_error.AddressError) {
+	if ipv4 == nil || ipv6 == nil || ipv4.IsMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	}
+
+	ipv4BitCount := int(ipv4.GetBitCount())
+	if ipv4BitsFromMSB < 0 || ipv4BitsFromMSB+ipv4BitCount > int(ipv6.GetBitCount()) {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.exceeds.size"}, val: ipv4BitsFromMSB}
+	}
+
+	ipv4Bytes, bytes := ipv4.Bytes(), ipv6.Bytes() // Bytes provides the lowest value
+	for i := 0; i < ipv4BitCount; i++ {
+		bitIndex := ipv4BitsFromMSB + i
+		mask := byte(0x80) >> (bitIndex & 7)
+		if ipv4Bytes[i>>3]&(byte(0x80)>>(i&7)) != 0 {
+			bytes[bitIndex>>3] |= mask
+		} else {
+			bytes[bitIndex>>3] &^= mask
+		}
+	}
+
+	res, err := newIPv6SectionFromBytes(bytes, ipv6.GetSegmentCount(), ipv6.getPrefixLen(), false)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func newIPv6SectionFromBytes(bytes []byte, segmentCount int, prefixLength PrefixLen, singleOnly bool) (res *IPv6AddressSection, err address_error.AddressValueError) {
 	if segmentCount < 0 {
 		segmentCount = (len(bytes) + 1) >> 1
@@ -0,0 +1,117 @@
+package goip
+
+import "context"
+
+// contextIterator wraps an iterator, ending the iteration when the context is cancelled.
+type contextIterator[T any] struct {
+	ctx context.Context
+	Iterator[T]
+}
+
+func (iter *contextIterator[T]) HasNext() bool {
+	return iter.ctx.Err() == nil && iter.Iterator.HasNext()
+}
+
+func (iter *contextIterator[T]) Next() (res T) {
+	if iter.HasNext() {
+		res = iter.Iterator.Next()
+	}
+	return
+}
+
+// NewContextIterator wraps the given iterator so that the iteration ends when the given context is cancelled or its deadline passes.
+// Once the context is done, HasNext returns false and Next returns the zero value for T, which is nil for the address types.
+func NewContextIterator[T any](ctx context.Context, iterator Iterator[T]) Iterator[T] {
+	return &contextIterator[T]{ctx: ctx, Iterator: iterator}
+}
+
+// IteratorWithContext is the same as Iterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPAddress) IteratorWithContext(ctx context.Context) Iterator[*IPAddress] {
+	return NewContextIterator(ctx, addr.Iterator())
+}
+
+// PrefixBlockIteratorWithContext is the same as PrefixBlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPAddress) PrefixBlockIteratorWithContext(ctx context.Context) Iterator[*IPAddress] {
+	return NewContextIterator(ctx, addr.PrefixBlockIterator())
+}
+
+// SequentialBlockIteratorWithContext is the same as SequentialBlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPAddress) SequentialBlockIteratorWithContext(ctx context.Context) Iterator[*IPAddress] {
+	return NewContextIterator(ctx, addr.SequentialBlockIterator())
+}
+
+// BlockIteratorWithContext is the same as BlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPAddress) BlockIteratorWithContext(ctx context.Context, segmentCount int) Iterator[*IPAddress] {
+	return NewContextIterator(ctx, addr.BlockIterator(segmentCount))
+}
+
+// IteratorWithContext is the same as Iterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPv4Address) IteratorWithContext(ctx context.Context) Iterator[*IPv4Address] {
+	return NewContextIterator(ctx, addr.Iterator())
+}
+
+// PrefixBlockIteratorWithContext is the same as PrefixBlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPv4Address) PrefixBlockIteratorWithContext(ctx context.Context) Iterator[*IPv4Address] {
+	return NewContextIterator(ctx, addr.PrefixBlockIterator())
+}
+
+// SequentialBlockIteratorWithContext is the same as SequentialBlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPv4Address) SequentialBlockIteratorWithContext(ctx context.Context) Iterator[*IPv4Address] {
+	return NewContextIterator(ctx, addr.SequentialBlockIterator())
+}
+
+// BlockIteratorWithContext is the same as BlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPv4Address) BlockIteratorWithContext(ctx context.Context, segmentCount int) Iterator[*IPv4Address] {
+	return NewContextIterator(ctx, addr.BlockIterator(segmentCount))
+}
+
+// IteratorWithContext is the same as Iterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPv6Address) IteratorWithContext(ctx context.Context) Iterator[*IPv6Address] {
+	return NewContextIterator(ctx, addr.Iterator())
+}
+
+// PrefixBlockIteratorWithContext is the same as PrefixBlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPv6Address) PrefixBlockIteratorWithContext(ctx context.Context) Iterator[*IPv6Address] {
+	return NewContextIterator(ctx, addr.PrefixBlockIterator())
+}
+
+// SequentialBlockIteratorWithContext is the same as SequentialBlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPv6Address) SequentialBlockIteratorWithContext(ctx context.Context) Iterator[*IPv6Address] {
+	return NewContextIterator(ctx, addr.SequentialBlockIterator())
+}
+
+// BlockIteratorWithContext is the same as BlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (addr *IPv6Address) BlockIteratorWithContext(ctx context.Context, segmentCount int) Iterator[*IPv6Address] {
+	return NewContextIterator(ctx, addr.BlockIterator(segmentCount))
+}
+
+// IteratorWithContext is the same as Iterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+// This allows the iteration of an enormous IPv6 range to be abandoned.
+func (rng *SequentialRange[T]) IteratorWithContext(ctx context.Context) Iterator[T] {
+	return NewContextIterator(ctx, rng.Iterator())
+}
+
+// PrefixBlockIteratorWithContext is the same as PrefixBlockIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (rng *SequentialRange[T]) PrefixBlockIteratorWithContext(ctx context.Context, prefLength BitCount) Iterator[T] {
+	return NewContextIterator(ctx, rng.PrefixBlockIterator(prefLength))
+}
+
+// PrefixIteratorWithContext is the same as PrefixIterator, except that the iteration ends when the given context is cancelled,
+// after which HasNext returns false and Next returns nil.
+func (rng *SequentialRange[T]) PrefixIteratorWithContext(ctx context.Context, prefLength BitCount) Iterator[*SequentialRange[T]] {
+	return NewContextIterator(ctx, rng.PrefixIterator(prefLength))
+}
@@ -33,8 +33,9 @@ const (
 
 var (
 	zeroMAC             = createMACZero(false)
+	zeroMACExtended     = createMACZero(true)
 	macAll              = zeroMAC.SetPrefixLen(0).ToPrefixBlock()
-	macAllExtended      = createMACZero(true).SetPrefixLen(0).ToPrefixBlock()
+	macAllExtended      = zeroMACExtended.SetPrefixLen(0).ToPrefixBlock()
 	IPv6LinkLocalPrefix = createLinkLocalPrefix()
 )
 
@@ -341,6 +342,27 @@ func (addr *MACAddress) IsLocal() bool {
 	return addr.GetSegment(0).MatchesWithMask(2, 0x2)
 }
 
+// IsLocallyAdministered returns whether this is a locally administered address,
+// one with the U/L bit, the second least significant bit of the first octet, set to 1 as in IEEE 802.
+// It is equivalent to IsLocal.
+func (addr *MACAddress) IsLocallyAdministered() bool {
+	return addr.IsLocal()
+}
+
+// IsUniversallyAdministered returns whether this is a universally administered address,
+// one with the U/L bit, the second least significant bit of the first octet, set to 0 as in IEEE 802.
+// It is equivalent to IsUniversal.
+func (addr *MACAddress) IsUniversallyAdministered() bool {
+	return addr.IsUniversal()
+}
+
+// IsGloballyUnique returns whether this is a globally unique address,
+// one with the U/L bit, the second least significant bit of the first octet, set to 0 as in IEEE 802.
+// It is equivalent to IsUniversal.
+func (addr *MACAddress) IsGloballyUnique() bool {
+	return addr.IsUniversal()
+}
+
 // ToOUIPrefixBlock returns a section in which the range of values match the full block for the OUI (organizationally unique identifier) bytes
 func (addr *MACAddress) ToOUIPrefixBlock() *MACAddress {
 	addr = addr.init()
@@ -606,6 +628,39 @@ func (addr *MACAddress) GetODISection() *MACAddressSection {
 	return addr.GetTrailingSection(MACOrganizationalUniqueIdentifierSegmentCount)
 }
 
+// GetOUIString returns the organizational unique identifier, the first 3 segments, as a colon-delimited string,
+// such as "aa:bb:cc" for both the MAC-48 address "aa:bb:cc:dd:ee:ff" and the EUI-64 address "aa:bb:cc:dd:ee:ff:11:22".
+func (addr *MACAddress) GetOUIString() string {
+	return addr.GetOUISection().ToColonDelimitedString()
+}
+
+// GetOUI returns the organizational unique identifier, the first 3 segments, as an address of the same size
+// in which the remaining segments are zero, such as "aa:bb:cc:00:00:00" for the MAC-48 address "aa:bb:cc:dd:ee:ff"
+// and "aa:bb:cc:00:00:00:00:00" for the EUI-64 address "aa:bb:cc:dd:ee:ff:11:22".
+// A MAC address has either 6 or 8 segments, so the OUI alone is available as a section from GetOUISection.
+// Any prefix length is dropped.
+func (addr *MACAddress) GetOUI() *MACAddress {
+	addr = addr.init().WithoutPrefixLen()
+	return addr.ReplaceLen(MACOrganizationalUniqueIdentifierSegmentCount, addr.GetSegmentCount(), addr.getZero(), MACOrganizationalUniqueIdentifierSegmentCount)
+}
+
+// GetDeviceIdentifier returns the segments following the organizational unique identifier, the organizational distinct identifier,
+// as an address of the same size in which the first 3 segments are zero,
+// such as "00:00:00:dd:ee:ff" for the MAC-48 address "aa:bb:cc:dd:ee:ff".
+// The device identifier alone is available as a section from GetODISection.
+// Any prefix length is dropped.
+func (addr *MACAddress) GetDeviceIdentifier() *MACAddress {
+	addr = addr.init().WithoutPrefixLen()
+	return addr.ReplaceLen(0, MACOrganizationalUniqueIdentifierSegmentCount, addr.getZero(), 0)
+}
+
+func (addr *MACAddress) getZero() *MACAddress {
+	if addr.GetSegmentCount() == ExtendedUniqueIdentifier64SegmentCount {
+		return zeroMACExtended
+	}
+	return zeroMAC
+}
+
 // CopySubSegments copies the existing segments from the given start index until but not including the segment at the given end index,
 // into the given slice, as much as can be fit into the slice, returning the number of segments copied.
 func (addr *MACAddress) CopySubSegments(start, end int, segs []*MACAddressSegment) (count int) {
@@ -1052,6 +1107,18 @@ func (addr *MACAddress) ToColonDelimitedString() string {
 	return addr.init().GetSection().ToColonDelimitedString()
 }
 
+// ToEUI48String produces the EUI-48 string using the given separator,
+// one of ':' for "aa:bb:cc:dd:ee:ff", '-' for "aa-bb-cc-dd-ee-ff", '.' for the dotted format "aabb.ccdd.eeff" or ' ' for "aa bb cc dd ee ff".
+//
+// An error is returned if this is not a 6-byte MAC address, if the separator is not one of those listed,
+// or if the dotted format is requested and the segment ranges cannot be joined into dotted segments.
+func (addr *MACAddress) ToEUI48String(separator rune) (string, address_error.AddressError) {
+	if addr == nil {
+		return nilString(), nil
+	}
+	return addr.init().GetSection().ToEUI48String(separator)
+}
+
 // ToCustomString creates a customized string
 // from this address or address collection according to the given string option parameters.
 func (addr *MACAddress) ToCustomString(stringOptions address_string.StringOptions) string {
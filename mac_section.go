@@ -768,6 +768,35 @@ func (section *MACAddressSection) ToColonDelimitedString() string {
 	return section.ToNormalizedString()
 }
 
+// ToEUI48String produces the EUI-48 string using the given separator,
+// one of ':' for "aa:bb:cc:dd:ee:ff", '-' for "aa-bb-cc-dd-ee-ff", '.' for the dotted format "aabb.ccdd.eeff" or ' ' for "aa bb cc dd ee ff".
+//
+// An error is returned if this section does not have exactly 6 segments, if the separator is not one of those listed,
+// or if the dotted format is requested and the segment ranges cannot be joined into dotted segments.
+func (section *MACAddressSection) ToEUI48String(separator rune) (string, address_error.AddressError) {
+	if section == nil {
+		return nilString(), nil
+	} else if section.GetSegmentCount() != ExtendedUniqueIdentifier48SegmentCount {
+		return "", &addressValueError{addressError: addressError{key: "ipaddress.error.mac.invalid.segment.count"}, val: section.GetSegmentCount()}
+	}
+
+	switch separator {
+	case MACColonSegmentSeparator:
+		return section.ToColonDelimitedString(), nil
+	case MACDashSegmentSeparator:
+		return section.ToDashedString(), nil
+	case MacSpaceSegmentSeparator:
+		return section.ToSpaceDelimitedString(), nil
+	case MacDottedSegmentSeparator:
+		str, err := section.ToDottedString()
+		if err != nil {
+			return "", err
+		}
+		return str, nil
+	}
+	return "", &addressValueError{addressError: addressError{key: "ipaddress.mac.error.invalid.separator"}, val: int(separator)}
+}
+
 // ToNormalizedWildcardString produces the normalized string.
 func (section *MACAddressSection) ToNormalizedWildcardString() string {
 	return section.ToNormalizedString()
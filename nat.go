@@ -0,0 +1,48 @@
+package goip
+
+import "github.com/pchchv/goip/address_error"
+
+// NATRule is a network address translation rule mapping the addresses of one subnet to those of another subnet of the same size.
+type NATRule struct {
+	// OriginalSubnet is the subnet of the addresses to be translated.
+	OriginalSubnet *IPAddress
+	// TranslatedSubnet is the subnet of the translated addresses.
+	TranslatedSubnet *IPAddress
+}
+
+// Translate maps the given address within the original subnet of this rule to the address at the same offset within the translated subnet.
+// For instance, with original subnet "10.0.0.0/24" and translated subnet "192.168.1.0/24", the address "10.0.0.5" is translated to "192.168.1.5".
+//
+// The subnets may be any sequential ranges of addresses with the same count, not only prefix blocks,
+// and they may be different IP versions, as with NAT64 translation between an IPv4 subnet and an IPv6 subnet.
+// The translated address has no prefix length.
+//
+// An error is returned if either subnet is nil or has no IP version, if the subnets are not sequential or differ in size,
+// if the given address is not an individual address, or if it is not within the original subnet.
+func (rule *NATRule) Translate(addr *IPAddress) (*IPAddress, address_error.AddressError) {
+	original, translated := rule.OriginalSubnet, rule.TranslatedSubnet
+	if original == nil || translated == nil || original.getIPVersion().IsIndeterminate() || translated.getIPVersion().IsIndeterminate() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipVersionIndeterminate"}}
+	} else if !original.IsSequential() || !translated.IsSequential() || original.GetCount().Cmp(translated.GetCount()) != 0 {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.nat.size.mismatch"}}
+	} else if addr == nil {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.ipVersionIndeterminate"}}
+	} else if addr.IsMultiple() {
+		return nil, &incompatibleAddressError{addressError{key: "ipaddress.error.not.single.address"}}
+	} else if !original.Contains(addr) {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.nat.not.in.subnet"}}
+	}
+
+	// the offset of the address within the original subnet, added to the lowest translated address
+	val := addr.GetValue()
+	val.Sub(val, original.GetValue()).Add(val, translated.GetValue())
+	if translated.IsIPv4() {
+		return NewIPv4AddressFromUint32(uint32(val.Uint64())).ToIP(), nil
+	}
+
+	res, err := NewIPv6AddressFromInt(val)
+	if err != nil {
+		return nil, err
+	}
+	return res.ToIP(), nil
+}
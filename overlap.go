@@ -0,0 +1,125 @@
+package goip
+
+import "sort"
+
+// segmentsOverlap returns whether the two addresses or subnets of the same version have at least one address in common,
+// which is the case when the ranges of each pair of corresponding segments overlap.
+func segmentsOverlap(one, two *Address) bool {
+	segCount := one.GetSegmentCount()
+	if segCount != two.GetSegmentCount() {
+		return false
+	}
+
+	for i := 0; i < segCount; i++ {
+		seg, otherSeg := one.GetSegment(i), two.GetSegment(i)
+		if seg.GetUpperSegmentValue() < otherSeg.GetSegmentValue() || otherSeg.GetUpperSegmentValue() < seg.GetSegmentValue() {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps returns true if this subnet and the given subnet have at least one address in common.
+// Addresses of different versions do not overlap.
+//
+// This is equivalent to checking that Intersect returns a non-nil result, without constructing the intersection.
+// Prefix lengths and zones are ignored, and a nil subnet overlaps nothing.
+func (addr *IPAddress) Overlaps(other *IPAddress) bool {
+	if addr == nil || other == nil {
+		return false
+	}
+	addr = addr.init()
+	other = other.init()
+	if !addr.isIP() || !versionsMatch(addr, other) {
+		return false
+	}
+	return segmentsOverlap(addr.ToAddressBase(), other.ToAddressBase())
+}
+
+// OverlapsAny returns true if this subnet has at least one address in common with any of the given subnets.
+// See Overlaps for details.
+func (addr *IPAddress) OverlapsAny(addrs []*IPAddress) bool {
+	for _, other := range addrs {
+		if addr.Overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps returns true if this subnet and the given subnet have at least one address in common.
+// See IPAddress.Overlaps for details.
+func (addr *IPv4Address) Overlaps(other *IPv4Address) bool {
+	if addr == nil || other == nil {
+		return false
+	}
+	return segmentsOverlap(addr.init().ToAddressBase(), other.init().ToAddressBase())
+}
+
+// Overlaps returns true if this subnet and the given subnet have at least one address in common.
+// See IPAddress.Overlaps for details.
+func (addr *IPv6Address) Overlaps(other *IPv6Address) bool {
+	if addr == nil || other == nil {
+		return false
+	}
+	return segmentsOverlap(addr.init().ToAddressBase(), other.init().ToAddressBase())
+}
+
+// FindOverlaps returns the index pairs of all the subnets in the given slice that overlap each other.
+// Each pair [i, j] has i < j, and the pairs are sorted by i and then j.
+// Nil elements and elements that are neither IPv4 nor IPv6 are ignored.
+//
+// Rather than comparing all the pairs of subnets, the subnets are sorted by their lowest address,
+// and each subnet is compared only with those preceding subnets whose highest address is not below its lowest address.
+// For subnets that are sequential, such as prefix blocks, all of those comparisons produce overlapping pairs.
+func FindOverlaps(addrs []*IPAddress) [][2]int {
+	type interval struct {
+		index        int
+		addr         *IPAddress
+		lower, upper *IPAddress
+	}
+
+	intervals := make([]interval, 0, len(addrs))
+	for i, addr := range addrs {
+		if addr != nil && addr.isIP() {
+			intervals = append(intervals, interval{index: i, addr: addr, lower: addr.GetLower(), upper: addr.GetUpper()})
+		}
+	}
+
+	// IPv4 sorts ahead of IPv6, so that subnets of the same version are adjacent
+	sort.Slice(intervals, func(i, j int) bool {
+		one, two := intervals[i], intervals[j]
+		if oneIsIPv4, twoIsIPv4 := one.addr.IsIPv4(), two.addr.IsIPv4(); oneIsIPv4 != twoIsIPv4 {
+			return oneIsIPv4
+		}
+		return compareLowIPAddressValues(one.lower, two.lower) < 0
+	})
+
+	var pairs [][2]int
+	var active []interval
+	for _, current := range intervals {
+		// retain the preceding subnets that reach the lowest address of the current subnet
+		retained := active[:0]
+		for _, previous := range active {
+			if versionsMatch(previous.addr, current.addr) && compareLowIPAddressValues(previous.upper, current.lower) >= 0 {
+				retained = append(retained, previous)
+				if segmentsOverlap(previous.addr.ToAddressBase(), current.addr.ToAddressBase()) {
+					if previous.index < current.index {
+						pairs = append(pairs, [2]int{previous.index, current.index})
+					} else {
+						pairs = append(pairs, [2]int{current.index, previous.index})
+					}
+				}
+			}
+		}
+		active = append(retained, current)
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	return pairs
+}
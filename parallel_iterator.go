@@ -0,0 +1,150 @@
+package goip
+
+import (
+	"context"
+	"math/big"
+	"sync"
+)
+
+// ParallelIterator iterates through the addresses of a subnet using multiple goroutines,
+// each goroutine iterating through its own contiguous shard of the subnet.
+type ParallelIterator[T any] interface {
+	// Iterate calls fn for each address of the subnet, fanning out across a pool of goroutines,
+	// and returns when all the goroutines are done.
+	// Each address is visited exactly once, and the addresses of each shard are visited in order,
+	// but the calls for different shards happen concurrently, so fn must be safe for concurrent use.
+	// When fn returns true, the iteration of the shard of that call stops.
+	// When ctx is cancelled, the iteration of all shards stops.
+	//
+	// Iterate may be called concurrently, each call iterating independently.
+	Iterate(ctx context.Context, fn func(T) bool)
+}
+
+// parallelIterator divides the addresses of a subnet into shards by their indices in the iteration order of the subnet.
+// The subnet is made up of sequential blocks of equal size, and each shard computes the blocks it iterates as it reaches them,
+// so that no shard holds more than one block at a time.
+type parallelIterator[T any] struct {
+	addr       *IPAddress
+	total      *big.Int // the count of addresses
+	shardCount int64
+	blockIndex int      // the index of the first segment that varies within the sequential blocks
+	blockSize  *big.Int // the count of addresses in each sequential block
+	convert    func(*IPAddress) T
+}
+
+func (iter *parallelIterator[T]) Iterate(ctx context.Context, fn func(T) bool) {
+	var wg sync.WaitGroup
+	shardCount := big.NewInt(iter.shardCount)
+	for i := int64(0); i < iter.shardCount; i++ {
+		// shard i iterates the indices from i * total / shardCount up to (i + 1) * total / shardCount
+		start := new(big.Int).Mul(iter.total, big.NewInt(i))
+		start.Quo(start, shardCount)
+		end := new(big.Int).Mul(iter.total, big.NewInt(i+1))
+		end.Quo(end, shardCount)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			iter.iterateShard(ctx, start, end, fn)
+		}()
+	}
+	wg.Wait()
+}
+
+func (iter *parallelIterator[T]) iterateShard(ctx context.Context, start, end *big.Int, fn func(T) bool) {
+	var blockIndex, offset big.Int
+	for index := start; index.Cmp(end) < 0; {
+		// iterate from the address at the current index up to the end of its block or shard, whichever comes first
+		blockIndex.QuoRem(index, iter.blockSize, &offset)
+		lower := iter.getBlockLowerValue(&blockIndex)
+		lower.Add(lower, &offset)
+		count := new(big.Int).Sub(iter.blockSize, &offset)
+		if remaining := new(big.Int).Sub(end, index); remaining.Cmp(count) < 0 {
+			count = remaining
+		}
+		upper := new(big.Int).Add(lower, count)
+		upper.Sub(upper, bigOneConst())
+
+		rng := NewSequentialRange(ipAddressFromValue(iter.addr, lower), ipAddressFromValue(iter.addr, upper))
+		for addrs := rng.Iterator(); addrs.HasNext(); {
+			if ctx.Err() != nil || fn(iter.convert(addrs.Next())) {
+				return
+			}
+		}
+		index = index.Add(index, count)
+	}
+}
+
+// getBlockLowerValue returns the value of the lowest address of the sequential block with the given index,
+// the segments preceding the block index acting as the digits of the block index in a mixed radix.
+func (iter *parallelIterator[T]) getBlockLowerValue(blockIndex *big.Int) *big.Int {
+	addr := iter.addr
+	val := addr.GetValue()
+	bitsPerSegment := uint(addr.GetBitsPerSegment())
+	segmentCount := addr.GetSegmentCount()
+	var remaining, digit, segmentValueCount big.Int
+	remaining.Set(blockIndex)
+	for i := iter.blockIndex - 1; i >= 0 && remaining.Sign() > 0; i-- {
+		segmentValueCount.SetInt64(int64(addr.GetSegment(i).GetValueCount()))
+		remaining.QuoRem(&remaining, &segmentValueCount, &digit)
+		val.Add(val, digit.Lsh(&digit, bitsPerSegment*uint(segmentCount-1-i)))
+	}
+	return val
+}
+
+// newParallelIterator divides the given subnet into shards of equal size, give or take one address,
+// with each shard a contiguous portion of the addresses of the subnet in iteration order.
+func newParallelIterator[T any](addr *IPAddress, parallelism int, convert func(*IPAddress) T) ParallelIterator[T] {
+	iter := &parallelIterator[T]{convert: convert}
+	if addr == nil || addr.getIPVersion().IsIndeterminate() {
+		return iter
+	}
+
+	addr = addr.WithoutPrefixLen()
+	iter.addr = addr
+	iter.total = addr.GetCount()
+	iter.blockIndex = addr.GetSequentialBlockIndex()
+	iter.blockSize = new(big.Int).Quo(iter.total, addr.GetSequentialBlockCount())
+	iter.shardCount = 1
+	if parallelism > 1 {
+		iter.shardCount = int64(parallelism)
+		if iter.total.IsInt64() && iter.total.Int64() < iter.shardCount {
+			iter.shardCount = iter.total.Int64()
+		}
+	}
+	return iter
+}
+
+// ipAddressFromValue returns the address with the given value and the same version as the given address.
+func ipAddressFromValue(addr *IPAddress, val *big.Int) *IPAddress {
+	if addr.IsIPv4() {
+		return NewIPv4AddressFromUint32(uint32(val.Uint64())).ToIP()
+	}
+	res, _ := NewIPv6AddressFromInt(val) // the value is within the range of an existing IPv6 subnet
+	return res.ToIP()
+}
+
+// ParallelIterator returns a ParallelIterator that iterates through the individual addresses of this address or subnet using
+// the given number of goroutines, each goroutine iterating through a contiguous portion of the subnet.
+// The portions are of equal size, give or take one address, and there are fewer goroutines when the subnet has fewer addresses.
+// A parallelism less than one is treated as one.
+//
+// The iterated addresses are those of the sequential ranges of the subnet, so they have no prefix length or zone.
+// For a subnet that is not sequential, such as "1.*.3.4", each goroutine computes the sequential blocks of its portion as it reaches them,
+// so creating the iterator takes the same time regardless of the size of the subnet.
+func (addr *IPAddress) ParallelIterator(parallelism int) ParallelIterator[*IPAddress] {
+	return newParallelIterator(addr.init(), parallelism, func(a *IPAddress) *IPAddress { return a })
+}
+
+// ParallelIterator returns a ParallelIterator that iterates through the individual addresses of this address or subnet using
+// the given number of goroutines, each goroutine iterating through a contiguous portion of the subnet.
+// See IPAddress.ParallelIterator for details.
+func (addr *IPv4Address) ParallelIterator(parallelism int) ParallelIterator[*IPv4Address] {
+	return newParallelIterator(addr.init().ToIP(), parallelism, (*IPAddress).ToIPv4)
+}
+
+// ParallelIterator returns a ParallelIterator that iterates through the individual addresses of this address or subnet using
+// the given number of goroutines, each goroutine iterating through a contiguous portion of the subnet.
+// See IPAddress.ParallelIterator for details.
+func (addr *IPv6Address) ParallelIterator(parallelism int) ParallelIterator[*IPv6Address] {
+	return newParallelIterator(addr.init().ToIP(), parallelism, (*IPAddress).ToIPv6)
+}
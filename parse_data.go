@@ -554,6 +554,22 @@ func (parseData *ipAddressParseData) isProvidingMixedIPv6() bool {
 	return parseData.mixedParsedAddress != nil
 }
 
+// hasSegmentFlag returns whether any of the parsed segments,
+// including those of the IPv4 part of a mixed address, has any of the given flags.
+func (parseData *ipAddressParseData) hasSegmentFlag(flagIndicator uint32) bool {
+	addressParseData := parseData.getAddressParseData()
+	for i := 0; i < addressParseData.getSegmentCount(); i++ {
+		if addressParseData.getFlag(i, flagIndicator) {
+			return true
+		}
+	}
+
+	if mixed := parseData.mixedParsedAddress; mixed != nil {
+		return mixed.ipAddressParseData.hasSegmentFlag(flagIndicator)
+	}
+	return false
+}
+
 func (parseData *ipAddressParseData) setMixedParsedAddress(val *parsedIPAddress) {
 	parseData.mixedParsedAddress = val
 }
@@ -95,6 +95,14 @@ func (parseData *parsedIPAddress) isProvidingBase85IPv6() bool {
 	return parseData.ipAddressParseData.isProvidingBase85IPv6()
 }
 
+func (parseData *parsedIPAddress) isProvidingWildcard() bool {
+	return parseData.ipAddressParseData.hasSegmentFlag(keyWildcard | keySingleWildcard | keyRangeWildcard)
+}
+
+func (parseData *parsedIPAddress) isProvidingRange() bool {
+	return parseData.ipAddressParseData.hasSegmentFlag(keyRangeWildcard)
+}
+
 func (parseData *parsedIPAddress) getProviderIPVersion() IPVersion {
 	return parseData.ipAddressParseData.getProviderIPVersion()
 }
@@ -0,0 +1,191 @@
+package goip
+
+import (
+	cryptorand "crypto/rand"
+	"math/big"
+	"math/rand"
+)
+
+// randomBelow returns a uniformly random integer in the range 0 to n - 1, using the given source of randomness,
+// or a cryptographically secure source when r is nil.
+func randomBelow(n *big.Int, r *rand.Rand) *big.Int {
+	if r == nil {
+		result, err := cryptorand.Int(cryptorand.Reader, n)
+		if err != nil {
+			panic(err) // the system source of randomness is unavailable
+		}
+		return result
+	} else if n.IsInt64() {
+		return big.NewInt(r.Int63n(n.Int64()))
+	}
+
+	// rejection sampling, drawing values with the bit length of n - 1 until one is below n
+	bitLen := new(big.Int).Sub(n, bigOneConst()).BitLen()
+	wordCount := (bitLen + 63) / 64
+	result := new(big.Int)
+	for {
+		result.SetInt64(0)
+		for i := 0; i < wordCount; i++ {
+			result.Lsh(result, 64).Or(result, new(big.Int).SetUint64(r.Uint64()))
+		}
+		result.Rsh(result, uint(wordCount*64-bitLen))
+		if result.Cmp(n) < 0 {
+			return result
+		}
+	}
+}
+
+// randomSegmentValues returns the segment values of a uniformly random individual address from the given subnet.
+// The random index of the address within the subnet is split into the index of each segment value,
+// the segments having a mixed radix determined by the number of values of each segment.
+func randomSegmentValues(subnet segmentedAddress, r *rand.Rand) []SegInt {
+	segCount := subnet.GetSegmentCount()
+	count := bigOne()
+	for i := 0; i < segCount; i++ {
+		seg := subnet.GetGenericSegment(i)
+		count.Mul(count, new(big.Int).SetUint64(uint64(seg.GetUpperSegmentValue()-seg.GetSegmentValue())+1))
+	}
+
+	index := randomBelow(count, r)
+	vals := make([]SegInt, segCount)
+	var segIndex big.Int
+	for i := segCount - 1; i >= 0; i-- {
+		seg := subnet.GetGenericSegment(i)
+		radix := new(big.Int).SetUint64(uint64(seg.GetUpperSegmentValue()-seg.GetSegmentValue()) + 1)
+		index.QuoRem(index, radix, &segIndex)
+		vals[i] = seg.GetSegmentValue() + SegInt(segIndex.Uint64())
+	}
+	return vals
+}
+
+func randomIPv4Address(addr *IPv4Address, r *rand.Rand) *IPv4Address {
+	if addr == nil {
+		return nil
+	}
+	addr = addr.init()
+	if !addr.IsMultiple() {
+		return addr
+	}
+
+	vals := randomSegmentValues(addr, r)
+	valProvider := func(segmentIndex int) IPv4SegInt {
+		return IPv4SegInt(vals[segmentIndex])
+	}
+	result := NewIPv4AddressFromRange(valProvider, valProvider)
+	// the prefix length is applied afterwards, so that an individual address with a zero host does not become the prefix block
+	if prefLen := addr.GetPrefixLen(); prefLen != nil {
+		result = result.SetPrefixLen(prefLen.bitCount())
+	}
+	return result
+}
+
+func randomIPv6Address(addr *IPv6Address, r *rand.Rand) *IPv6Address {
+	if addr == nil {
+		return nil
+	}
+	addr = addr.init()
+	if !addr.IsMultiple() {
+		return addr
+	}
+
+	vals := randomSegmentValues(addr, r)
+	valProvider := func(segmentIndex int) IPv6SegInt {
+		return IPv6SegInt(vals[segmentIndex])
+	}
+	result := NewIPv6AddressFromZonedRange(valProvider, valProvider, string(addr.zone))
+	if prefLen := addr.GetPrefixLen(); prefLen != nil {
+		result = result.SetPrefixLen(prefLen.bitCount())
+	}
+	return result
+}
+
+func randomMACAddress(addr *MACAddress, r *rand.Rand) *MACAddress {
+	if addr == nil {
+		return nil
+	}
+	addr = addr.init()
+	if !addr.IsMultiple() {
+		return addr
+	}
+
+	vals := randomSegmentValues(addr, r)
+	valProvider := func(segmentIndex int) MACSegInt {
+		return MACSegInt(vals[segmentIndex])
+	}
+	result := NewMACAddressFromRangeExt(valProvider, valProvider, addr.GetSegmentCount() == ExtendedUniqueIdentifier64SegmentCount)
+	if prefLen := addr.GetPrefixLen(); prefLen != nil {
+		result = result.SetPrefixLen(prefLen.bitCount())
+	}
+	return result
+}
+
+func randomIPAddress(addr *IPAddress, r *rand.Rand) *IPAddress {
+	if addr == nil {
+		return nil
+	}
+	addr = addr.init()
+	if addr.IsIPv4() {
+		return randomIPv4Address(addr.ToIPv4(), r).ToIP()
+	} else if addr.IsIPv6() {
+		return randomIPv6Address(addr.ToIPv6(), r).ToIP()
+	}
+	return addr
+}
+
+// RandomAddress returns a uniformly random individual address from this address or subnet,
+// using a cryptographically secure source of randomness.
+// The returned address has the prefix length and zone of this subnet.
+//
+// Every address of the subnet is equally likely, including for subnets with more addresses than the range of int64, such as IPv6 prefix blocks.
+// If this is an individual address, it is returned.
+//
+// RandomAddress is safe for concurrent use.
+func (addr *IPAddress) RandomAddress() *IPAddress {
+	return randomIPAddress(addr, nil)
+}
+
+// RandomAddressWithRand is the same as RandomAddress, except that the given source of randomness is used,
+// allowing for reproducible results with a seeded source.
+// A rand.Rand is not safe for concurrent use, so neither is this method when the same rand.Rand is used concurrently.
+func (addr *IPAddress) RandomAddressWithRand(r *rand.Rand) *IPAddress {
+	return randomIPAddress(addr, r)
+}
+
+// RandomAddress returns a uniformly random individual address from this address or subnet,
+// using a cryptographically secure source of randomness.
+// See IPAddress.RandomAddress for details.
+func (addr *IPv4Address) RandomAddress() *IPv4Address {
+	return randomIPv4Address(addr, nil)
+}
+
+// RandomAddressWithRand is the same as RandomAddress, except that the given source of randomness is used.
+// See IPAddress.RandomAddressWithRand for details.
+func (addr *IPv4Address) RandomAddressWithRand(r *rand.Rand) *IPv4Address {
+	return randomIPv4Address(addr, r)
+}
+
+// RandomAddress returns a uniformly random individual address from this address or subnet,
+// using a cryptographically secure source of randomness.
+// See IPAddress.RandomAddress for details.
+func (addr *IPv6Address) RandomAddress() *IPv6Address {
+	return randomIPv6Address(addr, nil)
+}
+
+// RandomAddressWithRand is the same as RandomAddress, except that the given source of randomness is used.
+// See IPAddress.RandomAddressWithRand for details.
+func (addr *IPv6Address) RandomAddressWithRand(r *rand.Rand) *IPv6Address {
+	return randomIPv6Address(addr, r)
+}
+
+// RandomAddress returns a uniformly random individual address from this address collection,
+// using a cryptographically secure source of randomness.
+// See IPAddress.RandomAddress for details.
+func (addr *MACAddress) RandomAddress() *MACAddress {
+	return randomMACAddress(addr, nil)
+}
+
+// RandomAddressWithRand is the same as RandomAddress, except that the given source of randomness is used.
+// See IPAddress.RandomAddressWithRand for details.
+func (addr *MACAddress) RandomAddressWithRand(r *rand.Rand) *MACAddress {
+	return randomMACAddress(addr, r)
+}
@@ -0,0 +1,345 @@
+package goip
+
+// mirrorIterator reverses the order of an iterator through the elements of a subnet,
+// by mapping each iterated element to its mirror image within the subnet.
+//
+// Within a segment with values ranging from l to u, the mirror image of the value v is l + u - v.
+// Mapping every segment of an element this way reverses the order of the elements,
+// so the elements of the wrapped iterator are produced in reverse order, without computing the elements in advance.
+type mirrorIterator[T any] struct {
+	Iterator[T]
+	mirror func(T) T
+}
+
+func (iter *mirrorIterator[T]) Next() (res T) {
+	if iter.HasNext() {
+		res = iter.mirror(iter.Iterator.Next())
+	}
+	return
+}
+
+// segmentedAddress is the part of the address types required to mirror segment values.
+type segmentedAddress interface {
+	GetSegmentCount() int
+	GetBitsPerSegment() BitCount
+	GetGenericSegment(index int) AddressSegmentType
+}
+
+// mirrorSegmentValues returns the lower and upper segment values of the mirror image of the element within the given subnet.
+// When the prefix length is not nil, the elements are the prefix blocks of the subnet,
+// in which case only the network bits are mirrored, and the host bits of the element are retained.
+func mirrorSegmentValues(subnet, elem segmentedAddress, prefLen PrefixLen) (lower, upper []SegInt) {
+	segCount := subnet.GetSegmentCount()
+	bitsPerSegment := subnet.GetBitsPerSegment()
+	lower, upper = make([]SegInt, segCount), make([]SegInt, segCount)
+	for i := 0; i < segCount; i++ {
+		subnetSeg, elemSeg := subnet.GetGenericSegment(i), elem.GetGenericSegment(i)
+		segLower, segUpper := subnetSeg.GetSegmentValue(), subnetSeg.GetUpperSegmentValue()
+		elemLower, elemUpper := elemSeg.GetSegmentValue(), elemSeg.GetUpperSegmentValue()
+		networkBits := bitsPerSegment
+		if prefLen != nil {
+			networkBits = prefLen.bitCount() - BitCount(i)*bitsPerSegment
+		}
+
+		if networkBits <= 0 {
+			lower[i], upper[i] = elemLower, elemUpper
+		} else if networkBits >= bitsPerSegment {
+			lower[i], upper[i] = segLower+segUpper-elemUpper, segLower+segUpper-elemLower
+		} else {
+			hostBits := uint(bitsPerSegment - networkBits)
+			networkVal := (segLower >> hostBits) + (segUpper >> hostBits) - (elemLower >> hostBits)
+			lower[i] = networkVal << hostBits
+			upper[i] = lower[i] | (SegInt(1)<<hostBits - 1)
+		}
+	}
+	return
+}
+
+func mirrorIPv4(subnet, elem *IPv4Address, prefLen PrefixLen) *IPv4Address {
+	lower, upper := mirrorSegmentValues(subnet, elem, prefLen)
+	result := NewIPv4AddressFromRange(
+		func(segmentIndex int) IPv4SegInt {
+			return IPv4SegInt(lower[segmentIndex])
+		},
+		func(segmentIndex int) IPv4SegInt {
+			return IPv4SegInt(upper[segmentIndex])
+		})
+	// the prefix length is applied afterwards, so that an individual address with a zero host does not become the prefix block
+	if elemPrefLen := elem.GetPrefixLen(); elemPrefLen != nil {
+		result = result.SetPrefixLen(elemPrefLen.bitCount())
+	}
+	return result
+}
+
+func mirrorIPv6(subnet, elem *IPv6Address, prefLen PrefixLen) *IPv6Address {
+	lower, upper := mirrorSegmentValues(subnet, elem, prefLen)
+	result := NewIPv6AddressFromZonedRange(
+		func(segmentIndex int) IPv6SegInt {
+			return IPv6SegInt(lower[segmentIndex])
+		},
+		func(segmentIndex int) IPv6SegInt {
+			return IPv6SegInt(upper[segmentIndex])
+		},
+		string(elem.zone))
+	if elemPrefLen := elem.GetPrefixLen(); elemPrefLen != nil {
+		result = result.SetPrefixLen(elemPrefLen.bitCount())
+	}
+	return result
+}
+
+func mirrorMAC(subnet, elem *MACAddress, prefLen PrefixLen) *MACAddress {
+	lower, upper := mirrorSegmentValues(subnet, elem, prefLen)
+	result := NewMACAddressFromRangeExt(
+		func(segmentIndex int) MACSegInt {
+			return MACSegInt(lower[segmentIndex])
+		},
+		func(segmentIndex int) MACSegInt {
+			return MACSegInt(upper[segmentIndex])
+		},
+		elem.GetSegmentCount() == ExtendedUniqueIdentifier64SegmentCount)
+	if elemPrefLen := elem.GetPrefixLen(); elemPrefLen != nil {
+		result = result.SetPrefixLen(elemPrefLen.bitCount())
+	}
+	return result
+}
+
+func newIPAddressMirrorIterator(subnet *IPAddress, iter Iterator[*IPAddress], prefLen PrefixLen) Iterator[*IPAddress] {
+	if subnet.IsIPv4() {
+		subnet4 := subnet.ToIPv4()
+		return &mirrorIterator[*IPAddress]{
+			Iterator: iter,
+			mirror: func(elem *IPAddress) *IPAddress {
+				return mirrorIPv4(subnet4, elem.ToIPv4(), prefLen).ToIP()
+			},
+		}
+	} else if subnet.IsIPv6() {
+		subnet6 := subnet.ToIPv6()
+		return &mirrorIterator[*IPAddress]{
+			Iterator: iter,
+			mirror: func(elem *IPAddress) *IPAddress {
+				return mirrorIPv6(subnet6, elem.ToIPv6(), prefLen).ToIP()
+			},
+		}
+	}
+	return iter // the zero IPAddress, which has no segments to reverse
+}
+
+// ReverseIterator provides an iterator to iterate through the individual addresses of this address or subnet,
+// in the reverse order of Iterator, from the highest address to the lowest.
+//
+// The addresses are computed as they are iterated, so reverse iteration of a large subnet requires no more memory than forward iteration.
+func (addr *IPAddress) ReverseIterator() Iterator[*IPAddress] {
+	if addr == nil {
+		return addr.Iterator()
+	}
+	addr = addr.init()
+	return newIPAddressMirrorIterator(addr, addr.Iterator(), nil)
+}
+
+// PrefixBlockReverseIterator provides an iterator to iterate through the individual prefix blocks of this address or subnet,
+// in the reverse order of PrefixBlockIterator, from the highest prefix block to the lowest.
+//
+// If this address has no prefix length, then this is equivalent to ReverseIterator.
+func (addr *IPAddress) PrefixBlockReverseIterator() Iterator[*IPAddress] {
+	if addr == nil {
+		return addr.PrefixBlockIterator()
+	}
+	addr = addr.init()
+	return newIPAddressMirrorIterator(addr, addr.PrefixBlockIterator(), addr.GetPrefixLen())
+}
+
+// SequentialBlockReverseIterator iterates through the sequential subnets or addresses that make up this address or subnet,
+// in the reverse order of SequentialBlockIterator, from the highest block to the lowest.
+//
+// For instance, given the IPv4 subnet "1-2.3-4.5-6.7-8",
+// it will iterate through "2.4.6.7-8", "2.4.5.7-8", "2.3.6.7-8", "2.3.5.7-8", "1.4.6.7-8", "1.4.5.7-8", "1.3.6.7-8" and "1.3.5.7-8".
+func (addr *IPAddress) SequentialBlockReverseIterator() Iterator[*IPAddress] {
+	if addr == nil {
+		return addr.SequentialBlockIterator()
+	}
+	addr = addr.init()
+	return newIPAddressMirrorIterator(addr, addr.SequentialBlockIterator(), nil)
+}
+
+// ReverseIterator provides an iterator to iterate through the individual addresses of this address or subnet,
+// in the reverse order of Iterator, from the highest address to the lowest.
+//
+// The addresses are computed as they are iterated, so reverse iteration of a large subnet requires no more memory than forward iteration.
+func (addr *IPv4Address) ReverseIterator() Iterator[*IPv4Address] {
+	if addr == nil {
+		return addr.Iterator()
+	}
+	addr = addr.init()
+	return &mirrorIterator[*IPv4Address]{
+		Iterator: addr.Iterator(),
+		mirror: func(elem *IPv4Address) *IPv4Address {
+			return mirrorIPv4(addr, elem, nil)
+		},
+	}
+}
+
+// PrefixBlockReverseIterator provides an iterator to iterate through the individual prefix blocks of this address or subnet,
+// in the reverse order of PrefixBlockIterator, from the highest prefix block to the lowest.
+//
+// If this address has no prefix length, then this is equivalent to ReverseIterator.
+func (addr *IPv4Address) PrefixBlockReverseIterator() Iterator[*IPv4Address] {
+	if addr == nil {
+		return addr.PrefixBlockIterator()
+	}
+	addr = addr.init()
+	prefLen := addr.GetPrefixLen()
+	return &mirrorIterator[*IPv4Address]{
+		Iterator: addr.PrefixBlockIterator(),
+		mirror: func(elem *IPv4Address) *IPv4Address {
+			return mirrorIPv4(addr, elem, prefLen)
+		},
+	}
+}
+
+// SequentialBlockReverseIterator iterates through the sequential subnets or addresses that make up this address or subnet,
+// in the reverse order of SequentialBlockIterator, from the highest block to the lowest.
+func (addr *IPv4Address) SequentialBlockReverseIterator() Iterator[*IPv4Address] {
+	if addr == nil {
+		return addr.SequentialBlockIterator()
+	}
+	addr = addr.init()
+	return &mirrorIterator[*IPv4Address]{
+		Iterator: addr.SequentialBlockIterator(),
+		mirror: func(elem *IPv4Address) *IPv4Address {
+			return mirrorIPv4(addr, elem, nil)
+		},
+	}
+}
+
+// ReverseIterator provides an iterator to iterate through the individual addresses of this address or subnet,
+// in the reverse order of Iterator, from the highest address to the lowest.
+//
+// The addresses are computed as they are iterated, so reverse iteration of a large subnet requires no more memory than forward iteration.
+func (addr *IPv6Address) ReverseIterator() Iterator[*IPv6Address] {
+	if addr == nil {
+		return addr.Iterator()
+	}
+	addr = addr.init()
+	return &mirrorIterator[*IPv6Address]{
+		Iterator: addr.Iterator(),
+		mirror: func(elem *IPv6Address) *IPv6Address {
+			return mirrorIPv6(addr, elem, nil)
+		},
+	}
+}
+
+// PrefixBlockReverseIterator provides an iterator to iterate through the individual prefix blocks of this address or subnet,
+// in the reverse order of PrefixBlockIterator, from the highest prefix block to the lowest.
+//
+// If this address has no prefix length, then this is equivalent to ReverseIterator.
+func (addr *IPv6Address) PrefixBlockReverseIterator() Iterator[*IPv6Address] {
+	if addr == nil {
+		return addr.PrefixBlockIterator()
+	}
+	addr = addr.init()
+	prefLen := addr.GetPrefixLen()
+	return &mirrorIterator[*IPv6Address]{
+		Iterator: addr.PrefixBlockIterator(),
+		mirror: func(elem *IPv6Address) *IPv6Address {
+			return mirrorIPv6(addr, elem, prefLen)
+		},
+	}
+}
+
+// SequentialBlockReverseIterator iterates through the sequential subnets or addresses that make up this address or subnet,
+// in the reverse order of SequentialBlockIterator, from the highest block to the lowest.
+func (addr *IPv6Address) SequentialBlockReverseIterator() Iterator[*IPv6Address] {
+	if addr == nil {
+		return addr.SequentialBlockIterator()
+	}
+	addr = addr.init()
+	return &mirrorIterator[*IPv6Address]{
+		Iterator: addr.SequentialBlockIterator(),
+		mirror: func(elem *IPv6Address) *IPv6Address {
+			return mirrorIPv6(addr, elem, nil)
+		},
+	}
+}
+
+// ReverseIterator provides an iterator to iterate through the individual addresses of this address or address collection,
+// in the reverse order of Iterator, from the highest address to the lowest.
+func (addr *MACAddress) ReverseIterator() Iterator[*MACAddress] {
+	if addr == nil {
+		return addr.Iterator()
+	}
+	addr = addr.init()
+	return &mirrorIterator[*MACAddress]{
+		Iterator: addr.Iterator(),
+		mirror: func(elem *MACAddress) *MACAddress {
+			return mirrorMAC(addr, elem, nil)
+		},
+	}
+}
+
+// PrefixBlockReverseIterator provides an iterator to iterate through the individual prefix blocks of this address collection,
+// in the reverse order of PrefixBlockIterator, from the highest prefix block to the lowest.
+//
+// If this address has no prefix length, then this is equivalent to ReverseIterator.
+func (addr *MACAddress) PrefixBlockReverseIterator() Iterator[*MACAddress] {
+	if addr == nil {
+		return addr.PrefixBlockIterator()
+	}
+	addr = addr.init()
+	prefLen := addr.GetPrefixLen()
+	return &mirrorIterator[*MACAddress]{
+		Iterator: addr.PrefixBlockIterator(),
+		mirror: func(elem *MACAddress) *MACAddress {
+			return mirrorMAC(addr, elem, prefLen)
+		},
+	}
+}
+
+// SequentialBlockReverseIterator iterates through the sequential address collections that make up this address collection,
+// in the reverse order of SequentialBlockIterator, from the highest block to the lowest.
+func (addr *MACAddress) SequentialBlockReverseIterator() Iterator[*MACAddress] {
+	if addr == nil {
+		return addr.SequentialBlockIterator()
+	}
+	addr = addr.init()
+	return &mirrorIterator[*MACAddress]{
+		Iterator: addr.SequentialBlockIterator(),
+		mirror: func(elem *MACAddress) *MACAddress {
+			return mirrorMAC(addr, elem, nil)
+		},
+	}
+}
+
+// seqRangeReverseIterator iterates downwards from the upper address of a sequential range to the lower.
+type seqRangeReverseIterator[T SequentialRangeConstraint[T]] struct {
+	current, lower T
+	done           bool
+}
+
+func (iter *seqRangeReverseIterator[T]) HasNext() bool {
+	return !iter.done
+}
+
+func (iter *seqRangeReverseIterator[T]) Next() (res T) {
+	if iter.done {
+		return
+	}
+	res = iter.current
+	if res.equalsSameVersion(iter.lower) {
+		iter.done = true
+	} else {
+		iter.current = res.Increment(-1)
+	}
+	return
+}
+
+// ReverseIterator provides an iterator to iterate through the individual addresses of this address range,
+// in the reverse order of Iterator, from the upper address to the lower.
+//
+// Call GetCount for the count.
+func (rng *SequentialRange[T]) ReverseIterator() Iterator[T] {
+	if rng == nil {
+		return nilIterator[T]()
+	}
+	rng = rng.init()
+	return &seqRangeReverseIterator[T]{current: rng.upper, lower: rng.lower}
+}
@@ -0,0 +1,119 @@
+package goip
+
+import (
+	"math/big"
+
+	"github.com/pchchv/goip/address_error"
+)
+
+// stridedIterator iterates through every stride'th address of a subnet,
+// using Increment to go directly to each iterated address without visiting the addresses in between.
+type stridedIterator[T interface {
+	Increment(int64) T
+	IsSequential() bool
+}] struct {
+	subnet, current T
+	count, index    *big.Int
+	stride          int64
+	done            bool
+}
+
+func (iter *stridedIterator[T]) HasNext() bool {
+	return !iter.done
+}
+
+func (iter *stridedIterator[T]) Next() (res T) {
+	if iter.done {
+		return
+	}
+	if iter.index.IsInt64() {
+		res = iter.subnet.Increment(iter.index.Int64())
+	} else {
+		// the index is beyond the range of Increment, which is possible only with the largest IPv6 subnets,
+		// in which case the addresses of a sequential subnet can be reached from the previous address
+		res = iter.current.Increment(iter.stride)
+	}
+	iter.current = res
+	iter.index.Add(iter.index, big.NewInt(iter.stride))
+	iter.done = iter.count.Cmp(iter.index) <= 0 || (!iter.index.IsInt64() && !iter.subnet.IsSequential())
+	return
+}
+
+func newStridedIterator[T interface {
+	Increment(int64) T
+	IsSequential() bool
+}](subnet T, count *big.Int, stride int64) Iterator[T] {
+	return &stridedIterator[T]{subnet: subnet, count: count, index: new(big.Int), stride: stride}
+}
+
+func checkStride(stride int64) address_error.AddressValueError {
+	if stride <= 0 {
+		return &addressValueError{addressError: addressError{key: "ipaddress.error.invalid.stride"}, val: int(stride)}
+	}
+	return nil
+}
+
+// StridedIterator provides an iterator to iterate through every stride'th individual address of this address or subnet,
+// in the same order as Iterator, starting with the first.
+// A stride of 1 iterates through the same addresses as Iterator.
+// A stride that is not smaller than the count of addresses iterates through the first address alone.
+//
+// The iterated addresses are computed directly with Increment, so the addresses in between are not visited.
+// For a prefix block, the stride applies to the individual addresses of the block.
+// For an IPv6 subnet that is not sequential, such as "1:*::*", the iteration ends once the position within the subnet exceeds the range of int64.
+//
+// If the stride is not positive, an error is returned.
+func (addr *IPAddress) StridedIterator(stride int64) (Iterator[*IPAddress], address_error.AddressValueError) {
+	if err := checkStride(stride); err != nil {
+		return nil, err
+	} else if addr == nil {
+		return addr.Iterator(), nil
+	}
+	addr = addr.init()
+	return newStridedIterator(addr, addr.GetCount(), stride), nil
+}
+
+// StridedIterator provides an iterator to iterate through every stride'th individual address of this address or subnet,
+// in the same order as Iterator, starting with the first.
+// See IPAddress.StridedIterator for details.
+//
+// If the stride is not positive, an error is returned.
+func (addr *IPv4Address) StridedIterator(stride int64) (Iterator[*IPv4Address], address_error.AddressValueError) {
+	if err := checkStride(stride); err != nil {
+		return nil, err
+	} else if addr == nil {
+		return addr.Iterator(), nil
+	}
+	addr = addr.init()
+	return newStridedIterator(addr, addr.GetCount(), stride), nil
+}
+
+// StridedIterator provides an iterator to iterate through every stride'th individual address of this address or subnet,
+// in the same order as Iterator, starting with the first.
+// See IPAddress.StridedIterator for details.
+//
+// If the stride is not positive, an error is returned.
+func (addr *IPv6Address) StridedIterator(stride int64) (Iterator[*IPv6Address], address_error.AddressValueError) {
+	if err := checkStride(stride); err != nil {
+		return nil, err
+	} else if addr == nil {
+		return addr.Iterator(), nil
+	}
+	addr = addr.init()
+	return newStridedIterator(addr, addr.GetCount(), stride), nil
+}
+
+// StridedIterator provides an iterator to iterate through every stride'th individual address of this address or address collection,
+// in the same order as Iterator, starting with the first.
+// See IPAddress.StridedIterator for details.
+//
+// If the stride is not positive, an error is returned.
+func (addr *MACAddress) StridedIterator(stride int64) (Iterator[*MACAddress], address_error.AddressValueError) {
+	if err := checkStride(stride); err != nil {
+		return nil, err
+	} else if addr == nil {
+		return addr.Iterator(), nil
+	}
+	addr = addr.init()
+	return newStridedIterator(addr, addr.GetCount(), stride), nil
+}
@@ -0,0 +1,87 @@
+package goip
+
+import (
+	"math/bits"
+
+	"github.com/pchchv/goip/address_error"
+)
+
+type splittableBlock[T any] interface {
+	GetBitCount() BitCount
+	GetPrefixLenForSingleBlock() PrefixLen
+	SetPrefixLen(BitCount) T
+	PrefixBlockIterator() Iterator[T]
+}
+
+func splitIntoPrefixLength[T splittableBlock[T]](addr T, prefixLen BitCount) ([]T, address_error.AddressValueError) {
+	blockPrefixLen := addr.GetPrefixLenForSingleBlock()
+	if blockPrefixLen == nil {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.address.not.block"}}
+	} else if prefixLen < blockPrefixLen.bitCount() || prefixLen > addr.GetBitCount() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.split.prefix.length"}, val: prefixLen}
+	}
+
+	// applying the longer prefix length to the block produces a subnet with the same addresses,
+	// whose prefix blocks are the split blocks
+	var blocks []T
+	for iter := addr.SetPrefixLen(blockPrefixLen.bitCount()).SetPrefixLen(prefixLen).PrefixBlockIterator(); iter.HasNext(); {
+		blocks = append(blocks, iter.Next())
+	}
+	return blocks, nil
+}
+
+func splitIntoN[T splittableBlock[T]](addr T, n int) ([]T, address_error.AddressValueError) {
+	if n <= 0 || n&(n-1) != 0 {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.split.count"}, val: n}
+	}
+
+	blockPrefixLen := addr.GetPrefixLenForSingleBlock()
+	if blockPrefixLen == nil {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.address.not.block"}}
+	}
+
+	prefixLen := blockPrefixLen.bitCount() + bits.TrailingZeros(uint(n))
+	if prefixLen > addr.GetBitCount() {
+		return nil, &addressValueError{addressError: addressError{key: "ipaddress.error.split.too.small"}, val: n}
+	}
+	return splitIntoPrefixLength(addr, prefixLen)
+}
+
+// SplitIntoN splits this block of addresses into n blocks of equal size, returned in order from lowest to highest.
+// For instance, splitting "192.168.0.0/24" into 4 blocks produces "192.168.0.0/26", "192.168.0.64/26", "192.168.0.128/26", and "192.168.0.192/26".
+//
+// This address or subnet must be a single prefix block, as determined by GetPrefixLenForSingleBlock,
+// such as "1.2.0.0/16", "1.2.*.*", or the individual address "1.2.3.4", otherwise an error is returned.
+// An error is also returned if n is not a positive power of two, or if the block has fewer than n addresses.
+func (addr *IPv4Address) SplitIntoN(n int) ([]*IPv4Address, address_error.AddressValueError) {
+	return splitIntoN(addr.init(), n)
+}
+
+// SplitIntoPrefixLength splits this block of addresses into all the prefix blocks of the given prefix length,
+// returned in order from lowest to highest.
+// For instance, splitting "192.168.0.0/24" with the prefix length 25 produces "192.168.0.0/25" and "192.168.0.128/25".
+//
+// This address or subnet must be a single prefix block, as determined by GetPrefixLenForSingleBlock, otherwise an error is returned.
+// An error is also returned if the given prefix length is shorter than the prefix length of the block or longer than the address bit-length.
+func (addr *IPv4Address) SplitIntoPrefixLength(prefixLen BitCount) ([]*IPv4Address, address_error.AddressValueError) {
+	return splitIntoPrefixLength(addr.init(), prefixLen)
+}
+
+// SplitIntoN splits this block of addresses into n blocks of equal size, returned in order from lowest to highest.
+// For instance, splitting "2001:db8::/32" into 2 blocks produces "2001:db8::/33" and "2001:db8:8000::/33".
+//
+// This address or subnet must be a single prefix block, as determined by GetPrefixLenForSingleBlock, otherwise an error is returned.
+// An error is also returned if n is not a positive power of two, or if the block has fewer than n addresses.
+func (addr *IPv6Address) SplitIntoN(n int) ([]*IPv6Address, address_error.AddressValueError) {
+	return splitIntoN(addr.init(), n)
+}
+
+// SplitIntoPrefixLength splits this block of addresses into all the prefix blocks of the given prefix length,
+// returned in order from lowest to highest.
+//
+// This address or subnet must be a single prefix block, as determined by GetPrefixLenForSingleBlock, otherwise an error is returned.
+// An error is also returned if the given prefix length is shorter than the prefix length of the block or longer than the address bit-length.
+// Note that the number of resulting blocks doubles with each additional bit of prefix length.
+func (addr *IPv6Address) SplitIntoPrefixLength(prefixLen BitCount) ([]*IPv6Address, address_error.AddressValueError) {
+	return splitIntoPrefixLength(addr.init(), prefixLen)
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/pchchv/goip"
 	"github.com/pchchv/goip/address_string_param"
-	"github.com/seancfoley/ipaddress-go/ipaddr"
 )
 
 type OrderingSupplier func(string, int) *Ordering
@@ -25,6 +24,55 @@ type addressOrderTest struct {
 
 func (t addressOrderTest) run() {
 	t.testOrder()
+	t.testSpecificityOrder()
+}
+
+func (t addressOrderTest) testSpecificityOrder() {
+	// sorted from most specific to least specific, with ties broken by low value, and IPv4 ahead of IPv6
+	expected := []string{
+		"1.2.3.4",
+		"1.2.3.5/32",
+		"9.0.0.1",
+		"1.2.3.4/31",
+		"1.2.3.0/24",
+		"1.2.*.*",
+		"10.0.0.0/8",
+		"0.0.0.0/0",
+		"1::1",
+		"1::/64",
+		"::/0",
+	}
+	addrs := make([]*goip.IPAddress, len(expected))
+	for i, str := range expected {
+		addrs[len(addrs)-1-i] = t.createParamsAddress(str, orderingOpts).GetAddress()
+	}
+	goip.SortBySpecificity(addrs)
+	for i, addr := range addrs {
+		if expectedAddr := t.createParamsAddress(expected[i], orderingOpts).GetAddress(); !addr.Equal(expectedAddr) {
+			t.addFailure(newIPAddrFailure(fmt.Sprintf("item %v: expected %v in the specificity ordering", i+1, expectedAddr), addr))
+		}
+	}
+
+	// ranges are ordered by the prefix blocks they match, as with the corresponding addresses
+	comparator := goip.SpecificityComparator()
+	for i := 1; i < len(expected); i++ {
+		one, two := addrs[i-1], addrs[i]
+		if one.IsIPv4() != two.IsIPv4() {
+			continue
+		}
+		oneRange, twoRange := one.ToSequentialRange(), two.ToSequentialRange()
+		if comparator.CompareRanges(oneRange, twoRange) >= 0 || comparator.CompareRanges(twoRange, oneRange) <= 0 {
+			t.addFailure(newSeqRangeFailure(fmt.Sprintf("expected the range to precede %v", twoRange), oneRange))
+		}
+	}
+	if comparator.CompareRanges(specificityRange("1.2.3.4", "1.2.3.4"), specificityRange("1.0.0.0", "1.255.255.255")) >= 0 {
+		t.addFailure(newFailure("expected the single address range to precede the /8 range", nil))
+	}
+	t.incrementTestCount()
+}
+
+func specificityRange(lower, upper string) *goip.IPAddressSeqRange {
+	return goip.NewIPAddressString(lower).GetAddress().SpanWithRange(goip.NewIPAddressString(upper).GetAddress())
 }
 
 func (t addressOrderTest) testOrder() {
@@ -136,9 +184,9 @@ func (t addressOrderTest) testOrder() {
 }
 
 type Ordering struct {
-	nestedType          *ipaddr.Address
-	nestedIPAddrString  *ipaddr.IPAddressString
-	nestedMACAddrString *ipaddr.MACAddressString
+	nestedType          *goip.Address
+	nestedIPAddrString  *goip.IPAddressString
+	nestedMACAddrString *goip.MACAddressString
 
 	order int
 }
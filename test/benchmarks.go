@@ -1,7 +1,9 @@
 package test
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/pchchv/goip"
@@ -564,6 +566,54 @@ func benchIPv6Ceiling(b *testing.B, addr *goip.IPv6Address) {
 	}
 }
 
+// the time to enumerate a block should drop in proportion to the number of goroutines, up to the number of available cores
+func BenchmarkParallelIterator(b *testing.B) {
+	block := goip.NewIPAddressString("10.0.0.0/16").GetAddress()
+	for _, parallelism := range []int{1, 2, 4} {
+		b.Run(fmt.Sprintf("parallelism-%d", parallelism), func(b *testing.B) {
+			iter := block.ParallelIterator(parallelism)
+			for i := 0; i < b.N; i++ {
+				iter.Iterate(context.Background(), func(*goip.IPAddress) bool { return false })
+			}
+		})
+	}
+}
+
+// aggregating 1,000 random individual addresses should take less than a millisecond
+func BenchmarkAggregateToPrefixBlocks(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	addrs := make([]*goip.IPAddress, 1000)
+	for i := range addrs {
+		addrs[i] = goip.NewIPv4AddressFromUint32(random.Uint32()).ToIP()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		goip.AggregateToPrefixBlocks(addrs)
+	}
+}
+
+// constructing a trie from a slice should be faster than adding the same addresses one at a time
+func BenchmarkTrieFromSlice(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	addrs := make([]*goip.IPv4Address, 10000)
+	for i := range addrs {
+		addrs[i] = goip.NewIPv4AddressFromUint32(random.Uint32()).ToPrefixBlockLen(goip.BitCount(8 + random.Intn(25)))
+	}
+	b.Run("individually", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			trie := &goip.IPv4AddressTrie{}
+			for _, addr := range addrs {
+				trie.Add(addr)
+			}
+		}
+	})
+	b.Run("from-slice", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			goip.NewIPv4AddressTrieFromSlice(addrs)
+		}
+	})
+}
+
 func printOp(format string, a ...any) {
 	fmt.Printf(format, a...)
 }
@@ -602,6 +602,18 @@ func (t hostTester) run() {
 	}, nil)
 	t.testHostInetSocketAddressSA("1.2.3.4:http", nil, nil)
 
+	t.testHostHTTPURL("example.com:8080", "", "path", "http://example.com:8080/path")
+	t.testHostHTTPURL("example.com", "https", "", "https://example.com")
+	t.testHostHTTPURL("[::1]:8080", "", "/path", "http://[::1]:8080/path")
+	t.testHostHTTPURL("1.2.3.4", "", "", "http://1.2.3.4")
+	t.testHostHTTPURL("example.com", "ht tp", "", "")
+	t.testHostHTTPURL("1.2.3.0/24", "", "", "")
+
+	t.testVersionedHostAddress("1.2.3.4", "1.2.3.4", "")
+	t.testVersionedHostAddress("1.2.3.4:80", "1.2.3.4", "")
+	t.testVersionedHostAddress("[1::1]", "", "1::1")
+	t.testVersionedHostAddress("[1::1]:80", "", "1::1")
+	t.testVersionedHostAddress("::ffff:1.2.3.4", "", "::ffff:102:304")
 }
 
 func (t hostTester) testSelf(host string, isSelf bool) {
@@ -970,6 +982,46 @@ func (t hostTester) testHostAll(hostName *goip.HostName, hostExpected, addrExpec
 	t.incrementTestCount()
 }
 
+// testHostHTTPURL expects an error when the expected URL is empty
+func (t hostTester) testHostHTTPURL(str, scheme, path, expected string) {
+	host := t.createHost(str)
+	result, err := host.ToHTTPURL(scheme, path)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newHostFailure("expected error, got URL "+result, host))
+		}
+	} else if err != nil {
+		t.addFailure(newHostFailure("unexpected error "+err.Error(), host))
+	} else if result != expected {
+		t.addFailure(newHostFailure("URL "+result+" does not match expected "+expected, host))
+	}
+	t.incrementTestCount()
+}
+
+// testVersionedHostAddress checks the IPv4 and IPv6 addresses of the host, with an empty expected string indicating an error is expected
+func (t hostTester) testVersionedHostAddress(str, expectedIPv4, expectedIPv6 string) {
+	host := t.createHost(str)
+	if ipv4, err := host.GetIPv4Address(); expectedIPv4 == "" {
+		if err == nil {
+			t.addFailure(newHostFailure("expected error, got IPv4 address "+ipv4.String(), host))
+		}
+	} else if err != nil {
+		t.addFailure(newHostFailure("unexpected error "+err.Error(), host))
+	} else if ipv4.String() != expectedIPv4 {
+		t.addFailure(newHostFailure("IPv4 address "+ipv4.String()+" does not match expected "+expectedIPv4, host))
+	}
+	if ipv6, err := host.GetIPv6Address(); expectedIPv6 == "" {
+		if err == nil {
+			t.addFailure(newHostFailure("expected error, got IPv6 address "+ipv6.String(), host))
+		}
+	} else if err != nil {
+		t.addFailure(newHostFailure("unexpected error "+err.Error(), host))
+	} else if ipv6.String() != expectedIPv6 {
+		t.addFailure(newHostFailure("IPv6 address "+ipv6.String()+" does not match expected "+expectedIPv6, host))
+	}
+	t.incrementTestCount()
+}
+
 func ToPort(i goip.PortInt) goip.Port {
 	res := goip.PortNum(i)
 	return &res
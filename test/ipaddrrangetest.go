@@ -1,15 +1,26 @@
 package test
 
 import (
+	"database/sql"
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
+	"net"
+	"net/netip"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pchchv/goip"
+	"github.com/pchchv/goip/address_error"
 	"github.com/pchchv/goip/address_string_param"
 )
 
@@ -2440,6 +2451,322 @@ func (t ipAddressRangeTester) run() {
 	t.testCover("::1", "::", "::0-1/127")
 	t.testCoverSingle("ffff:ffff:ffff:ffff::/64", "ffff:ffff:ffff:ffff:*/64")
 
+	t.testIPRangeString("10.0.0.1-255", "10.0.0.1-10.0.0.255")
+	t.testIPRangeString("10.0.0.0/24", "10.0.0.0/24")
+	t.testIPRangeString("10.0.0.*", "10.0.0.0-10.0.0.255")
+	t.testIPRangeString("1.2.3.4", "1.2.3.4")
+	t.testIPRangeString("1.2-3.4.5", "1.2.4.5-1.3.4.5")
+	t.testIPRangeString("1::1-ff", "1::1-1::ff")
+
+	t.testParseIPRangeString("10.0.0.1-10.0.0.255", "10.0.0.1", "10.0.0.255")
+	t.testParseIPRangeString("10.0.0.255-10.0.0.1", "10.0.0.1", "10.0.0.255")
+	t.testParseIPRangeString("1.2.3.4-5", "1.2.3.4", "1.2.3.5")
+	t.testParseIPRangeString("1::1-1::ff", "1::1", "1::ff")
+	t.testParseIPRangeString("1.2.3.4-::1", "", "")
+	t.testParseIPRangeString("1.2.3.4-1.2.3.0/24", "", "")
+
+	t.testCondensedIPv4Notation("10.0.0.1", 1, "10.0.1")
+	t.testCondensedIPv4Notation("10.0.0.1", 2, "10.1")
+	t.testCondensedIPv4Notation("10.0.0.1", 3, "167772161")
+	t.testCondensedIPv4Notation("1.2.3.4", 2, "1.131844")
+	t.testCondensedIPv4Notation("10.0.0.1", 0, "")
+	t.testCondensedIPv4Notation("10.0.0.1", 4, "")
+	t.testCondensedIPv4Notation("10.0.0.*", 1, "10.0.0-255")
+	t.testCondensedIPv4Notation("10.0.*.1", 1, "")
+
+	t.testIsHost("1.2.3.4", true)
+	t.testIsHost("1.2.3.4/16", true)
+	t.testIsHost("1.2.3.*", false)
+	t.testIsHost("1.2.0.0/16", false)
+	t.testIsHost("1.2.3.4/32", false)
+	t.testIsHost("1::1", true)
+	t.testIsHost("1::1-2", false)
+
+	t.testNetip("1.2.3.4", "1.2.3.4", "1.2.3.4/32")
+	t.testNetip("1.2.3.4/16", "1.2.3.4", "1.2.3.4/16")
+	t.testNetip("1.2.0.0/16", "1.2.0.0", "1.2.0.0/16")
+	t.testNetip("1.2.*.*", "1.2.0.0", "1.2.0.0/16")
+	t.testNetip("1.2.3.1-2", "1.2.3.1", "")
+	t.testNetip("fe80::1%eth0", "fe80::1%eth0", "fe80::1/128")
+	t.testNetip("1:2::/32", "1:2::", "1:2::/32")
+	t.testNetip("", "", "")
+
+	t.testParseAddressList("10.0.0.1 10.0.0.2, 192.168.0.0/24", []string{"10.0.0.1", "10.0.0.2", "192.168.0.0/24"}, nil)
+	t.testParseAddressList(" ::1,,1.2.3.*\n\t1:2::/32 ", []string{"::1", "1.2.3.*", "1:2::/32"}, nil)
+	t.testParseAddressList("1.2.3.4, 1.2.3.256 foo *", []string{"1.2.3.4"}, []string{"1.2.3.256", "foo", "*"})
+	t.testParseAddressList(" , ", nil, nil)
+
+	t.testSubtractRanges("1.2.3.0-100", "1.2.3.10-20", []string{"1.2.3.0 -> 1.2.3.9", "1.2.3.21 -> 1.2.3.100"})
+	t.testSubtractRanges("1.2.3.0-100", "1.2.3.0-20", []string{"1.2.3.21 -> 1.2.3.100"})
+	t.testSubtractRanges("1.2.3.0-100", "1.2.3.50-200", []string{"1.2.3.0 -> 1.2.3.49"})
+	t.testSubtractRanges("1.2.3.0-100", "1.2.3.101-200", []string{"1.2.3.0 -> 1.2.3.100"})
+	t.testSubtractRanges("1.2.3.0-100", "1.2.3.0-100", nil)
+	t.testSubtractRanges("1.2.3.10-20", "1.2.3.0-100", nil)
+	t.testSubtractRanges("1::-ffff", "1::100-1ff", []string{"1:: -> 1::ff", "1::200 -> 1::ffff"})
+
+	t.testWildcardString("1.2.*.4", true, false)
+	t.testWildcardString("1.2.3._", true, false)
+	t.testWildcardString("1.2.3-4.5", true, true)
+	t.testWildcardString("*:*", true, false)
+	t.testWildcardString("*", true, false)
+	t.testWildcardString("1::2-3", true, true)
+	t.testWildcardString("::ffff:1.2.3-4.5", true, true)
+	t.testWildcardString("::ffff:1.2.*.5", true, false)
+	t.testWildcardString("1.2.0.0/16", false, false)
+	t.testWildcardString("1.2.3.4", false, false)
+	t.testWildcardString("1::/64", false, false)
+	t.testWildcardString("", false, false)
+	t.testWildcardString("1.2.3.x", false, false)
+
+	t.testReachabilityBitmap("10.0.0-2.*", 24, []byte{0xe0}, []string{"10.0.0.0/23", "10.0.2.0/24"})
+	t.testReachabilityBitmap("10.0.0-2.128-255", 25, []byte{0x54}, []string{"10.0.0.128/25", "10.0.1.128/25", "10.0.2.128/25"})
+	t.testReachabilityBitmap("10.0.0.0/24", 26, []byte{0xf0}, []string{"10.0.0.0/24"})
+	t.testReachabilityBitmap("10.0.0.0/24", 24, []byte{0x80}, []string{"10.0.0.0/24"})
+	t.testReachabilityBitmap("10.0.0-5.*", 24, []byte{0xfc}, []string{"10.0.0.0/22", "10.0.4.0/23"})
+	t.testReachabilityBitmap("10.0.0.1-7", 30, []byte{0x40}, []string{"10.0.0.4/30"})
+	t.testReachabilityBitmap("10.0.0.1-2", 30, []byte{0}, nil)
+	t.testReachabilityBitmap("10.0.0.0/24", 23, nil, nil)
+	t.testReachabilityBitmap("10.0.0.0/24", 33, nil, nil)
+	t.testInvalidReachabilityBitmap("10.0.0.0/24", 26, []byte{0xf0, 0})
+	t.testInvalidReachabilityBitmap("10.0.0.0/24", 26, nil)
+	t.testInvalidReachabilityBitmap("10.0.0.0/24", 20, []byte{0xf0})
+
+	t.testPrefixBlockForLength("1.2.0.0/16", 16, true)
+	t.testPrefixBlockForLength("1.2.*.*", 16, true)
+	t.testPrefixBlockForLength("1.2.3.0/16", 16, false)
+	t.testPrefixBlockForLength("1.2.0.0/24", 16, false)
+	t.testPrefixBlockForLength("1.2.0.0/16", 24, false)
+	t.testPrefixBlockForLength("1.2.0.0/16", 8, false)
+	t.testPrefixBlockForLength("1.2.3.4", 32, true)
+	t.testPrefixBlockForLength("*.*.*.*", 0, true)
+	t.testPrefixBlockForLength("1.2-3.*.*", 15, true)
+	t.testPrefixBlockForLength("1.1-2.*.*", 15, false)
+	t.testPrefixBlockForLength("1.2-3.*.*", 16, false)
+	t.testPrefixBlockForLength("1:2::/32", 32, true)
+	t.testPrefixBlockForLength("1:2:*", 32, true)
+
+	t.testSplitToSequentialRanges("1.2-3.4.5-6", []string{"1.2.4.5 -> 1.2.4.6", "1.3.4.5 -> 1.3.4.6"})
+	t.testSplitToSequentialRanges("1.2.3-4.*", []string{"1.2.3.0 -> 1.2.4.255"})
+	t.testSplitToSequentialRanges("1.2.3.4", []string{"1.2.3.4 -> 1.2.3.4"})
+	t.testSplitToSequentialRanges("1.2.0.0/16", []string{"1.2.0.0 -> 1.2.255.255"})
+	t.testSplitToSequentialRanges("1-2.3.4.*", []string{"1.3.4.0 -> 1.3.4.255", "2.3.4.0 -> 2.3.4.255"})
+	t.testSplitToSequentialRanges("1::1-2:3", []string{"1::1:3 -> 1::1:3", "1::2:3 -> 1::2:3"})
+
+	t.testFullRange("0.0.0.0/0", true)
+	t.testFullRange("::/0", true)
+	t.testFullRange("*.*.*.*", true)
+	t.testFullRange("*:*", true)
+	t.testFullRange("0-255.*.*.*", true)
+	t.testFullRange("0-254.*.*.*", false)
+	t.testFullRange("1-255.*.*.*", false)
+	t.testFullRange("0.0.0.0/1", false)
+	t.testFullRange("0.0.0.0", false)
+	t.testFullRange("1.2.3.4/0", false)
+
+	t.testSeqRangeAliases("1.2.3.4", "1.2.5.6")
+	t.testSeqRangeAliases("1::", "1::ffff")
+
+	t.testContainsString("1.2.0.0/16", "1.2.3.4", true, false)
+	t.testContainsString("1.2.*.*", "1.2.3.0/24", true, false)
+	t.testContainsString("1.2.3.0/24", "1.2.0.0/16", false, false)
+	t.testContainsString("1::/64", "1.2.3.4", false, false)
+	t.testContainsString("1.2.3.4/33", "1.2.3.4", false, true)
+	t.testContainsString("1.2.3.4", "1.2.3.256", false, true)
+	t.testContainsString("bad", "bad", false, true)
+
+	t.testJSON("1.2.3.4", `"1.2.3.4"`)
+	t.testJSON("1.2.0.0/16", `"1.2.0.0/16"`)
+	t.testJSON("1.2.3.4/16", `"1.2.3.4/16"`)
+	t.testJSON("1.2.*.4", `"1.2.*.4"`)
+	t.testJSON("1.2.3-4.5", `"1.2.3-4.5"`)
+	t.testJSON("fe80::1%eth0", `"fe80::1%eth0"`)
+	t.testJSON("1:2::/32", `"1:2::/32"`)
+	t.testJSON("1:2:0:0:0:0:0:ff", `"1:2::ff"`)
+	t.testInvalidJSON(`"1.2.3.4"`, goip.IPv6)
+	t.testInvalidJSON(`"1::"`, goip.IPv4)
+	t.testInvalidJSON(`"1.2.3.256"`, goip.IndeterminateIPVersion)
+	t.testInvalidJSON(`1234`, goip.IndeterminateIPVersion)
+	t.testInvalidJSON(`"*"`, goip.IndeterminateIPVersion)
+	t.testRangeJSON("1.2.3.4", "1.2.4.0", `{"lower":"1.2.3.4","upper":"1.2.4.0"}`)
+	t.testRangeJSON("1::", "1::ff", `{"lower":"1::","upper":"1::ff"}`)
+	t.testInvalidRangeJSON(`{"lower":"1.2.3.4","upper":"1::"}`)
+	t.testInvalidRangeJSON(`{"lower":"1.2.3.4","upper":"x"}`)
+	t.testInvalidRangeJSON(`"1.2.3.4"`)
+
+	t.testSQL("192.168.1.0/24", "192.168.1.0/24")
+	t.testSQL("192.168.1.5/24", "192.168.1.5/24")
+	t.testSQL("1.2.*.4", "1.2.*.4")
+	t.testSQL("2001:db8::/32", "2001:db8::/32")
+	t.testSQL("fe80::1%eth0", "fe80::1%eth0")
+	t.testInvalidSQL(42)
+	t.testInvalidSQL("1.2.3.256")
+	t.testSQLParseError("1.2.3.256")
+	t.testSQLParseError("1:2:3:4:5:6:7:8:9")
+	t.testInvalidSQL(net.IP{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+
+	t.testText("1.2.3.4", "1.2.3.4")
+	t.testText("1.2.0.0/16", "1.2.0.0/16")
+	t.testText("1.2.*.4", "1.2.*.4")
+	t.testText("1:2::/32", "1:2::/32")
+	t.testText("fe80::1%eth0", "fe80::1%eth0")
+	t.testText("fe80::%eth0/64", "fe80::%eth0/64")
+	t.testText("1:2:*::", "1:2:*::")
+	t.testInvalidText("1.2.3.256")
+	t.testInvalidText("1:2:3:4:5:6:7:8:9")
+	t.testStringText("1.2.3-4.*/16")
+	t.testStringText("a.b.com:80")
+
+	t.testBinary("1.2.3.4", []byte{1, 4, 1, 2, 3, 4, 1, 2, 3, 4, 0})
+	t.testBinary("1.2.3.4/16", []byte{1, 4, 1, 2, 3, 4, 1, 2, 3, 4, 1, 16})
+	t.testBinary("1.2.0.0/16", []byte{1, 4, 1, 2, 0, 0, 1, 2, 255, 255, 1, 16})
+	t.testBinary("1.2.*.4", []byte{1, 4, 1, 2, 0, 4, 1, 2, 255, 4, 0})
+	t.testBinary("1:2::/32", nil)
+	t.testBinary("fe80::1%eth0", nil)
+	t.testBinary("fe80::%eth0/64", nil)
+	t.testBinary("1:2:*::", nil)
+	t.testInvalidBinary(nil)
+	t.testInvalidBinary([]byte{1})
+	t.testInvalidBinary([]byte{2, 4, 1, 2, 3, 4, 1, 2, 3, 4, 0})
+	t.testInvalidBinary([]byte{1, 5, 1, 2, 3, 4, 1, 2, 3, 4, 0})
+	t.testInvalidBinary([]byte{1, 4, 1, 2, 3, 4, 1, 2, 3, 4})
+	t.testInvalidBinary([]byte{1, 4, 1, 2, 3, 4, 1, 2, 3, 4, 0, 0})
+	t.testInvalidBinary([]byte{1, 4, 1, 2, 3, 4, 1, 2, 3, 4, 1, 33})
+	t.testInvalidBinary([]byte{1, 4, 1, 2, 3, 4, 1, 2, 3})
+
+	t.testContextIterator("1.2.3.*", 3)
+	t.testContextIterator("1.2.3.0/30", 0)
+	t.testContextIterator("1:2::/126", 2)
+	t.testContextIterator("*:*", 5)
+	t.testContextIteratorCancelledConcurrently("1::/64")
+	t.testContextIteratorCancelledConcurrently("*.*.*.*")
+
+	t.testReverseIterators("1.2.3.4")
+	t.testReverseIterators("1.2.3.4/16")
+	t.testReverseIterators("1.2.3.*")
+	t.testReverseIterators("1.2-4.3.0/28")
+	t.testReverseIterators("1.2.3-5.6-9/30")
+	t.testReverseIterators("1.*.3.0-1/9")
+	t.testReverseIterators("1:2::/124")
+	t.testReverseIterators("1:2-3::4-6/120")
+	t.testReverseIterators("fe80::1-3%eth0")
+	t.testReverseRangeIterator("1.2.3.250", "1.2.4.5")
+	t.testReverseRangeIterator("1:2::fffe", "1:2::1:1")
+	t.testReverseRangeIterator("1.2.3.4", "1.2.3.4")
+
+	t.testStridedIterator("1.2.3.0/24", 7, 37, "1.2.3.252")
+	t.testStridedIterator("1.2.3.*", 1, 256, "1.2.3.255")
+	t.testStridedIterator("1.2.3.*", 256, 1, "1.2.3.0")
+	t.testStridedIterator("1.2.3.*", 1000, 1, "1.2.3.0")
+	t.testStridedIterator("1.2.3.4", 5, 1, "1.2.3.4")
+	t.testStridedIterator("1.2-3.4-5.6", 3, 2, "1.3.5.6")
+	t.testStridedIterator("1.2-3.*.1-2", 5, 205, "1.3.254.1")
+	t.testStridedIterator("1:2::/120", 16, 16, "1:2::f0")
+	t.testStridedIterator("1:2:*::1-3", 4, 49152, "1:2:fffe::3")
+	t.testInvalidStride("1.2.3.*", 0)
+	t.testInvalidStride("1.2.3.*", -7)
+	t.testLargeStride("::/0", 1<<62)
+	t.testLargeStride("1:*::/16", 1<<40)
+	t.testLargeStride("1-2:*:*:*:*:0:0:1", 1<<62)
+
+	t.testRandomAddress("1.2.3.4")
+	t.testRandomAddress("1.2.3.0/28")
+	t.testRandomAddress("1.2-3.4.5-8")
+	t.testRandomAddress("1.0-2.*.0-4")
+	t.testRandomAddress("1:2::/123")
+	t.testRandomAddress("1:2-4::5-a")
+	t.testRandomAddress("fe80::1-3%eth0")
+	t.testRandomAddress("::/0")
+	t.testRandomAddress("1:*:*::*:*")
+
+	t.testSplitIntoN("0.0.0.0/0", 4, []string{"0.0.0.0/2", "64.0.0.0/2", "128.0.0.0/2", "192.0.0.0/2"})
+	t.testSplitIntoN("0.0.0.0/0", 1, []string{"0.0.0.0/0"})
+	t.testSplitIntoN("::/0", 2, []string{"::/1", "8000::/1"})
+	t.testSplitIntoN("192.168.0.0/24", 4, []string{"192.168.0.0/26", "192.168.0.64/26", "192.168.0.128/26", "192.168.0.192/26"})
+	t.testSplitIntoN("1.2.*.*", 2, []string{"1.2.0.0/17", "1.2.128.0/17"})
+	t.testSplitIntoN("1.2.3.254/31", 2, []string{"1.2.3.254/32", "1.2.3.255/32"})
+	t.testSplitIntoN("1.2.3.4", 1, []string{"1.2.3.4/32"})
+	t.testSplitIntoN("1:2::3", 1, []string{"1:2::3/128"})
+	t.testSplitIntoN("1.2.3.4", 2, nil)
+	t.testSplitIntoN("1.2.3.254/31", 4, nil)
+	t.testSplitIntoN("0.0.0.0/0", 3, nil)
+	t.testSplitIntoN("0.0.0.0/0", 0, nil)
+	t.testSplitIntoN("0.0.0.0/0", -2, nil)
+	t.testSplitIntoN("1.2.3-4.0/24", 2, nil)
+	t.testSplitIntoPrefixLength("0.0.0.0/0", 1, []string{"0.0.0.0/1", "128.0.0.0/1"})
+	t.testSplitIntoPrefixLength("1.2.0.0/16", 16, []string{"1.2.0.0/16"})
+	t.testSplitIntoPrefixLength("1.2.3.4", 32, []string{"1.2.3.4/32"})
+	t.testSplitIntoPrefixLength("1:2::/126", 127, []string{"1:2::/127", "1:2::2/127"})
+	t.testSplitIntoPrefixLength("1.2.0.0/16", 15, nil)
+	t.testSplitIntoPrefixLength("1.2.0.0/16", 33, nil)
+	t.testSplitIntoPrefixLength("1:2::/126", 129, nil)
+
+	t.testOverlaps("1.2.3.0/24", "1.2.3.255", true)
+	t.testOverlaps("1.2.3.0/24", "1.2.4.0", false)
+	t.testOverlaps("1.2.3.0/24", "1.2.2.255", false)
+	t.testOverlaps("1.2.3.0-127", "1.2.3.127-200", true)
+	t.testOverlaps("1.2.3.0-127", "1.2.3.128-255", false)
+	t.testOverlaps("1.2.3-4.5", "1.2.3.6-7", false)
+	t.testOverlaps("1.2.3-4.5", "1.2.4.4-5", true)
+	t.testOverlaps("1.2-3.4.5", "1.2-3.5.5", false)
+	t.testOverlaps("1.*.3.4", "2.2.*.4", false)
+	t.testOverlaps("0.0.0.0/0", "255.255.255.255", true)
+	t.testOverlaps("1.2.3.4", "::ffff:102:304", false)
+	t.testOverlaps("fe80::1%eth0", "fe80::1%eth1", true)
+	t.testOverlaps("1::/64", "1:0:0:1::/64", false)
+	t.testOverlaps("1::/64", "1::ffff:ffff:ffff:ffff", true)
+	t.testFindOverlaps([]string{"1.2.3.0/24", "1.2.4.0/24", "1.2.3.255", "1.2.3-4.5", "", "1.2.4.5", "::/0", "1::1"},
+		[][2]int{{0, 2}, {0, 3}, {1, 3}, {1, 5}, {3, 5}, {6, 7}})
+	t.testFindOverlaps([]string{"1.2.3.0-127", "1.2.3.128-255", "1.2.4.0"}, nil)
+	t.testFindOverlaps([]string{"1.2.3.4", "1.2.3.4", "1.2.3.4"}, [][2]int{{0, 1}, {0, 2}, {1, 2}})
+	t.testFindOverlaps(nil, nil)
+
+	t.testGapTo("1.2.3.0/24", "1.2.6.0/24", []string{"1.2.4.0/23"})
+	t.testGapTo("1.2.3.0/24", "1.2.4.0/24", nil)
+	t.testGapTo("1.2.3.0/24", "1.2.4.1", []string{"1.2.4.0/32"})
+	t.testGapTo("1.2.3.0/24", "1.2.3.255", nil)
+	t.testGapTo("1.2.6.0/24", "1.2.3.0/24", nil)
+	t.testGapTo("1.2.3.4/24", "1.2.3.7/24", []string{"1.2.3.5/32", "1.2.3.6/32"})
+	t.testGapTo("1.2.3-4.5", "1.2.5.0", []string{"1.2.4.6/31", "1.2.4.8/29", "1.2.4.16/28", "1.2.4.32/27", "1.2.4.64/26", "1.2.4.128/25"})
+	t.testGapTo("0.0.0.0", "0.0.0.3", []string{"0.0.0.1/32", "0.0.0.2/32"})
+	t.testGapTo("0.0.0.0/1", "255.255.255.255", []string{"128.0.0.0/2", "192.0.0.0/3", "224.0.0.0/4", "240.0.0.0/5", "248.0.0.0/6", "252.0.0.0/7",
+		"254.0.0.0/8", "255.0.0.0/9", "255.128.0.0/10", "255.192.0.0/11", "255.224.0.0/12", "255.240.0.0/13", "255.248.0.0/14", "255.252.0.0/15",
+		"255.254.0.0/16", "255.255.0.0/17", "255.255.128.0/18", "255.255.192.0/19", "255.255.224.0/20", "255.255.240.0/21", "255.255.248.0/22",
+		"255.255.252.0/23", "255.255.254.0/24", "255.255.255.0/25", "255.255.255.128/26", "255.255.255.192/27", "255.255.255.224/28",
+		"255.255.255.240/29", "255.255.255.248/30", "255.255.255.252/31", "255.255.255.254/32"})
+	t.testGapTo("1.2.3.4", "::ffff:1.2.3.6", nil)
+	t.testGapTo("1::/64", "1:0:0:2::/64", []string{"1:0:0:1::/64"})
+	t.testGapTo("::", "::ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", nil)
+	t.testFindGaps([]string{"1.2.3.0/24", "1.2.3.128/25", "1.2.5.0/24", "1.2.5.10", "", "1.2.7.0", "::1", "::5"},
+		[]string{"1.2.4.0/24", "1.2.6.0/24", "::2/127", "::4/128"})
+	t.testFindGaps([]string{"1.2.3.0/24", "1.2.4.0/24", "1.2.5.0/24"}, nil)
+	t.testFindGaps([]string{"1.2.0.0/16", "1.2.3.0/24", "1.2.255.255", "1.3.0.1"}, []string{"1.3.0.0/32"})
+	t.testFindGaps(nil, nil)
+
+	t.testRangeSetIdentities(
+		[]string{"1.2.3.0/24", "1.2.4.0-10", "10.0.0.0/8", "1::/64"},
+		[]string{"1.2.3.128-255", "1.2.4.5-20", "10.1.0.0/16", "::/0"},
+		[]string{"0.0.0.0/0", "1:0:0:0:8000::/65"})
+	t.testRangeSetIdentities(
+		[]string{"1.2.3.4", "1.2.3.5", "1.2.3.7"},
+		[]string{"1.2.3.6"},
+		[]string{"1.2.3.5-6"})
+	t.testRangeSetIdentities([]string{"1.2.3.0/24"}, nil, []string{"5.6.7.8"})
+	t.testRangeSetIdentities(nil, nil, nil)
+	t.testRangeSetContains([]string{"1.2.3.0/24", "1.2.5.0/24"}, "1.2.3-5.7", false)
+	t.testRangeSetContains([]string{"1.2.3.7", "1.2.4.7"}, "1.2.3-4.7", true)
+	t.testRangeSetContains([]string{"1.2.3.0/24", "1.2.4.0/24"}, "1.2.3-4.*", true)
+	t.testRangeSetContains([]string{"1.2.3.0/24", "::/0"}, "::ffff:1.2.3.4", true)
+	t.testRangeSetContains([]string{"1.2.3.0/24"}, "1.2.2.255", false)
+
+	t.testParallelIterator("1.2.3.4", 4)
+	t.testParallelIterator("1.2.3.0/24", 1)
+	t.testParallelIterator("1.2.3.0/24", 3)
+	t.testParallelIterator("1.2.3.0/30", 8)
+	t.testParallelIterator("1.*.3.4", 5)
+	t.testParallelIterator("1.2-3.4-5.6-7", 3)
+	t.testParallelIterator("1:2::/120", 7)
+	t.testParallelIterator("1:2-4::5-a", 2)
+
 	t.ipAddressTester.run()
 }
 
@@ -3417,13 +3744,13 @@ func (t ipAddressRangeTester) testIPv4IPAddrStrings(w *goip.IPAddressString, ipA
 		singleHex, singleOctal)
 
 	//now test some IPv4-only strings
-	t.testIPv4OnlyStrings(w, goip.ToIPv4(), octalString, hexString)
-	t.testInetAtonCombos(w, goip.ToIPv4())
+	t.testIPv4OnlyStrings(w, ipAddr.ToIPv4(), octalString, hexString)
+	t.testInetAtonCombos(w, ipAddr.ToIPv4())
 }
 
 func (t ipAddressRangeTester) testIPv4OnlyStrings(w *goip.IPAddressString, ipAddr *goip.IPv4Address, octalString, hexString string) {
-	oct := goip.ToInetAtonString(goip.InetAtonRadixOctal)
-	hex := goip.ToInetAtonString(goip.InetAtonRadixHex)
+	oct := ipAddr.ToInetAtonString(goip.InetAtonRadixOctal)
+	hex := ipAddr.ToInetAtonString(goip.InetAtonRadixHex)
 	octMatch := oct == octalString
 	if !octMatch {
 		t.addFailure(newFailure("failed expected: "+octalString+" actual: "+oct, w))
@@ -3437,13 +3764,13 @@ func (t ipAddressRangeTester) testIPv4OnlyStrings(w *goip.IPAddressString, ipAdd
 }
 
 func (t ipAddressRangeTester) testInetAtonCombos(w *goip.IPAddressString, ipAddr *goip.IPv4Address) {
-	vals := []goip.InetAtonRadix{goip.InetAtonRadixOctal, goip.InetAtonRadixHex, goip.InetAtonRadixDecimal}
+	vals := []goip.InetAtonRadix{goip.InetAtonRadixOctal, goip.InetAtonRadixHex, goip.InetAtonRadix_decimal}
 	for _, radix := range vals {
 		for i := 0; i < goip.IPv4SegmentCount; i++ {
-			str, e := goip.ToInetAtonJoinedString(radix, i)
+			str, e := ipAddr.ToInetAtonJoinedString(radix, i)
 			if e != nil {
 				//verify this case: joining segments results in a joined segment that is not a contiguous range
-				section := goip.GetSection()
+				section := ipAddr.GetSection()
 				verifiedIllegalJoin := false
 				for j := section.GetSegmentCount() - i - 1; j < section.GetSegmentCount()-1; j++ {
 					if section.GetSegment(j).IsMultiple() {
@@ -3461,7 +3788,7 @@ func (t ipAddressRangeTester) testInetAtonCombos(w *goip.IPAddressString, ipAddr
 			} else {
 				parsed := goip.NewIPAddressStringParams(str, inetAtonwildcardAndRangeOptions)
 				parsedValue := parsed.GetAddress()
-				if !goip.Equal(parsedValue) {
+				if !ipAddr.Equal(parsedValue) {
 					t.addFailure(newFailure("failed expected: "+ipAddr.String()+" actual: "+parsedValue.String(), w))
 				} else {
 					origStr := str
@@ -5025,6 +5352,1090 @@ func asRangeSlice(addrs []*goip.IPAddressSeqRange) (result []string) {
 	return
 }
 
+func (t ipAddressRangeTester) testIPRangeString(str, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.ToIPRangeString(); result != expected {
+		t.addFailure(newIPAddrFailure("range string "+result+" does not match expected "+expected, addr))
+	} else if addr.IsSequential() {
+		if rng, err := goip.ParseIPRangeString(result); err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error parsing "+result+": "+err.Error(), addr))
+		} else if !rng.Equal(addr.ToSequentialRange()) {
+			t.addFailure(newIPAddrFailure("parsed range "+rng.String()+" does not match", addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testParseIPRangeString expects an error when the expected lower and upper strings are empty
+func (t ipAddressRangeTester) testParseIPRangeString(str, expectedLower, expectedUpper string) {
+	rng, err := goip.ParseIPRangeString(str)
+	if expectedLower == "" {
+		if err == nil {
+			t.addFailure(newSeqRangeFailure("expected error parsing "+str, rng))
+		}
+	} else if err != nil {
+		t.addFailure(newFailure("unexpected error "+err.Error(), t.createAddress(str)))
+	} else if !rng.GetLower().Equal(t.createAddress(expectedLower).GetAddress()) ||
+		!rng.GetUpper().Equal(t.createAddress(expectedUpper).GetAddress()) {
+		t.addFailure(newSeqRangeFailure("parsed range does not match "+expectedLower+" to "+expectedUpper, rng))
+	}
+	t.incrementTestCount()
+}
+
+// testCondensedIPv4Notation expects an error when the expected string is empty
+func (t ipAddressRangeTester) testCondensedIPv4Notation(str string, joinedSegments int, expected string) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	result, err := addr.ToCondensedIPv4Notation(joinedSegments)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error joining "+strconv.Itoa(joinedSegments)+" segments, got "+result, addr.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr.ToIP()))
+	} else if result != expected {
+		t.addFailure(newIPAddrFailure("condensed string "+result+" does not match expected "+expected, addr.ToIP()))
+	} else if sectionResult, _ := addr.GetSection().ToCondensedIPv4Notation(joinedSegments); sectionResult != result {
+		t.addFailure(newIPAddrFailure("section condensed string "+sectionResult+" does not match "+result, addr.ToIP()))
+	} else if parsed := t.createInetAtonAddress(result).GetAddress(); !parsed.Equal(addr.ToIP()) {
+		t.addFailure(newIPAddrFailure("inet_aton string "+result+" parsed as "+parsed.String(), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testIsHost(str string, expected bool) {
+	addr := t.createAddress(str).GetAddress()
+	if addr.IsHost() != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("host mismatch, expected ", expected), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testNetip uses the zero IPAddress when the string is empty,
+// and expects no netip.Prefix when the expected prefix string is empty
+func (t ipAddressRangeTester) testNetip(str, expectedAddr, expectedPrefix string) {
+	addr := &goip.IPAddress{}
+	if str != "" {
+		addr = t.createAddress(str).GetAddress()
+	}
+
+	var expected netip.Addr
+	if expectedAddr != "" {
+		expected = netip.MustParseAddr(expectedAddr)
+	}
+	if result := addr.ToNetipAddr(); result != expected {
+		t.addFailure(newIPAddrFailure("netip address "+result.String()+" does not match expected "+expectedAddr, addr))
+	}
+
+	prefix, ok := addr.ToNetipPrefix()
+	if expectedPrefix == "" {
+		if ok || prefix.IsValid() {
+			t.addFailure(newIPAddrFailure("expected no netip prefix, got "+prefix.String(), addr))
+		}
+	} else if !ok || prefix != netip.MustParsePrefix(expectedPrefix) {
+		t.addFailure(newIPAddrFailure("netip prefix "+prefix.String()+" does not match expected "+expectedPrefix, addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testParseAddressList(input string, expected, expectedErrs []string) {
+	addrs, errs := goip.ParseAddressList(input)
+	if len(addrs) != len(expected) {
+		t.addFailure(newAddressItemFailure("parsed "+strconv.Itoa(len(addrs))+" addresses from "+input+", expected "+strconv.Itoa(len(expected)), nil))
+	} else {
+		for i, addr := range addrs {
+			if !addr.Equal(t.createAddress(expected[i]).GetAddress()) {
+				t.addFailure(newIPAddrFailure("parsed address from "+input+" does not match expected "+expected[i], addr))
+			}
+		}
+	}
+
+	if len(errs) != len(expectedErrs) {
+		t.addFailure(newAddressItemFailure("got "+strconv.Itoa(len(errs))+" errors from "+input+", expected "+strconv.Itoa(len(expectedErrs)), nil))
+	} else {
+		for _, str := range expectedErrs {
+			if errs[str] == nil {
+				t.addFailure(newAddressItemFailure("expected error for "+str+" in "+input, nil))
+			}
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testSubtractRanges(str, otherStr string, expected []string) {
+	rng := t.createAddress(str).GetAddress().ToSequentialRange()
+	other := t.createAddress(otherStr).GetAddress().ToSequentialRange()
+	diff := rng.Subtract(other)
+	if len(diff) != len(expected) {
+		t.addFailure(newSeqRangeFailure(fmt.Sprint("subtracting ", other, " gives ", diff, ", expected ", expected), rng))
+	} else {
+		for i, r := range diff {
+			if r.String() != expected[i] {
+				t.addFailure(newSeqRangeFailure(fmt.Sprint("subtracting ", other, " gives ", diff, ", expected ", expected), rng))
+				break
+			}
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testWildcardString(str string, expectedWildcard, expectedRange bool) {
+	addrStr := goip.NewIPAddressString(str)
+	if result := addrStr.IsWildcard(); result != expectedWildcard {
+		t.addFailure(newFailure(fmt.Sprint("wildcard is ", result, ", expected ", expectedWildcard), addrStr))
+	} else if result = addrStr.IsRange(); result != expectedRange {
+		t.addFailure(newFailure(fmt.Sprint("range is ", result, ", expected ", expectedRange), addrStr))
+	}
+	t.incrementTestCount()
+}
+
+// testReachabilityBitmap checks the bitmap and the blocks reconstructed from it, with a nil expected bitmap indicating an error is expected
+func (t ipAddressRangeTester) testReachabilityBitmap(str string, granularity goip.BitCount, expected []byte, expectedBlocks []string) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	bitmap, err := addr.ToReachabilityBitmap(granularity)
+	if expected == nil {
+		if err == nil {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("expected error for granularity ", granularity), addr.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error for bitmap: "+err.Error(), addr.ToIP()))
+	} else if !bytes.Equal(bitmap, expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprintf("bitmap %x does not match expected %x", bitmap, expected), addr.ToIP()))
+	} else if blocks, err := goip.NewIPv4AddressesFromReachabilityBitmap(addr, granularity, bitmap); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error for blocks from bitmap: "+err.Error(), addr.ToIP()))
+	} else if fmt.Sprint(blocks) != fmt.Sprint(expectedBlocks) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("blocks from bitmap ", blocks, " do not match expected ", expectedBlocks), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testInvalidReachabilityBitmap(str string, granularity goip.BitCount, bitmap []byte) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	if blocks, err := goip.NewIPv4AddressesFromReachabilityBitmap(addr, granularity, bitmap); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error for bitmap, got ", blocks), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testPrefixBlockForLength(str string, prefLen goip.BitCount, expected bool) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.IsPrefixBlockForLength(prefLen); result != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("prefix block for length ", prefLen, " is ", result, ", expected ", expected), addr))
+	} else if result && !addr.ContainsPrefixBlock(prefLen) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("prefix block for length ", prefLen, " inconsistent with ContainsPrefixBlock"), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testSplitToSequentialRanges(str string, expected []string) {
+	addr := t.createAddress(str).GetAddress()
+	ranges := addr.SplitToSequentialRanges()
+	if len(ranges) != len(expected) || addr.GetSequentialBlockCount().Cmp(big.NewInt(int64(len(expected)))) != 0 {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("sequential ranges ", ranges, " do not match expected ", expected), addr))
+	} else {
+		count := new(big.Int)
+		for i, rng := range ranges {
+			if rng.String() != expected[i] {
+				t.addFailure(newIPAddrFailure("sequential range "+rng.String()+" does not match expected "+expected[i], addr))
+				break
+			}
+			count.Add(count, rng.GetCount())
+		}
+		if count.Cmp(addr.GetCount()) != 0 {
+			t.addFailure(newIPAddrFailure("sequential ranges have count "+count.String(), addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testFullRange(str string, expected bool) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.IsFullRange(); result != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("full range is ", result, ", expected ", expected), addr))
+	} else if result != (addr.IncludesZero() && addr.IncludesMax()) {
+		t.addFailure(newIPAddrFailure("full range inconsistent with including zero and max", addr))
+	}
+	t.incrementTestCount()
+}
+
+// testSeqRangeAliases checks that the sequential ranges of the generic type can be used as the alias types, and vice versa
+func (t ipAddressRangeTester) testSeqRangeAliases(lowerStr, upperStr string) {
+	lower, upper := t.createAddress(lowerStr).GetAddress(), t.createAddress(upperStr).GetAddress()
+	var rng *goip.IPAddressSeqRange = lower.SpanWithRange(upper)
+	var generic *goip.SequentialRange[*goip.IPAddress] = rng
+	if !generic.GetLower().Equal(lower) || !generic.GetUpper().Equal(upper) {
+		t.addFailure(newSeqRangeFailure("range does not match "+lowerStr+" to "+upperStr, rng))
+	}
+	if lower.IsIPv4() {
+		var ipv4Rng *goip.IPv4AddressSeqRange = generic.ToIPv4()
+		var ipv4Generic *goip.SequentialRange[*goip.IPv4Address] = lower.ToIPv4().SpanWithRange(upper.ToIPv4())
+		if !ipv4Rng.Equal(ipv4Generic) {
+			t.addFailure(newSeqRangeFailure("IPv4 range does not match "+ipv4Generic.String(), rng))
+		}
+	} else {
+		var ipv6Rng *goip.IPv6AddressSeqRange = generic.ToIPv6()
+		var ipv6Generic *goip.SequentialRange[*goip.IPv6Address] = lower.ToIPv6().SpanWithRange(upper.ToIPv6())
+		if !ipv6Rng.Equal(ipv6Generic) {
+			t.addFailure(newSeqRangeFailure("IPv6 range does not match "+ipv6Generic.String(), rng))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testContainsString(str, otherStr string, expected, expectedErr bool) {
+	addrStr, other := goip.NewIPAddressString(str), goip.NewIPAddressString(otherStr)
+	if result, err := addrStr.ContainsString(other); (err != nil) != expectedErr {
+		t.addFailure(newFailure(fmt.Sprint("containment error for ", otherStr, " is ", err, ", expected error ", expectedErr), addrStr))
+	} else if result != expected {
+		t.addFailure(newFailure(fmt.Sprint("containment of ", otherStr, " is ", result, ", expected ", expected), addrStr))
+	} else if result != addrStr.Contains(other) {
+		t.addFailure(newFailure("containment of "+otherStr+" inconsistent with Contains", addrStr))
+	}
+	if otherAddr := other.GetAddress(); otherAddr != nil || addrStr.IsValid() {
+		if result, err := addrStr.ContainsAddress(otherAddr); (err != nil) != !addrStr.IsValid() {
+			t.addFailure(newFailure(fmt.Sprint("address containment error for ", otherStr, " is ", err), addrStr))
+		} else if result != expected {
+			t.addFailure(newFailure(fmt.Sprint("address containment of ", otherStr, " is ", result, ", expected ", expected), addrStr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testJSON checks the JSON of the address and the round trip, both directly and with the address as a struct field
+func (t ipAddressRangeTester) testJSON(str, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	data, err := json.Marshal(addr)
+	if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error marshalling: "+err.Error(), addr))
+	} else if string(data) != expected {
+		t.addFailure(newIPAddrFailure("JSON "+string(data)+" does not match expected "+expected, addr))
+	} else {
+		var back goip.IPAddress
+		if err = json.Unmarshal(data, &back); err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error unmarshalling "+string(data)+": "+err.Error(), addr))
+		} else if !back.Equal(addr) || !back.GetPrefixLen().Equal(addr.GetPrefixLen()) || back.String() != addr.String() {
+			t.addFailure(newIPAddrFailure("JSON round trip produced "+back.String(), addr))
+		}
+
+		type wrapper struct {
+			Addr  *goip.IPAddress
+			IPv4  *goip.IPv4Address `json:",omitempty"`
+			IPv6  *goip.IPv6Address `json:",omitempty"`
+			Empty *goip.IPAddress
+		}
+		wrapped := wrapper{Addr: addr, IPv4: addr.ToIPv4(), IPv6: addr.ToIPv6()}
+		var wrappedBack wrapper
+		if data, err = json.Marshal(wrapped); err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error marshalling wrapped address: "+err.Error(), addr))
+		} else if err = json.Unmarshal(data, &wrappedBack); err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error unmarshalling "+string(data)+": "+err.Error(), addr))
+		} else if !wrappedBack.Addr.Equal(addr) || wrappedBack.Empty != nil ||
+			(addr.IsIPv4() && !wrappedBack.IPv4.Equal(addr.ToIPv4())) || (addr.IsIPv6() && !wrappedBack.IPv6.Equal(addr.ToIPv6())) {
+			t.addFailure(newIPAddrFailure("wrapped JSON round trip produced "+string(data), addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testInvalidJSON expects an error unmarshalling the JSON to an address of the given version, or to an IPAddress when the version is indeterminate
+func (t ipAddressRangeTester) testInvalidJSON(data string, version goip.IPVersion) {
+	var err error
+	if version.IsIPv4() {
+		err = json.Unmarshal([]byte(data), &goip.IPv4Address{})
+	} else if version.IsIPv6() {
+		err = json.Unmarshal([]byte(data), &goip.IPv6Address{})
+	} else {
+		err = json.Unmarshal([]byte(data), &goip.IPAddress{})
+	}
+	if err == nil {
+		t.addFailure(newIPAddrFailure("expected error unmarshalling "+data, nil))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testRangeJSON(lowerStr, upperStr, expected string) {
+	rng := t.createAddress(lowerStr).GetAddress().SpanWithRange(t.createAddress(upperStr).GetAddress())
+	data, err := json.Marshal(rng)
+	if err != nil {
+		t.addFailure(newSeqRangeFailure("unexpected error marshalling: "+err.Error(), rng))
+	} else if string(data) != expected {
+		t.addFailure(newSeqRangeFailure("JSON "+string(data)+" does not match expected "+expected, rng))
+	} else {
+		var back goip.IPAddressSeqRange
+		if err = json.Unmarshal(data, &back); err != nil {
+			t.addFailure(newSeqRangeFailure("unexpected error unmarshalling "+string(data)+": "+err.Error(), rng))
+		} else if !back.Equal(rng) {
+			t.addFailure(newSeqRangeFailure("JSON round trip produced "+back.String(), rng))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testInvalidRangeJSON(data string) {
+	if err := json.Unmarshal([]byte(data), &goip.IPAddressSeqRange{}); err == nil {
+		t.addFailure(newSeqRangeFailure("expected error unmarshalling "+data, nil))
+	}
+	t.incrementTestCount()
+}
+
+// testSQL checks the database value of the address and scanning it back from each of the supported source types
+func (t ipAddressRangeTester) testSQL(str, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	value, err := addr.Value()
+	if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error for database value: "+err.Error(), addr))
+	} else if value != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("database value ", value, " does not match expected ", expected), addr))
+	} else {
+		sources := []interface{}{value, []byte(expected)}
+		if !addr.IsMultiple() && !addr.IsPrefixed() && !addr.ToIPv6().HasZone() {
+			sources = append(sources, addr.GetNetIP())
+		}
+		for _, src := range sources {
+			var back goip.IPAddress
+			if err = back.Scan(src); err != nil {
+				t.addFailure(newIPAddrFailure(fmt.Sprintf("unexpected error scanning %T: %v", src, err), addr))
+			} else if !back.Equal(addr) || back.String() != addr.String() {
+				t.addFailure(newIPAddrFailure(fmt.Sprintf("scanning %T produced %v", src, back.String()), addr))
+			}
+		}
+		if addr.IsIPv4() {
+			var back goip.IPv4Address
+			if err = back.Scan(value); err != nil || !back.Equal(addr.ToIPv4()) {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("scanning IPv4 produced ", back.String(), " ", err), addr))
+			} else if err = back.Scan(addr.GetLower().GetNetIP().To16()); err != nil || !back.Equal(addr.GetLower().ToIPv4()) {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("scanning IPv4-mapped net.IP produced ", back.String(), " ", err), addr))
+			} else if err = new(goip.IPv6Address).Scan(value); err == nil {
+				t.addFailure(newIPAddrFailure("expected error scanning IPv4 value as IPv6", addr))
+			}
+		} else {
+			var back goip.IPv6Address
+			if err = back.Scan(value); err != nil || !back.Equal(addr.ToIPv6()) {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("scanning IPv6 produced ", back.String(), " ", err), addr))
+			} else if err = new(goip.IPv4Address).Scan(value); err == nil {
+				t.addFailure(newIPAddrFailure("expected error scanning IPv6 value as IPv4", addr))
+			}
+		}
+	}
+
+	var null goip.IPAddress
+	if value, err = null.Value(); value != nil || err != nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("zero address has database value ", value), nil))
+	} else if err = addr.Scan(nil); err != nil || addr.IsIPv4() || addr.IsIPv6() {
+		t.addFailure(newIPAddrFailure("scanning NULL did not produce the zero address", addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testInvalidSQL(src interface{}) {
+	if err := new(goip.IPAddress).Scan(src); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error scanning ", src), nil))
+	} else if err = new(goip.IPv4Address).Scan(src); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error scanning IPv4 ", src), nil))
+	} else if err = new(goip.IPv6Address).Scan(src); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error scanning IPv6 ", src), nil))
+	}
+	t.incrementTestCount()
+}
+
+// testSQLParseError checks that scanning a string which cannot be parsed results in an AddressValueError
+func (t ipAddressRangeTester) testSQLParseError(src string) {
+	for _, scanner := range []sql.Scanner{new(goip.IPAddress), new(goip.IPv4Address), new(goip.IPv6Address)} {
+		err := scanner.Scan(src)
+		if valueErr, ok := err.(address_error.AddressValueError); !ok || valueErr.GetKey() != "ipaddress.error.scan.parse" {
+			t.addFailure(newIPAddrFailure(fmt.Sprintf("expected value error scanning %s into %T, got %v", src, scanner, err), nil))
+		} else if !strings.Contains(err.Error(), src) {
+			t.addFailure(newIPAddrFailure(fmt.Sprintf("value error scanning %s into %T does not include the parsing error: %v", src, scanner, err), nil))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testText checks the text of the address matches the expected canonical string,
+// and that the text can be unmarshalled to the same address, both with and without the IP version
+func (t ipAddressRangeTester) testText(str, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	text, err := addr.MarshalText()
+	if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error marshalling text: "+err.Error(), addr))
+	} else if string(text) != expected {
+		t.addFailure(newIPAddrFailure("text "+string(text)+" does not match expected "+expected, addr))
+	} else {
+		var back goip.IPAddress
+		if err = back.UnmarshalText(text); err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error unmarshalling text "+string(text)+": "+err.Error(), addr))
+		} else if !back.Equal(addr) || back.String() != addr.String() {
+			t.addFailure(newIPAddrFailure("text round trip produced "+back.String(), addr))
+		}
+		if addr.IsIPv4() {
+			var back goip.IPv4Address
+			if err = back.UnmarshalText(text); err != nil || !back.Equal(addr.ToIPv4()) {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("IPv4 text round trip produced ", back.String(), " ", err), addr))
+			} else if err = new(goip.IPv6Address).UnmarshalText(text); err == nil {
+				t.addFailure(newIPAddrFailure("expected error unmarshalling IPv4 text as IPv6", addr))
+			}
+		} else {
+			var back goip.IPv6Address
+			if err = back.UnmarshalText(text); err != nil || !back.Equal(addr.ToIPv6()) || back.GetZone() != addr.ToIPv6().GetZone() {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("IPv6 text round trip produced ", back.String(), " ", err), addr))
+			} else if err = new(goip.IPv4Address).UnmarshalText(text); err == nil {
+				t.addFailure(newIPAddrFailure("expected error unmarshalling IPv6 text as IPv4", addr))
+			}
+		}
+	}
+
+	var null goip.IPAddress
+	if err = addr.UnmarshalText(nil); err != nil || !addr.Equal(&null) {
+		t.addFailure(newIPAddrFailure("unmarshalling empty text did not produce the zero address", addr))
+	} else if text, err = (*goip.IPAddress)(nil).MarshalText(); err != nil || len(text) != 0 {
+		t.addFailure(newIPAddrFailure("nil address did not produce empty text", nil))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testInvalidText(str string) {
+	if err := new(goip.IPAddress).UnmarshalText([]byte(str)); err == nil {
+		t.addFailure(newIPAddrFailure("expected error unmarshalling text "+str, nil))
+	} else if err = new(goip.IPv4Address).UnmarshalText([]byte(str)); err == nil {
+		t.addFailure(newIPAddrFailure("expected error unmarshalling IPv4 text "+str, nil))
+	} else if err = new(goip.IPv6Address).UnmarshalText([]byte(str)); err == nil {
+		t.addFailure(newIPAddrFailure("expected error unmarshalling IPv6 text "+str, nil))
+	}
+	t.incrementTestCount()
+}
+
+// testStringText checks that address strings and host names marshal to their original strings,
+// and that unmarshalling invalid text leaves them unchanged
+func (t ipAddressRangeTester) testStringText(str string) {
+	addrStr := goip.NewIPAddressString(str)
+	host := goip.NewHostName(str)
+	var backStr goip.IPAddressString
+	var backHost goip.HostName
+	if text, err := addrStr.MarshalText(); err != nil || string(text) != str {
+		t.addFailure(newFailure(fmt.Sprint("text ", string(text), " does not match original ", str, " ", err), addrStr))
+	} else if err = backStr.UnmarshalText(text); addrStr.IsValid() && (err != nil || !backStr.Equal(addrStr)) {
+		t.addFailure(newFailure(fmt.Sprint("text round trip produced ", backStr.String(), " ", err), addrStr))
+	}
+	if text, err := host.MarshalText(); err != nil || string(text) != str {
+		t.addFailure(newHostFailure(fmt.Sprint("text ", string(text), " does not match original ", str, " ", err), host))
+	} else if err = backHost.UnmarshalText(text); err != nil || !backHost.Equal(host) {
+		t.addFailure(newHostFailure(fmt.Sprint("text round trip produced ", backHost.String(), " ", err), host))
+	} else if err = backHost.UnmarshalText([]byte("a..b")); err == nil || !backHost.Equal(host) {
+		t.addFailure(newHostFailure("invalid text replaced the host name", host))
+	}
+	t.incrementTestCount()
+}
+
+// testBinary checks the binary representation of the address matches the expected bytes, when not nil,
+// and that the address survives binary and gob round trips with its prefix length and zone
+func (t ipAddressRangeTester) testBinary(str string, expected []byte) {
+	addr := t.createAddress(str).GetAddress()
+	data, err := addr.MarshalBinary()
+	if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error marshalling binary: "+err.Error(), addr))
+	} else if expected != nil && !bytes.Equal(data, expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("binary ", data, " does not match expected ", expected), addr))
+	} else {
+		var back goip.IPAddress
+		if err = back.UnmarshalBinary(data); err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error unmarshalling binary: "+err.Error(), addr))
+		} else if !back.Equal(addr) || back.String() != addr.String() {
+			t.addFailure(newIPAddrFailure("binary round trip produced "+back.String(), addr))
+		}
+		if addr.IsIPv4() {
+			var back goip.IPv4Address
+			if err = back.UnmarshalBinary(data); err != nil || back.String() != addr.String() {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("IPv4 binary round trip produced ", back.String(), " ", err), addr))
+			} else if err = new(goip.IPv6Address).UnmarshalBinary(data); err == nil {
+				t.addFailure(newIPAddrFailure("expected error unmarshalling IPv4 binary as IPv6", addr))
+			}
+		} else {
+			var back goip.IPv6Address
+			if err = back.UnmarshalBinary(data); err != nil || back.String() != addr.String() {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("IPv6 binary round trip produced ", back.String(), " ", err), addr))
+			} else if err = new(goip.IPv4Address).UnmarshalBinary(data); err == nil {
+				t.addFailure(newIPAddrFailure("expected error unmarshalling IPv6 binary as IPv4", addr))
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	var back goip.IPAddress
+	if err = gob.NewEncoder(&buf).Encode(addr); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error encoding gob: "+err.Error(), addr))
+	} else if err = gob.NewDecoder(&buf).Decode(&back); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error decoding gob: "+err.Error(), addr))
+	} else if back.String() != addr.String() {
+		t.addFailure(newIPAddrFailure("gob round trip produced "+back.String(), addr))
+	}
+
+	var null goip.IPAddress
+	if data, err = null.MarshalBinary(); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error marshalling the zero address: "+err.Error(), nil))
+	} else if err = addr.UnmarshalBinary(data); err != nil || !addr.Equal(&null) {
+		t.addFailure(newIPAddrFailure("binary round trip of the zero address produced "+addr.String(), nil))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testInvalidBinary(data []byte) {
+	if err := new(goip.IPAddress).UnmarshalBinary(data); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error unmarshalling binary ", data), nil))
+	} else if err = new(goip.IPv4Address).UnmarshalBinary(data); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error unmarshalling IPv4 binary ", data), nil))
+	}
+	t.incrementTestCount()
+}
+
+// testContextIterator checks that a context iterator matches the wrapped iterator until the context is cancelled,
+// which happens after cancelAfter elements, and that nothing is produced after the cancellation
+func (t ipAddressRangeTester) testContextIterator(str string, cancelAfter int) {
+	addr := t.createAddress(str).GetAddress()
+	if addr.GetCount().Cmp(big.NewInt(1024)) <= 0 {
+		var expected, actual []*goip.IPAddress
+		for iter := addr.Iterator(); iter.HasNext(); {
+			expected = append(expected, iter.Next())
+		}
+		for iter := addr.IteratorWithContext(context.Background()); iter.HasNext(); {
+			actual = append(actual, iter.Next())
+		}
+		if len(actual) != len(expected) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("context iterator produced ", len(actual), " addresses, expected ", len(expected)), addr))
+		} else {
+			for i := range expected {
+				if !actual[i].Equal(expected[i]) {
+					t.addFailure(newIPAddrFailure(fmt.Sprint("context iterator produced ", actual[i], " at ", i, ", expected ", expected[i]), addr))
+					break
+				}
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	iter := addr.IteratorWithContext(ctx)
+	expectedIter := addr.Iterator()
+	for i := 0; i < cancelAfter; i++ {
+		if next, expected := iter.Next(), expectedIter.Next(); next == nil || !next.Equal(expected) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("context iterator produced ", next, " before cancellation, expected ", expected), addr))
+		}
+	}
+	cancel()
+	if iter.HasNext() {
+		t.addFailure(newIPAddrFailure("context iterator has more after cancellation", addr))
+	} else if next := iter.Next(); next != nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("context iterator produced ", next, " after cancellation"), addr))
+	} else if addr.PrefixBlockIteratorWithContext(ctx).HasNext() || addr.SequentialBlockIteratorWithContext(ctx).HasNext() || addr.BlockIteratorWithContext(ctx, 1).HasNext() {
+		t.addFailure(newIPAddrFailure("block iterators with a cancelled context have elements", addr))
+	}
+
+	rng := addr.ToSequentialRange()
+	if rng.IteratorWithContext(ctx).HasNext() || rng.PrefixBlockIteratorWithContext(ctx, addr.GetBitCount()).HasNext() || rng.PrefixIteratorWithContext(ctx, 8).HasNext() {
+		t.addFailure(newSeqRangeFailure("range iterators with a cancelled context have elements", rng))
+	} else if !rng.IteratorWithContext(context.Background()).HasNext() {
+		t.addFailure(newSeqRangeFailure("range iterator with an active context is empty", rng))
+	}
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	if addr.ToIPv4() != nil && addr.ToIPv4().IteratorWithContext(expired).HasNext() {
+		t.addFailure(newIPAddrFailure("IPv4 iterator with an expired deadline has elements", addr))
+	} else if addr.ToIPv6() != nil && addr.ToIPv6().IteratorWithContext(expired).HasNext() {
+		t.addFailure(newIPAddrFailure("IPv6 iterator with an expired deadline has elements", addr))
+	}
+	t.incrementTestCount()
+}
+
+// testContextIteratorCancelledConcurrently cancels the context from a second goroutine
+// while the first goroutine drains the context iterator of a large subnet.
+func (t ipAddressRangeTester) testContextIteratorCancelledConcurrently(str string) {
+	const cancelAfter = 1000
+	addr := t.createAddress(str).GetAddress()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	iter := addr.IteratorWithContext(ctx)
+	started := make(chan struct{})
+	done := make(chan int)
+	go func() {
+		count := 0
+		for iter.HasNext() {
+			if iter.Next() != nil {
+				count++
+				if count == cancelAfter {
+					close(started)
+				}
+			}
+		}
+		done <- count
+	}()
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	select {
+	case count := <-done:
+		if count < cancelAfter {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("context iterator ended after ", count, " addresses, before cancellation"), addr))
+		} else if iter.HasNext() {
+			t.addFailure(newIPAddrFailure("context iterator has more after concurrent cancellation", addr))
+		} else if next := iter.Next(); next != nil {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("context iterator produced ", next, " after concurrent cancellation"), addr))
+		}
+	case <-time.After(time.Minute):
+		t.addFailure(newIPAddrFailure("context iterator did not end after concurrent cancellation", addr))
+	}
+	t.incrementTestCount()
+}
+
+// testReverseIterators checks that each reverse iterator produces the elements of the corresponding forward iterator in reverse order
+func (t ipAddressRangeTester) testReverseIterators(str string) {
+	addr := t.createAddress(str).GetAddress()
+	t.checkReversed(addr, "iterator", collectStrings(addr.Iterator()), collectStrings(addr.ReverseIterator()))
+	t.checkReversed(addr, "sequential block iterator", collectStrings(addr.SequentialBlockIterator()), collectStrings(addr.SequentialBlockReverseIterator()))
+	if addr.IsPrefixed() {
+		t.checkReversed(addr, "prefix block iterator", collectStrings(addr.PrefixBlockIterator()), collectStrings(addr.PrefixBlockReverseIterator()))
+	}
+	if ipv4 := addr.ToIPv4(); ipv4 != nil {
+		t.checkReversed(addr, "IPv4 iterator", collectStrings(ipv4.Iterator()), collectStrings(ipv4.ReverseIterator()))
+	} else if ipv6 := addr.ToIPv6(); ipv6 != nil {
+		t.checkReversed(addr, "IPv6 iterator", collectStrings(ipv6.Iterator()), collectStrings(ipv6.ReverseIterator()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testReverseRangeIterator(lower, upper string) {
+	rng := t.createAddress(lower).GetAddress().SpanWithRange(t.createAddress(upper).GetAddress())
+	forward, reverse := collectStrings(rng.Iterator()), collectStrings(rng.ReverseIterator())
+	if !isReversed(forward, reverse) {
+		t.addFailure(newSeqRangeFailure(fmt.Sprint("reverse iterator produced ", reverse, ", expected the reverse of ", forward), rng))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) checkReversed(addr *goip.IPAddress, name string, forward, reverse []string) {
+	if !isReversed(forward, reverse) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("reverse ", name, " produced ", reverse, ", expected the reverse of ", forward), addr))
+	}
+}
+
+func collectStrings[T fmt.Stringer](iter goip.Iterator[T]) (result []string) {
+	for iter.HasNext() {
+		result = append(result, iter.Next().String())
+	}
+	return
+}
+
+func isReversed(forward, reverse []string) bool {
+	if len(forward) != len(reverse) || len(forward) == 0 {
+		return false
+	}
+	for i, str := range forward {
+		if reverse[len(reverse)-1-i] != str {
+			return false
+		}
+	}
+	return true
+}
+
+// testStridedIterator checks the strided iterator produces every stride'th address of the iterator,
+// the expected count of addresses, and the expected last address
+func (t ipAddressRangeTester) testStridedIterator(str string, stride int64, expectedCount int, expectedLast string) {
+	addr := t.createAddress(str).GetAddress()
+	iter, err := addr.StridedIterator(stride)
+	if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error for stride: "+err.Error(), addr))
+		return
+	}
+
+	var strided []*goip.IPAddress
+	for iter.HasNext() {
+		strided = append(strided, iter.Next())
+	}
+	if len(strided) != expectedCount {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("strided iterator produced ", len(strided), " addresses, expected ", expectedCount), addr))
+	} else if last := strided[len(strided)-1]; !last.Equal(t.createAddress(expectedLast).GetAddress()) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("last strided address ", last, " does not match expected ", expectedLast), addr))
+	} else if iter.Next() != nil {
+		t.addFailure(newIPAddrFailure("strided iterator produced an address when done", addr))
+	} else if addr.GetCount().Cmp(big.NewInt(1<<16)) <= 0 {
+		i := 0
+		for all := addr.Iterator(); all.HasNext(); i++ {
+			next := all.Next()
+			if int64(i)%stride == 0 && !next.Equal(strided[int64(i)/stride]) {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("strided address ", strided[int64(i)/stride], " does not match ", next, " at ", i), addr))
+				break
+			}
+		}
+	}
+
+	if ipv4 := addr.ToIPv4(); ipv4 != nil {
+		if ipv4Iter, err := ipv4.StridedIterator(stride); err != nil || !ipv4Iter.Next().Equal(strided[0]) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("IPv4 strided iterator does not start with ", strided[0], " ", err), addr))
+		}
+	} else if ipv6Iter, err := addr.ToIPv6().StridedIterator(stride); err != nil || !ipv6Iter.Next().Equal(strided[0]) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("IPv6 strided iterator does not start with ", strided[0], " ", err), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testInvalidStride(str string, stride int64) {
+	addr := t.createAddress(str).GetAddress()
+	if _, err := addr.StridedIterator(stride); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error for stride ", stride), addr))
+	} else if _, err = addr.ToIPv4().StridedIterator(stride); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error for IPv4 stride ", stride), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testLargeStride checks the first few addresses of a strided iteration through a subnet too large to iterate,
+// continuing past the range of int64 for sequential subnets and ending there for subnets that are not sequential
+func (t ipAddressRangeTester) testLargeStride(str string, stride int64) {
+	addr := t.createAddress(str).GetAddress()
+	iter, err := addr.StridedIterator(stride)
+	if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error for stride: "+err.Error(), addr))
+		return
+	}
+
+	lower := addr.GetLower().WithoutPrefixLen()
+	step := big.NewInt(stride)
+	expected := new(big.Int)
+	i := 0
+	for ; i < 4 && iter.HasNext(); i++ {
+		next := iter.Next()
+		if addr.IsSequential() && next.GetValue().Cmp(new(big.Int).Add(lower.GetValue(), expected)) != 0 {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("strided address ", next, " at ", i, " does not match expected offset ", expected), addr))
+		} else if !addr.Contains(next) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("strided address ", next, " is not in the subnet"), addr))
+		}
+		expected.Add(expected, step)
+	}
+	if addr.IsSequential() != (i == 4) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("strided iterator produced ", i, " addresses"), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testRandomAddress checks that random addresses are individual addresses of the subnet, with the prefix length and zone of the subnet,
+// and for subnets small enough to count every address, that a chi-squared test does not reject the uniformity of a seeded sequence of random addresses
+func (t ipAddressRangeTester) testRandomAddress(str string) {
+	addr := t.createAddress(str).GetAddress()
+	for i := 0; i < 10; i++ {
+		random := addr.RandomAddress()
+		if random.IsMultiple() || !addr.Contains(random) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("random address ", random, " is not an address of the subnet"), addr))
+			break
+		} else if !random.GetPrefixLen().Equal(addr.GetPrefixLen()) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("random address ", random, " does not have the prefix length of the subnet"), addr))
+			break
+		} else if addr.IsIPv6() && random.ToIPv6().GetZone() != addr.ToIPv6().GetZone() {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("random address ", random, " does not have the zone of the subnet"), addr))
+			break
+		}
+	}
+	if !addr.IsMultiple() && !addr.RandomAddress().Equal(addr) {
+		t.addFailure(newIPAddrFailure("random address of an individual address is a different address", addr))
+	} else if first, second := addr.RandomAddressWithRand(rand.New(rand.NewSource(1))), addr.RandomAddressWithRand(rand.New(rand.NewSource(1))); !first.Equal(second) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("random addresses ", first, " and ", second, " from the same seed do not match"), addr))
+	}
+
+	count := addr.GetCount()
+	if count.Cmp(big.NewInt(1)) > 0 && count.Cmp(big.NewInt(1024)) <= 0 {
+		cells := int(count.Int64())
+		samples := cells * 200
+		counts := make(map[string]int, cells)
+		r := rand.New(rand.NewSource(int64(cells)))
+		for i := 0; i < samples; i++ {
+			counts[addr.RandomAddressWithRand(r).WithoutPrefixLen().String()]++
+		}
+
+		expected := float64(samples) / float64(cells)
+		var chiSquared float64
+		for iter := addr.WithoutPrefixLen().Iterator(); iter.HasNext(); {
+			diff := float64(counts[iter.Next().String()]) - expected
+			chiSquared += diff * diff / expected
+		}
+		// the critical value for a significance level of 0.001, using the Wilson–Hilferty approximation of the chi-squared distribution
+		degrees := float64(cells - 1)
+		critical := degrees * math.Pow(1-2/(9*degrees)+3.09*math.Sqrt(2/(9*degrees)), 3)
+		if len(counts) != cells {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("random addresses covered ", len(counts), " addresses, expected ", cells), addr))
+		} else if chiSquared > critical {
+			t.addFailure(newIPAddrFailure(fmt.Sprintf("chi-squared statistic %.2f of random addresses exceeds %.2f", chiSquared, critical), addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testSplitIntoN checks splitting the block into n blocks produces the expected blocks, with nil meaning an error is expected
+func (t ipAddressRangeTester) testSplitIntoN(str string, n int, expected []string) {
+	addr := t.createAddress(str).GetAddress()
+	var blocks []string
+	var err error
+	if addr.IsIPv4() {
+		var res []*goip.IPv4Address
+		res, err = addr.ToIPv4().SplitIntoN(n)
+		blocks = cloneTo(res, (*goip.IPv4Address).String)
+	} else {
+		var res []*goip.IPv6Address
+		res, err = addr.ToIPv6().SplitIntoN(n)
+		blocks = cloneTo(res, (*goip.IPv6Address).String)
+	}
+	t.checkSplit(addr, fmt.Sprint("splitting into ", n), blocks, err, expected)
+}
+
+// testSplitIntoPrefixLength checks splitting the block into the blocks of the given prefix length produces the expected blocks,
+// with nil meaning an error is expected
+func (t ipAddressRangeTester) testSplitIntoPrefixLength(str string, prefixLen goip.BitCount, expected []string) {
+	addr := t.createAddress(str).GetAddress()
+	var blocks []string
+	var err error
+	if addr.IsIPv4() {
+		var res []*goip.IPv4Address
+		res, err = addr.ToIPv4().SplitIntoPrefixLength(prefixLen)
+		blocks = cloneTo(res, (*goip.IPv4Address).String)
+	} else {
+		var res []*goip.IPv6Address
+		res, err = addr.ToIPv6().SplitIntoPrefixLength(prefixLen)
+		blocks = cloneTo(res, (*goip.IPv6Address).String)
+	}
+	t.checkSplit(addr, fmt.Sprint("splitting into prefix length ", prefixLen), blocks, err, expected)
+}
+
+func (t ipAddressRangeTester) checkSplit(addr *goip.IPAddress, op string, blocks []string, err error, expected []string) {
+	if expected == nil {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error "+op+", got "+fmt.Sprint(blocks), addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+op+": "+err.Error(), addr))
+	} else if fmt.Sprint(blocks) != fmt.Sprint(expected) {
+		t.addFailure(newIPAddrFailure(op+" produced "+fmt.Sprint(blocks)+", expected "+fmt.Sprint(expected), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testOverlaps(oneStr, twoStr string, expected bool) {
+	one, two := t.createAddress(oneStr).GetAddress(), t.createAddress(twoStr).GetAddress()
+	if one.Overlaps(two) != expected || two.Overlaps(one) != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("overlap with ", two, " is not ", expected), one))
+	} else if expected != (one.Intersect(two) != nil) && one.GetIPVersion() == two.GetIPVersion() {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("overlap with ", two, " does not match the intersection ", one.Intersect(two)), one))
+	} else if one.OverlapsAny([]*goip.IPAddress{nil, two}) != expected || one.OverlapsAny(nil) || one.Overlaps(nil) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("overlap with any of ", two, " is not ", expected), one))
+	}
+	if one.IsIPv4() && two.IsIPv4() && one.ToIPv4().Overlaps(two.ToIPv4()) != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("IPv4 overlap with ", two, " is not ", expected), one))
+	} else if one.IsIPv6() && two.IsIPv6() && one.ToIPv6().Overlaps(two.ToIPv6()) != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("IPv6 overlap with ", two, " is not ", expected), one))
+	}
+	t.incrementTestCount()
+}
+
+// testFindOverlaps checks the overlapping pairs of the given subnets, with empty strings for nil elements
+func (t ipAddressRangeTester) testFindOverlaps(strs []string, expected [][2]int) {
+	addrs := make([]*goip.IPAddress, len(strs))
+	for i, str := range strs {
+		if str != "" {
+			addrs[i] = t.createAddress(str).GetAddress()
+		}
+	}
+	if pairs := goip.FindOverlaps(addrs); fmt.Sprint(pairs) != fmt.Sprint(expected) && (len(pairs) > 0 || len(expected) > 0) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("overlapping pairs ", pairs, " do not match expected ", expected, " for ", strs), nil))
+	}
+	t.incrementTestCount()
+}
+
+// testGapTo checks the gap between the two subnets matches the expected blocks, with nil meaning no gap
+func (t ipAddressRangeTester) testGapTo(oneStr, twoStr string, expected []string) {
+	one, two := t.createAddress(oneStr).GetAddress(), t.createAddress(twoStr).GetAddress()
+	gaps := cloneTo(one.GapTo(two), (*goip.IPAddress).String)
+	if fmt.Sprint(gaps) != fmt.Sprint(expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("gap to ", two, " is ", gaps, ", expected ", expected), one))
+	} else if one.IsIPv4() && two.IsIPv4() {
+		if ipv4Gaps := cloneTo(one.ToIPv4().GapTo(two.ToIPv4()), (*goip.IPv4Address).String); fmt.Sprint(ipv4Gaps) != fmt.Sprint(expected) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("IPv4 gap to ", two, " is ", ipv4Gaps, ", expected ", expected), one))
+		}
+	} else if one.IsIPv6() && two.IsIPv6() {
+		if ipv6Gaps := cloneTo(one.ToIPv6().GapTo(two.ToIPv6()), (*goip.IPv6Address).String); fmt.Sprint(ipv6Gaps) != fmt.Sprint(expected) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("IPv6 gap to ", two, " is ", ipv6Gaps, ", expected ", expected), one))
+		}
+	}
+	if reverse := two.GapTo(one); len(expected) > 0 && reverse != nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("gap from ", two, " is ", reverse, ", expected none"), one))
+	}
+	t.incrementTestCount()
+}
+
+// testFindGaps checks the gaps between the given sorted subnets, with empty strings for nil elements
+func (t ipAddressRangeTester) testFindGaps(strs []string, expected []string) {
+	addrs := make([]*goip.IPAddress, len(strs))
+	for i, str := range strs {
+		if str != "" {
+			addrs[i] = t.createAddress(str).GetAddress()
+		}
+	}
+	if gaps := cloneTo(goip.FindGaps(addrs), (*goip.IPAddress).String); fmt.Sprint(gaps) != fmt.Sprint(expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("gaps ", gaps, " do not match expected ", expected, " for ", strs), nil))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) createRangeSet(strs []string) *goip.IPRangeSet {
+	set := &goip.IPRangeSet{}
+	for _, str := range strs {
+		set.Add(t.createAddress(str).GetAddress().ToSequentialRange())
+	}
+	return set
+}
+
+// testRangeSetIdentities checks the set identities of the union, intersection and difference of the range sets with the given subnets,
+// along with the counts and prefix blocks of the sets
+func (t ipAddressRangeTester) testRangeSetIdentities(oneStrs, twoStrs, threeStrs []string) {
+	a, b, c := t.createRangeSet(oneStrs), t.createRangeSet(twoStrs), t.createRangeSet(threeStrs)
+	empty := goip.NewRangeSet[*goip.IPAddress]()
+	aStr := a.String()
+	check := func(name string, one, two *goip.IPRangeSet) {
+		if one.String() != two.String() {
+			t.addFailure(newIPAddrFailure(fmt.Sprint(name, " does not hold for ", a, ", ", b, ", ", c, ": ", one, " != ", two), nil))
+		}
+	}
+	check("A ∪ A = A", a.Union(a), a)
+	check("A ∩ A = A", a.Intersect(a), a)
+	check("A − A = ∅", a.Subtract(a), empty)
+	check("A ∪ ∅ = A", a.Union(empty), a)
+	check("A ∩ ∅ = ∅", a.Intersect(empty), empty)
+	check("A − ∅ = A", a.Subtract(empty), a)
+	check("∅ − A = ∅", empty.Subtract(a), empty)
+	check("A ∪ B = B ∪ A", a.Union(b), b.Union(a))
+	check("A ∩ B = B ∩ A", a.Intersect(b), b.Intersect(a))
+	check("(A − B) ∪ (A ∩ B) = A", a.Subtract(b).Union(a.Intersect(b)), a)
+	check("(A − B) ∩ B = ∅", a.Subtract(b).Intersect(b), empty)
+	check("A ∩ (B ∪ C) = (A ∩ B) ∪ (A ∩ C)", a.Intersect(b.Union(c)), a.Intersect(b).Union(a.Intersect(c)))
+	check("A − (B ∪ C) = (A − B) ∩ (A − C)", a.Subtract(b.Union(c)), a.Subtract(b).Intersect(a.Subtract(c)))
+	check("A − (B ∩ C) = (A − B) ∪ (A − C)", a.Subtract(b.Intersect(c)), a.Subtract(b).Union(a.Subtract(c)))
+	check("(A ∪ B) ∪ C = A ∪ (B ∪ C)", a.Union(b).Union(c), a.Union(b.Union(c)))
+	check("(A ∩ B) ∩ C = A ∩ (B ∩ C)", a.Intersect(b).Intersect(c), a.Intersect(b.Intersect(c)))
+	if a.String() != aStr {
+		t.addFailure(newIPAddrFailure("set operations modified the set "+aStr+" to "+a.String(), nil))
+	}
+
+	union := new(big.Int).Add(a.Count(), b.Count())
+	if union.Sub(union, a.Intersect(b).Count()).Cmp(a.Union(b).Count()) != 0 {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("count of the union ", a.Union(b).Count(), " does not match ", union), nil))
+	} else if a.IsEmpty() != (a.Count().Sign() == 0) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("set ", a, " with count ", a.Count(), " has emptiness ", a.IsEmpty()), nil))
+	}
+
+	added, removed := t.createRangeSet(oneStrs), t.createRangeSet(oneStrs)
+	for _, rng := range b.GetRanges() {
+		added.Add(rng)
+		removed.Remove(rng)
+	}
+	check("A.Add(B) = A ∪ B", added, a.Union(b))
+	check("A.Remove(B) = A − B", removed, a.Subtract(b))
+
+	blocks := a.ToPrefixBlocks()
+	blockCount, fromBlocks := new(big.Int), &goip.IPRangeSet{}
+	for i, block := range blocks {
+		if !block.IsSinglePrefixBlock() || !a.Contains(block) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("prefix block ", block, " of ", a, " is not a prefix block of the set"), block))
+		} else if i > 0 && blocks[i-1].Overlaps(block) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("prefix block ", block, " of ", a, " overlaps ", blocks[i-1]), block))
+		}
+		blockCount.Add(blockCount, block.GetCount())
+		fromBlocks.Add(block.ToSequentialRange())
+	}
+	if blockCount.Cmp(a.Count()) != 0 {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("prefix blocks of ", a, " have count ", blockCount, ", expected ", a.Count()), nil))
+	}
+	check("A = ∪ ToPrefixBlocks(A)", fromBlocks, a)
+	t.incrementTestCount()
+}
+
+func (t ipAddressRangeTester) testRangeSetContains(strs []string, str string, expected bool) {
+	set := t.createRangeSet(strs)
+	addr := t.createAddress(str).GetAddress()
+	if set.Contains(addr) != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("containment in ", set, " is not ", expected), addr))
+	} else if set.Contains(nil) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint(set, " contains nil"), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testParallelIterator checks the parallel iterator visits each address of the subnet exactly once, in the order of the iterator when there is one goroutine,
+// and that the iteration stops when the function returns true or the context is cancelled
+func (t ipAddressRangeTester) testParallelIterator(str string, parallelism int) {
+	addr := t.createAddress(str).GetAddress()
+	var expected []string
+	for iter := addr.WithoutPrefixLen().Iterator(); iter.HasNext(); {
+		expected = append(expected, iter.Next().String())
+	}
+
+	var mutex sync.Mutex
+	var visited []string
+	counts := map[string]int{}
+	addr.ParallelIterator(parallelism).Iterate(context.Background(), func(next *goip.IPAddress) bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		visited = append(visited, next.String())
+		counts[next.String()]++
+		return false
+	})
+	if len(visited) != len(expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("parallel iterator visited ", len(visited), " addresses, expected ", len(expected)), addr))
+	} else if parallelism == 1 && fmt.Sprint(visited) != fmt.Sprint(expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("parallel iterator with one goroutine visited ", visited, ", expected ", expected), addr))
+	} else {
+		for _, str := range expected {
+			if counts[str] != 1 {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("parallel iterator visited ", str, " ", counts[str], " times"), addr))
+				break
+			}
+		}
+	}
+
+	// each goroutine stops after its first address
+	var stopped int32
+	addr.ParallelIterator(parallelism).Iterate(context.Background(), func(*goip.IPAddress) bool {
+		atomic.AddInt32(&stopped, 1)
+		return true
+	})
+	shards := parallelism
+	if len(expected) < shards {
+		shards = len(expected)
+	}
+	if int(stopped) != shards {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("stopping parallel iteration visited ", stopped, " addresses, expected one for each of ", shards, " goroutines"), addr))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var cancelled int32
+	addr.ParallelIterator(parallelism).Iterate(ctx, func(*goip.IPAddress) bool {
+		atomic.AddInt32(&cancelled, 1)
+		return false
+	})
+	if cancelled != 0 {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("parallel iteration with a cancelled context visited ", cancelled, " addresses"), addr))
+	}
+
+	if ipv4 := addr.ToIPv4(); ipv4 != nil {
+		var ipv4Count int32
+		ipv4.ParallelIterator(parallelism).Iterate(context.Background(), func(next *goip.IPv4Address) bool {
+			if addr.Contains(next.ToIP()) {
+				atomic.AddInt32(&ipv4Count, 1)
+			}
+			return false
+		})
+		if int(ipv4Count) != len(expected) {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("IPv4 parallel iterator visited ", ipv4Count, " addresses, expected ", len(expected)), addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
 func asRangeSliceString(addrs []*goip.IPAddressSeqRange) string {
 	return fmt.Sprintf("%v", asRangeSlice(addrs))
 }
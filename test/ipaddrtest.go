@@ -6,6 +6,9 @@ import (
 	"math"
 	"math/big"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -1766,6 +1769,13 @@ func (t ipAddressTester) run() {
 	t.testIncrement("::1:ffff", -2, "::1:fffd")
 	t.testIncrement("::1:ffff", -0x10000, "::ffff")
 	t.testIncrement("::1:ffff", -0x10001, "::fffe")
+	t.testIncrement("::/0", 0, "::")
+	t.testIncrement("::/0", 5, "::5")
+	t.testIncrement("::/0", math.MaxInt64, "::7fff:ffff:ffff:ffff")
+	t.testIncrement("::/0", -1, "")
+	t.testIncrement("ffff::/16", 1, "ffff::1")
+	t.testIncrement("ffff::/16", 0x10000, "ffff::1:0")
+	t.testIncrement("ffff::/16", -1, "fffe:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
 	t.testLeadingZeroAddr("00.1.2.3", true)
 	t.testLeadingZeroAddr("1.00.2.3", true)
 	t.testLeadingZeroAddr("1.2.00.3", true)
@@ -2142,6 +2152,695 @@ func (t ipAddressTester) run() {
 			addr:  "1::78/126",
 		},
 	})
+
+	t.testFlagValue("1.2.3.4", true)
+	t.testFlagValue("1.2.3.0/24", true)
+	t.testFlagValue("1:2::/64", true)
+	t.testFlagValue("", true)
+	t.testFlagValue("1.2.3.4.5", false)
+	t.testFlagValue("1:2:3:4:5:6:7:8:9", false)
+	t.testFlagValue("a.b.c.d", false)
+
+	t.testWithBitSet("10.0.0.1/24", 0, false, "10.0.0.0")
+	t.testWithBitSet("10.0.0.2/24", 0, true, "10.0.0.3")
+	t.testWithBitSet("10.0.0.1", 31, true, "138.0.0.1")
+	t.testWithBitSet("10.0.0.1/24", 8, true, "10.0.1.1")
+	t.testWithBitSet("1::1/64", 0, false, "1::")
+	t.testWithBitSet("1::%eth0", 127, true, "8001::%eth0")
+
+	t.testOperatorReserved("100.64.0.1", true)
+	t.testOperatorReserved("100.127.255.255", true)
+	t.testOperatorReserved("100.64.0.0/10", true)
+	t.testOperatorReserved("100.128.0.1", false)
+	t.testOperatorReserved("100.63.255.255", false)
+	t.testOperatorReserved("100.0.0.0/8", false)
+	t.testOperatorReserved("10.0.0.1", false)
+	t.testOperatorReserved("::ffff:100.64.0.1", false)
+
+	t.testDetect4in6Type("::ffff:1.2.3.4", goip.IPv4in6Mapped)
+	t.testDetect4in6Type("::1.2.3.4", goip.IPv4in6Compatible)
+	t.testDetect4in6Type("2002:c000:204::1", goip.IPv4in6SixToFour)
+	t.testDetect4in6Type("2001:0:4136:e378:8000:63bf:3fff:fdd2", goip.IPv4in6Teredo)
+	t.testDetect4in6Type("fe80::5efe:192.0.2.1", goip.IPv4in6ISATAP)
+	t.testDetect4in6Type("fe80::200:5efe:192.0.2.1", goip.IPv4in6ISATAP)
+	t.testDetect4in6Type("64:ff9b:1::192.0.2.1", goip.IPv4in6NAT64)
+	t.testDetect4in6Type("64:ff9b::192.0.2.1", goip.IPv4in6WellKnownPrefix)
+	t.testDetect4in6Type("2001:db8::1", goip.IPv4in6None)
+	t.testDetect4in6Type("2002::/16", goip.IPv4in6SixToFour)
+	t.testDetect4in6Type("2000::/15", goip.IPv4in6None)
+
+	t.testTryCanonicalBlock("10.0.0.0/8", true)
+	t.testTryCanonicalBlock("10.1.2.3/8", false)
+	t.testTryCanonicalBlock("10.1.2.3", false)
+	t.testTryCanonicalBlock("1:2::/32", true)
+	t.testTryCanonicalBlock("1:2::1/32", false)
+
+	t.testCompareStringSemantics("::1", "0:0:0:0:0:0:0:1", 0)
+	t.testCompareStringSemantics("1.2.3.4", "1.2.3.4", 0)
+	t.testCompareStringSemantics("1:2::/32", "1:2:0:0::/32", 0)
+	t.testCompareStringSemantics("1.2.3.4", "1.2.3.5", -1)
+	t.testCompareStringSemantics("1.2.3.0/24", "1.2.3.5", 1)
+	t.testCompareStringSemantics("a:b::", "a:b::1", -1)
+	t.testCompareStringSemanticsError(t.createAddress("*"), t.createAddress("1.2.3.4"))
+	t.testCompareStringSemanticsError(t.createAddress("*"), t.createAddress(""))
+	noEmptyAddressOpts := new(address_string_param.IPAddressStringParamsBuilder).ParseEmptyStrAs(address_string_param.NoAddressOption).ToParams()
+	t.testCompareStringSemanticsError(goip.NewIPAddressStringParams("", noEmptyAddressOpts), t.createAddress("::1"))
+
+	t.testNetworkAddress("1:2:3:4::", 64, true)
+	t.testNetworkAddress("1:2:3:4::1", 64, false)
+	t.testNetworkAddress("1:2:3:4::/64", -1, false)
+	t.testNetworkAddress("1:2:3:4::", -1, false)
+	t.testNetworkAddress("10.1.0.0", 16, true)
+	t.testNetworkAddress("10.1.0.1", 16, false)
+	t.testNetworkAddress("10.1.0.0/16", -1, false)
+	t.testNetworkAddress("10.1.0.0", -1, false)
+
+	t.testSocks5Address("1.2.3.4", []byte{1, 1, 2, 3, 4})
+	t.testSocks5Address("::1", []byte{4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	t.testSocks5Address("1.2.3.0/24", nil)
+	t.testInvalidSocks5Address([]byte{})
+	t.testInvalidSocks5Address([]byte{3, 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e'})
+	t.testInvalidSocks5Address([]byte{1, 1, 2, 3})
+	t.testInvalidSocks5Address([]byte{4, 1, 2, 3, 4})
+
+	t.testBGPLargeCommunity("64496:4294967295:2", 64496, 4294967295, 2)
+	t.testBGPLargeCommunity("0:0:0", 0, 0, 0)
+	t.testBGPLargeCommunity(" 4294967295:1:4294967295 ", 4294967295, 1, 4294967295)
+	t.testInvalidBGPLargeCommunity("64496:1")
+	t.testInvalidBGPLargeCommunity("64496:1:2:3")
+	t.testInvalidBGPLargeCommunity("64496:4294967296:2")
+	t.testInvalidBGPLargeCommunity("64496:-1:2")
+	t.testInvalidBGPLargeCommunity("64496::2")
+	t.testInvalidBGPLargeCommunity("a:b:c")
+
+	t.testPrefixLengthIterator("1.2.3.4", "0.0.0.0/0", "1.2.3.4/32")
+	t.testPrefixLengthIterator("10.1.0.1/16", "0.0.0.0/0", "10.1.0.1/32")
+	t.testPrefixLengthIterator("1:2::3", "::/0", "1:2::3/128")
+
+	t.testUint32NetworkMask("1.2.3.4/24", 0xffffff00)
+	t.testUint32NetworkMask("1.2.3.4/0", 0)
+	t.testUint32NetworkMask("1.2.3.4/32", 0xffffffff)
+	t.testUint32NetworkMask("255.255.192.0", 0xffffc000)
+	t.testUint32NetworkMask("255.0.255.0", 0)
+	t.testInvalidUint32NetworkMask(0xff00ff00)
+	t.testInvalidUint32NetworkMask(0x7fffffff)
+
+	t.testCompactJSON("1.2.3.4", `[1,2,3,4]`)
+	t.testCompactJSON("1.2.3.4/24", `[1,2,3,4]`)
+	t.testCompactJSON("::1", `"00000000000000000000000000000001"`)
+	t.testCompactJSON("fe80::1%eth0", `"fe800000000000000000000000000001%eth0"`)
+	t.testCompactJSON("1.2.3.0/24", "")
+	t.testUnmarshalCompactJSON(`"01020304"`, "1.2.3.4")
+	t.testUnmarshalCompactJSON(`[1,2,3,4,5,6,7,8]`, "1:2:3:4:5:6:7:8")
+	t.testUnmarshalCompactJSON(` [ 255, 0, 0, 1 ] `, "255.0.0.1")
+	t.testUnmarshalCompactJSON(`[1,2,3]`, "")
+	t.testUnmarshalCompactJSON(`[256,0,0,0]`, "")
+	t.testUnmarshalCompactJSON(`"0102030"`, "")
+	t.testUnmarshalCompactJSON(`"01020304%eth0"`, "")
+	t.testUnmarshalCompactJSON(`{}`, "")
+
+	t.testNatPt("::ffff:0:1.2.3.4", "1.2.3.4")
+	t.testNatPt("::ffff:0:a00:1", "10.0.0.1")
+	t.testNatPt("::ffff:1.2.3.4", "")
+	t.testNatPt("1::ffff:0:1.2.3.4", "")
+	t.testNewNatPt("", "")
+	if t.allowsRange() {
+		t.testNewNatPt("1.2.3.*", "::ffff:0:102:300-3ff")
+		t.testNewNatPt("1.2.*.3", "")
+	}
+
+	for _, str := range []string{"8.8.8.8", "8.8.8.0/24", "192.0.0.9", "192.0.0.10", "192.88.99.2", "192.31.196.1", "192.52.193.0/24", "223.255.255.255",
+		"2001:4860::8888", "2001:1::1", "2001:1::3", "2001:3::/32", "2001:4:112::1", "2001:20::1", "2001:3f::1", "2002::1"} {
+		t.testGloballyRoutable(str, true)
+	}
+	for _, str := range []string{"0.0.0.0", "10.0.0.1", "100.64.0.1", "127.0.0.1", "169.254.0.1", "172.16.0.1", "192.0.0.8", "192.0.0.0/29",
+		"192.0.2.1", "192.88.99.1", "192.88.99.0/24", "192.168.1.1", "198.18.0.1", "198.51.100.1", "203.0.113.1", "224.0.0.1", "240.0.0.1",
+		"255.255.255.255", "8.0.0.0/5", "::", "::1", "::ffff:1.2.3.4", "64:ff9b:1::1", "100::1", "2001::1", "2001:1::4", "2001:2::1",
+		"2001:db8::1", "2001::/16", "fc00::1", "fe80::1", "fec0::1", "ff02::1"} {
+		t.testGloballyRoutable(str, false)
+	}
+
+	t.testIPAddressHTTPURL("192.168.1.1", "", 8080, "", "http://192.168.1.1:8080")
+	t.testIPAddressHTTPURL("192.168.1.1/24", "HTTPS", 0, "a/b", "https://192.168.1.1/a/b")
+	t.testIPAddressHTTPURL("::1", "http", 8080, "/path", "http://[::1]:8080/path")
+	t.testIPAddressHTTPURL("fe80::1%eth0", "", 0, "", "http://[fe80::1%25eth0]")
+	t.testIPAddressHTTPURL("192.168.1.1", "1http", 0, "", "")
+	t.testIPAddressHTTPURL("192.168.1.0/24", "", 0, "", "")
+
+	t.testBitsAndByteCount("1.2.3.4", 32, 4)
+	t.testBitsAndByteCount("1.2.3.0/24", 32, 4)
+	t.testBitsAndByteCount("::1", 128, 16)
+	t.testBitsAndByteCount("", 0, 0)
+
+	t.testEnumerate("10.0.0.1", "10.0.0.10", 10, 10)
+	t.testEnumerate("10.0.0.10", "10.0.0.1", 100, 10)
+	t.testEnumerate("10.0.0.1", "10.0.0.10", 9, -1)
+	t.testEnumerate("::1", "::1", 1, 1)
+	t.testEnumerate("::1", "::1", 0, -1)
+	t.testEnumerate("0.0.0.0", "255.255.255.255", 1000, -1)
+	t.testEnumerate("::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", math.MaxInt, -1)
+
+	t.testFirstNBits("10.1.2.3", 16, "10.1.0.0")
+	t.testFirstNBits("10.1.2.3", 0, "0.0.0.0")
+	t.testFirstNBits("10.1.2.3", 32, "10.1.2.3")
+	t.testFirstNBits("10.1.2.3/8", 20, "10.1.0.0")
+	t.testFirstNBits("1:2:3:4::5", 48, "1:2:3::")
+	t.testFirstNBits("10.1.2.3", -1, "")
+	t.testFirstNBits("10.1.2.3", 33, "")
+	t.testFirstNBits("1::", 129, "")
+
+	t.testMaskPair("192.168.0.0/16", "255.255.0.0", "0.0.255.255")
+	t.testMaskPair("10.1.2.3/0", "0.0.0.0", "255.255.255.255")
+	t.testMaskPair("10.1.2.3/32", "255.255.255.255", "0.0.0.0")
+	t.testMaskPair("1::/64", "ffff:ffff:ffff:ffff::", "::ffff:ffff:ffff:ffff")
+	t.testMaskPair("1.2.3.4", "", "")
+	t.testMaskPair("1::1", "", "")
+
+	t.testSetHostBits("10.0.0.0/24", "0.0.0.50", "10.0.0.50")
+	t.testSetHostBits("10.0.0.7/24", "192.168.1.50", "10.0.0.50")
+	t.testSetHostBits("10.1.2.3/16", "0.0.255.1", "10.1.255.1")
+	t.testSetHostBits("1:2::/32", "::3:4", "1:2::3:4")
+	t.testSetHostBits("10.0.0.0", "0.0.0.1", "")
+	t.testSetHostBits("10.0.0.0/24", "::1", "")
+
+	t.testNextAvailableAddress("10.0.0.0/24", nil, "10.0.0.1")
+	t.testNextAvailableAddress("10.0.0.0/24", []string{}, "10.0.0.1")
+	t.testNextAvailableAddress("10.0.0.0/32", nil, "10.0.0.0")
+	t.testNextAvailableAddress("10.0.0.0/24", []string{"10.0.0.1", "10.0.0.2", "10.0.0.4"}, "10.0.0.3")
+	t.testNextAvailableAddress("10.0.0.0/24", []string{"10.0.0.0/25", "10.0.0.128/26"}, "10.0.0.192")
+	t.testNextAvailableAddress("10.0.0.0/30", []string{"10.0.0.1", "10.0.0.2"}, "")
+	t.testNextAvailableAddress("10.0.0.0/31", nil, "10.0.0.0")
+	t.testNextAvailableAddress("10.0.0.0/24", []string{"0.0.0.0/0"}, "")
+	t.testNextAvailableAddress("1::/64", nil, "1::1")
+	t.testNextAvailableAddress("1::/64", []string{"1::1"}, "1::2")
+	t.testNextAvailableAddress("1::/126", []string{"1::1", "1::2"}, "1::3")
+	t.testNextAvailableAddress("1::/126", []string{"1::/127", "1::2", "1::3"}, "")
+
+	t.testStringMask("192.168.1.255", "255.255.255.0", "192.168.1.0")
+	t.testStringMask("1:2:3:4:5:6:7:8", "ffff:ffff::", "1:2::")
+	t.testStringMask("192.168.1.255", "ffff::", "")
+	t.testStringMask("192.168.1.256", "255.255.255.0", "")
+	t.testStringMask("192.168.1.255", "255.255.255.256", "")
+
+	t.testAnnotatedString("192.168.0.0/16", goip.DefaultAnnotationOptions, "192.168.0.0/16 (65536 hosts, network: 192.168.0.0, broadcast: 192.168.255.255)")
+	t.testAnnotatedString("10.1.2.3/24", goip.DefaultAnnotationOptions, "10.1.2.3/24 (256 hosts, network: 10.1.2.0, broadcast: 10.1.2.255)")
+	t.testAnnotatedString("1.2.3.4", goip.DefaultAnnotationOptions, "1.2.3.4 (1 host)")
+	t.testAnnotatedString("1::/64", goip.DefaultAnnotationOptions, "1::/64 (18446744073709551616 hosts, network: 1::)")
+	t.testAnnotatedString("10.0.0.0/8", goip.AnnotationOptions{NetworkMask: true, WildcardMask: true}, "10.0.0.0/8 (network mask: 255.0.0.0, wildcard mask: 0.255.255.255)")
+	t.testAnnotatedString("1.2.3.4", goip.AnnotationOptions{Network: true, NetworkMask: true}, "1.2.3.4")
+
+	t.testMaskConstructors(24, "255.255.255.0", "0.0.0.255", "ffff:ff00::", "0:ff:ffff:ffff:ffff:ffff:ffff:ffff")
+	t.testMaskConstructors(0, "0.0.0.0", "255.255.255.255", "::", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+	t.testMaskConstructors(32, "255.255.255.255", "0.0.0.0", "ffff:ffff::", "::ffff:ffff:ffff:ffff:ffff:ffff")
+	t.testMaskConstructors(64, "", "", "ffff:ffff:ffff:ffff::", "::ffff:ffff:ffff:ffff")
+	t.testMaskConstructors(128, "", "", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff", "::")
+	t.testMaskConstructors(129, "", "", "", "")
+	t.testMaskConstructors(-1, "", "", "", "")
+
+	t.testBase64("1.2.3.4", false, "AQIDBA==")
+	t.testBase64("1.2.3.4/16", true, "AQIDBA==")
+	t.testBase64("251.255.254.0", false, "+//+AA==")
+	t.testBase64("251.255.254.0", true, "-__-AA==")
+	t.testBase64("::1", false, "AAAAAAAAAAAAAAAAAAAAAQ==")
+	t.testInvalidBase64("AQIDBA==", goip.IPv6, false)
+	t.testInvalidBase64("AQIDBA", goip.IPv4, false)
+	t.testInvalidBase64("-__-AA==", goip.IPv4, false)
+	t.testInvalidBase64("AQIDBAU=", goip.IPv4, false)
+	t.testInvalidBase64("AQIDBA==", goip.IndeterminateIPVersion, false)
+
+	t.testStrictContains("1.2.0.0/16", "1.2.3.0/24", true)
+	t.testStrictContains("1.2.0.0/16", "1.2.3.4", true)
+	t.testStrictContains("1.2.0.0/16", "1.2.0.0/16", false)
+	t.testStrictContains("1.2.3.4", "1.2.3.4", false)
+	t.testStrictContains("1.2.3.0/24", "1.2.0.0/16", false)
+	t.testStrictContains("1.2.3.0/24", "1.3.3.0/24", false)
+	t.testStrictContains("1::/64", "1::1", true)
+	t.testStrictContains("1::/64", "1.2.3.4", false)
+
+	t.testCompressedStrings("1:0:2:3:4:5:6:7", "1:0:2:3:4:5:6:7", "1::2:3:4:5:6:7")
+	t.testCompressedStrings("1:0:0:2:0:0:3:4", "1::2:0:0:3:4", "1::2:0:0:3:4")
+	t.testCompressedStrings("1:0:0:2::5:6", "1::2:0:0:5:6", "1::2:0:0:5:6")
+	t.testCompressedStrings("1:2:3:4::/64", "1:2:3:4:0:0:0:0/64", "1:2:3:4::/64")
+	t.testCompressedStrings("1:2:3:4::1/64", "1:2:3:4::1/64", "1:2:3:4::1/64")
+	t.testCompressedStrings("::", "::", "::")
+	t.testCompressedStrings("fe80::1%eth0", "fe80::1%eth0", "fe80::1%eth0")
+
+	t.testURLHostString("192.168.1.1", "192.168.1.1")
+	t.testURLHostString("192.168.1.0/24", "192.168.1.0")
+	t.testURLHostString("2001:db8::1", "[2001:db8::1]")
+	t.testURLHostString("2001:db8::/32", "[2001:db8::]")
+	t.testURLHostString("fe80::1%eth0", "[fe80::1%25eth0]")
+
+	t.testPackedDecimal("192.168.1.1", "192.168.001.001")
+	t.testPackedDecimal("0.0.0.0", "000.000.000.000")
+	t.testPackedDecimal("255.255.255.255", "255.255.255.255")
+	t.testPackedDecimal("10.1.2.0/24", "010.001.002.000")
+	t.testInvalidPackedDecimal("192.168.1.1")
+	t.testInvalidPackedDecimal("256.000.000.000")
+	t.testInvalidPackedDecimal("1a2.000.000.000")
+	t.testInvalidPackedDecimal("001.002.003")
+	t.testInvalidPackedDecimal("001.002.003.004.005")
+	t.testInvalidPackedDecimal("")
+	t.testZeroPaddedHex("2001:db8::1", "2001:0db8:0000:0000:0000:0000:0000:0001")
+	t.testZeroPaddedHex("::", "0000:0000:0000:0000:0000:0000:0000:0000")
+	t.testZeroPaddedHex("2001:db8::/32", "2001:0db8:0000:0000:0000:0000:0000:0000")
+	t.testZeroPaddedHex("fe80::1%eth0", "fe80:0000:0000:0000:0000:0000:0000:0001%eth0")
+
+	t.testMulticastSourceBytes("192.0.2.1", []byte{192, 0, 2, 1})
+	t.testMulticastSourceBytes("0.0.0.0", []byte{0, 0, 0, 0})
+	t.testMulticastSourceBytes("2001:db8::1", []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	t.testMulticastSourceBytes("fe80::1%eth0", []byte{0xfe, 0x80, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	t.testMulticastSourceBytes("10.1.0.0/16", nil)
+	t.testMulticastSourceBytes("2001:db8::/64", nil)
+	t.testInvalidMulticastSourceBytes([]byte{1, 2, 3})
+	t.testInvalidMulticastSourceBytes([]byte{1, 2, 3, 4, 5})
+	t.testInvalidMulticastSourceBytes(nil)
+
+	t.testOnionV3("2001:db8::1", "eaaq3oaaaaaaaaaaaaaaaaaaae.onion")
+	t.testOnionV3("::", "aaaaaaaaaaaaaaaaaaaaaaaaaa.onion")
+	t.testOnionV3("2001:db8::/32", "eaaq3oaaaaaaaaaaaaaaaaaaaa.onion")
+	t.testInvalidOnionV3("eaaq3oaaaaaaaaaaaaaaaaaa.onion")
+	t.testInvalidOnionV3("eaaq3oaaaaaaaaaaaaaaaaaa1e.onion")
+	t.testInvalidOnionV3(".onion")
+	t.testInvalidOnionV3("")
+
+	t.testBitString("10.0.0.1", "00001010000000000000000000000001", "00001010000000000000000000000001")
+	t.testBitString("10.0.0.0/8", "00001010000000000000000000000000", "00001010000000000000000000000000/8")
+	t.testBitString("255.255.255.255", "11111111111111111111111111111111", "11111111111111111111111111111111")
+	t.testBitString("8000::/1",
+		"1"+strings.Repeat("0", 127),
+		"1"+strings.Repeat("0", 127)+"/1")
+	t.testBitString("::1", strings.Repeat("0", 127)+"1", strings.Repeat("0", 127)+"1")
+	t.testInvalidBitString("0000101000000000000000000000001")
+	t.testInvalidBitString("00001010000000000000000000000002")
+	t.testInvalidBitString("00001010000000000000000000000000/33")
+	t.testInvalidBitString("00001010000000000000000000000000/a")
+	t.testInvalidBitString("")
+
+	t.testCIDRSummary("192.168.1.77", 24, "192.168.1.0", "192.168.1.255", "192.168.1.1", "192.168.1.254", 254, "255.255.255.0", "0.0.0.255")
+	t.testCIDRSummary("10.1.2.3/8", 30, "10.1.2.0", "10.1.2.3", "10.1.2.1", "10.1.2.2", 2, "255.255.255.252", "0.0.0.3")
+	t.testCIDRSummary("10.1.2.3", 31, "10.1.2.2", "10.1.2.3", "10.1.2.2", "10.1.2.3", 2, "255.255.255.254", "0.0.0.1")
+	t.testCIDRSummary("10.1.2.3", 32, "10.1.2.3", "10.1.2.3", "10.1.2.3", "10.1.2.3", 1, "255.255.255.255", "0.0.0.0")
+	t.testCIDRSummary("10.1.2.3", 40, "10.1.2.3", "10.1.2.3", "10.1.2.3", "10.1.2.3", 1, "255.255.255.255", "0.0.0.0")
+	t.testCIDRSummary("10.1.2.3", 0, "0.0.0.0", "255.255.255.255", "0.0.0.1", "255.255.255.254", 4294967294, "0.0.0.0", "255.255.255.255")
+	t.testCIDRSummary("10.1.2.0/24", 16, "10.1.0.0", "10.1.255.255", "10.1.0.1", "10.1.255.254", 65534, "255.255.0.0", "0.0.255.255")
+
+	t.testPrefixHierarchy([]string{"1.2.3.0/24", "1.0.0.0/8", "1.2.3.4", "2.0.0.0/8", "1.2.0.0/16", "1.3.0.0/16", "1.2.0.0/16"},
+		"[1.0.0.0/8 [1.2.0.0/16 [1.2.3.0/24 [1.2.3.4/32]]] [1.3.0.0/16]] [2.0.0.0/8]")
+	t.testPrefixHierarchy([]string{"2001:db8::/32", "10.0.0.0/8", "2001:db8:1::/48", "0.0.0.0/0", "::/0"},
+		"[0.0.0.0/0 [10.0.0.0/8]] [::/0 [2001:db8::/32 [2001:db8:1::/48]]]")
+	t.testPrefixHierarchy([]string{"1.2.3.0/24", "1.2.4.0/24"}, "[1.2.3.0/24] [1.2.4.0/24]")
+	t.testPrefixHierarchy(nil, "")
+	t.testPrefixHierarchy([]string{"1.2.3.0/24", "1.2.3.4/16"}, "[1.2.3.0/24 [1.2.3.4/32]]")
+	t.testInvalidPrefixHierarchy()
+
+	t.testHostIterator("10.1.2.0/29", 6, "10.1.2.1/29", "10.1.2.6/29")
+	t.testHostIterator("10.1.2.0/30", 2, "10.1.2.1/30", "10.1.2.2/30")
+	t.testHostIterator("10.1.2.0/31", 2, "10.1.2.0/31", "10.1.2.1/31")
+	t.testHostIterator("10.1.2.3/32", 1, "10.1.2.3/32", "10.1.2.3/32")
+	t.testHostIterator("10.1.2.3", 1, "10.1.2.3", "10.1.2.3")
+	t.testHostIterator("1::/126", 4, "1::/126", "1::3/126")
+	t.testHostIterator("1::1", 1, "1::1", "1::1")
+
+	t.testAllowListHandler("10.0.0.0/8", "10.1.2.3:1234", true)
+	t.testAllowListHandler("10.0.0.0/8", "10.1.2.3", true)
+	t.testAllowListHandler("10.0.0.0/8", "11.1.2.3:1234", false)
+	t.testAllowListHandler("10.0.0.0/8", "[::ffff:10.1.2.3]:1234", true)
+	t.testAllowListHandler("10.0.0.0/8", "[1::1]:1234", false)
+	t.testAllowListHandler("10.0.0.0/8", "not an address", false)
+	t.testAllowListHandler("10.0.0.0/8", "", false)
+	t.testAllowListHandler("fe80::/64", "[fe80::1%eth0]:1234", true)
+	t.testAllowListHandler("fe80::/64", "[fe81::1]:1234", false)
+	t.testAllowListHandler("1.2.3.4", "1.2.3.4:80", true)
+
+	t.testGrowShrinkPrefix("10.1.2.3/16", "10.1.2.3/17", "10.1.2.3/15")
+	t.testGrowShrinkPrefix("10.1.2.3", "10.1.2.3/1", "10.1.2.3/31")
+	t.testGrowShrinkPrefix("10.1.2.3/32", "10.1.2.3/32", "10.1.2.3/31")
+	t.testGrowShrinkPrefix("10.1.2.3/0", "10.1.2.3/1", "10.1.2.3/0")
+	t.testGrowShrinkPrefix("1::1", "1::1/1", "1::1/127")
+	t.testGrowShrinkPrefix("1::1/64", "1::1/65", "1::1/63")
+
+	t.testReverseDNS("4.3.2.1.in-addr.arpa.", "1.2.3.4")
+	t.testReverseDNS("4.3.2.1.IN-ADDR.ARPA", "1.2.3.4")
+	t.testReverseDNS("0.168.192.in-addr.arpa.", "192.168.0.0/24")
+	t.testReverseDNS("10.in-addr.arpa", "10.0.0.0/8")
+	t.testReverseDNS("8.b.d.0.1.0.0.2.ip6.arpa", "2001:db8::/32")
+	t.testReverseDNS("1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa.", "2001:db8::1")
+	t.testReverseDNS("1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.B.D.0.1.0.0.2.IP6.INT", "2001:db8::1")
+	t.testReverseDNS("256.3.2.1.in-addr.arpa", "")
+	t.testReverseDNS("5.4.3.2.1.in-addr.arpa", "")
+	t.testReverseDNS("4..2.1.in-addr.arpa", "")
+	t.testReverseDNS("in-addr.arpa", "")
+	t.testReverseDNS("g.ip6.arpa", "")
+	t.testReverseDNS("10.ip6.arpa", "")
+	t.testReverseDNS("4.3.2.1.example.com", "")
+
+	t.testConcatenateIPv6Sections("2001:db8:1:2::", 4, "::a:b:c:d", 4, "2001:db8:1:2:a:b:c:d")
+	t.testConcatenateIPv6Sections("2001:db8:1:2::/48", 4, "::a:b:c:d", 4, "2001:db8:1:2:a:b:c:d/48")
+	t.testConcatenateIPv6Sections("2001:db8:1:2::", 4, "::a:b:c:d/120", 4, "2001:db8:1:2:a:b:c:d/120")
+	t.testConcatenateIPv6Sections("2001:db8:1:2::", 0, "::a:b:c:d", 8, "::a:b:c:d")
+	t.testConcatenateIPv6Sections("2001:db8:1:2::", 4, "::a:b:c:d", 5, "")
+	t.testConcatenateIPv4AndIPv6Sections("1.2.3.4", "64:ff9b::/96", 96, "64:ff9b::102:304/96")
+	t.testConcatenateIPv4AndIPv6Sections("255.255.255.255", "::", 4, "fff:ffff:f000::")
+	t.testConcatenateIPv4AndIPv6Sections("0.0.0.0", "ffff:ffff:ffff::", 8, "ff00:0:ff::")
+	t.testConcatenateIPv4AndIPv6Sections("1.2.3.4", "64:ff9b::/96", 97, "")
+	t.testConcatenateIPv4AndIPv6Sections("1.2.3.4", "64:ff9b::/96", -1, "")
+	t.testConcatenateIPv4AndIPv6Sections("1.2.3.0/24", "64:ff9b::/96", 96, "")
+
+	t.testNetworkHostBoundary("1.2.3.4/16", "1.2.0.0/16", "1.2.255.255/16")
+	t.testNetworkHostBoundary("1.2.0.0/16", "1.2.0.0/16", "1.2.255.255/16")
+	t.testNetworkHostBoundary("1.2.3.4/32", "1.2.3.4/32", "1.2.3.4/32")
+	t.testNetworkHostBoundary("1.2.3.4/0", "0.0.0.0/0", "255.255.255.255/0")
+	t.testNetworkHostBoundary("2001:db8::1/64", "2001:db8::/64", "2001:db8::ffff:ffff:ffff:ffff/64")
+	t.testNetworkHostBoundary("1.2.3.4", "", "")
+	t.testNetworkHostBoundary("2001:db8::1", "", "")
+
+	t.testEmbeddedIPv4Section("::ffff:1.2.3.4", 8, "1.2.3.4")
+	t.testEmbeddedIPv4Section("64:ff9b::102:304", 8, "1.2.3.4")
+	t.testEmbeddedIPv4Section("::ffff:0:0/96", 8, "0.0.0.0/0")
+	t.testEmbeddedIPv4Section("::ffff:1.2.3.4", 6, "")
+
+	t.testUsableHostCount("10.1.2.0/24", "254")
+	t.testUsableHostCount("10.1.2.3/24", "254")
+	t.testUsableHostCount("10.1.2.0/30", "2")
+	t.testUsableHostCount("10.1.2.0/31", "2")
+	t.testUsableHostCount("10.1.2.3/32", "1")
+	t.testUsableHostCount("10.1.2.3", "1")
+	t.testUsableHostCount("0.0.0.0/0", "4294967294")
+	t.testUsableHostCount("2001:db8::/64", "18446744073709551616")
+	t.testUsableHostCount("2001:db8::1/127", "2")
+	t.testUsableHostCount("2001:db8::1", "1")
+
+	t.testNATRule("10.0.0.0/24", "192.168.1.0/24", "10.0.0.5", "192.168.1.5")
+	t.testNATRule("10.0.0.0/24", "192.168.1.0/24", "10.0.0.255", "192.168.1.255")
+	t.testNATRule("10.0.0.0/24", "192.168.1.0/24", "10.0.0.0/24", "")
+	t.testNATRule("10.0.0.0/24", "192.168.1.0/24", "10.0.1.5", "")
+	t.testNATRule("10.0.0.0/24", "192.168.1.0/25", "10.0.0.5", "")
+	t.testNATRule("192.168.1.0/24", "64:ff9b::c0a8:100/120", "192.168.1.5", "64:ff9b::c0a8:105")
+	t.testNATRule("64:ff9b::c0a8:100/120", "192.168.1.0/24", "64:ff9b::c0a8:1ff", "192.168.1.255")
+	t.testNATRule("2001:db8::/64", "2001:db8:1::/64", "2001:db8::1:2", "2001:db8:1::1:2")
+	t.testNATRule("", "192.168.1.0/24", "10.0.0.5", "")
+
+	t.testIANARegistration("10.1.2.3", "Private-Use", "RFC 1918", true, false)
+	t.testIANARegistration("172.16.0.0/12", "Private-Use", "RFC 1918", true, false)
+	t.testIANARegistration("192.0.0.9", "Port Control Protocol Anycast", "RFC 7723", true, true)
+	t.testIANARegistration("192.0.0.5", "IPv4 Service Continuity Prefix", "RFC 7335", true, false)
+	t.testIANARegistration("192.0.0.100", "IETF Protocol Assignments", "RFC 6890", false, false)
+	t.testIANARegistration("192.88.99.1", "Deprecated (6to4 Relay Anycast)", "RFC 7526", false, false)
+	t.testIANARegistration("192.88.99.2", "6a44-relay anycast address", "RFC 6751", true, true)
+	t.testIANARegistration("0.0.0.0", "This host on this network", "RFC 1122", false, false)
+	t.testIANARegistration("0.1.2.3", "This network", "RFC 791", false, false)
+	t.testIANARegistration("255.255.255.255", "Limited Broadcast", "RFC 919", false, false)
+	t.testIANARegistration("240.0.0.0/4", "Reserved", "RFC 1112", false, false)
+	t.testIANARegistration("8.8.8.8", "", "", false, false)
+	t.testIANARegistration("10.0.0.0/7", "", "", false, false)
+
+	t.testApplySubnetMask("1.2.3.4", "255.255.255.0", "1.2.3.0/24")
+	t.testApplySubnetMask("1.2.3.4", "255.255.0.0", "1.2.0.0/16")
+	t.testApplySubnetMask("1.2.3.4", "255.255.255.255", "1.2.3.4/32")
+	t.testApplySubnetMask("1.2.3.4", "0.0.0.0", "0.0.0.0/0")
+	t.testApplySubnetMask("1.2.3.4/8", "255.255.255.0", "1.2.3.0/24")
+	t.testApplySubnetMask("1:2:3::4", "ffff:ffff::", "1:2::/32")
+	t.testApplySubnetMask("1.2.3.4", "255.0.255.0", "")
+	t.testApplySubnetMask("1.2.3.4", "0.0.0.255", "")
+	t.testApplySubnetMask("1.2.3.4", "ffff::", "")
+
+	t.testSegmentSlices("1.2.3.4", []uint32{1, 2, 3, 4})
+	t.testSegmentSlices("1.2.3.0/24", []uint32{1, 2, 3, 0})
+	t.testSegmentSlices("255.0.128.1", []uint32{255, 0, 128, 1})
+	t.testSegmentSlices("2001:db8::ff01", []uint32{0x2001, 0xdb8, 0, 0, 0, 0, 0, 0xff01})
+
+	t.testNAT64("2001:db8::/32", "192.0.2.33", "2001:db8:c000:221::")
+	t.testNAT64("2001:db8:100::/40", "192.0.2.33", "2001:db8:1c0:2:21::")
+	t.testNAT64("2001:db8:122::/48", "192.0.2.33", "2001:db8:122:c000:2:2100::")
+	t.testNAT64("2001:db8:122:300::/56", "192.0.2.33", "2001:db8:122:3c0:0:221::")
+	t.testNAT64("2001:db8:122:344::/64", "192.0.2.33", "2001:db8:122:344:c0:2:2100:0")
+	t.testNAT64("2001:db8:122:344::/96", "192.0.2.33", "2001:db8:122:344::c000:221")
+	t.testNAT64("64:ff9b::/96", "192.0.2.33", "64:ff9b::c000:221")
+	t.testNAT64("2001:db8::/33", "192.0.2.33", "")
+	t.testNAT64("2001:db8::", "192.0.2.33", "")
+	t.testNAT64("2001:db8::/32", "192.0.2.0/24", "")
+	t.testNAT64IPv4("64:ff9b::c000:221", "192.0.2.33")
+	t.testNAT64IPv4("64:ff9b:1:c000:2:2100::", "192.0.2.33")
+	t.testNAT64IPv4("2001:db8:c000:221::", "")
+	t.testNAT64IPv4("64:ff9b::/96", "")
+
+	t.testGeoIPKey("1.2.3.4", 0x01020304, 0, 0)
+	t.testGeoIPKey("1.2.3.0/24", 0x01020300, 0, 0)
+	t.testGeoIPKey("255.255.255.255", 0xffffffff, 0, 0)
+	t.testGeoIPKey("2001:db8::1", 0, 0x20010db800000000, 1)
+	t.testGeoIPKey("2001:db8::/32", 0, 0x20010db800000000, 0)
+	t.testGeoIPKey("::ffff:ffff:ffff:ffff", 0, 0, 0xffffffffffffffff)
+
+	t.testOddEven("10.0.0.1", true)
+	t.testOddEven("10.0.0.0", false)
+	t.testOddEven("10.0.0.0/31", false)
+	t.testOddEven("10.0.0.3/31", true)
+	t.testOddEven("255.255.255.255", true)
+	t.testOddEven("1::1", true)
+	t.testOddEven("1::fffe", false)
+	t.testOddEven("1::/64", false)
+
+	t.testIPv4ScopedMulticast("239.1.2.3", true, false)
+	t.testIPv4ScopedMulticast("239.192.0.1", true, true)
+	t.testIPv4ScopedMulticast("239.195.255.255", true, true)
+	t.testIPv4ScopedMulticast("239.196.0.0", true, false)
+	t.testIPv4ScopedMulticast("239.192.0.0/14", true, true)
+	t.testIPv4ScopedMulticast("238.0.0.0/7", false, false)
+	t.testIPv4ScopedMulticast("224.0.0.1", false, false)
+	t.testMulticastScope("ff01::1", goip.MulticastScopeInterfaceLocal, "interface-local")
+	t.testMulticastScope("ff02::1", goip.MulticastScopeLinkLocal, "link-local")
+	t.testMulticastScope("ff13::1", goip.MulticastScopeRealmLocal, "realm-local")
+	t.testMulticastScope("ff04::1", goip.MulticastScopeAdminLocal, "admin-local")
+	t.testMulticastScope("ff05::1", goip.MulticastScopeSiteLocal, "site-local")
+	t.testMulticastScope("ff38::1", goip.MulticastScopeOrganizationLocal, "organization-local")
+	t.testMulticastScope("ff0e::1", goip.MulticastScopeGlobal, "global")
+	t.testMulticastScope("ff00::1", goip.MulticastScopeReserved, "reserved")
+	t.testMulticastScope("ff0f::1", 0xf, "reserved")
+	t.testMulticastScope("ff06::1", 0x6, "unassigned")
+	t.testMulticastScope("ff02::/16", goip.MulticastScopeLinkLocal, "link-local")
+	t.testMulticastScope("ff00::/8", goip.MulticastScopeNone, "none")
+	t.testMulticastScope("fe80::1", goip.MulticastScopeNone, "none")
+
+	t.testPaddedDottedDecimal([]string{"10.2.3.4", "9.255.0.1", "10.10.3.4", "1.2.3.4", "100.0.0.0", "10.2.3.40/24"},
+		[]string{"001.002.003.004", "009.255.000.001", "010.002.003.004", "010.002.003.040", "010.010.003.004", "100.000.000.000"})
+
+	t.testToCIDRList([]string{"10.0.0.0/24", "10.0.1.0/24", " 10.0.2.5 "}, 128, []string{"10.0.0.0/23", "10.0.2.5/32"})
+	t.testToCIDRList([]string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.5"}, 24, []string{"10.0.0.0/23", "10.0.2.0/24"})
+	t.testToCIDRList([]string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.5", "10.0.3.255"}, 24, []string{"10.0.0.0/22"})
+	t.testToCIDRList([]string{"2001:db8::1", "1.2.3.4", "2001:db8::/32", "1.2.*.*"}, 128, []string{"1.2.0.0/16", "2001:db8::/32"})
+	t.testToCIDRList([]string{"1.2.3.0-255"}, 32, []string{"1.2.3.0/24"})
+	t.testToCIDRList([]string{"1.2.3.1-2"}, 32, []string{"1.2.3.1/32", "1.2.3.2/32"})
+	t.testToCIDRList(nil, 32, []string{})
+	t.testToCIDRList([]string{"*"}, 32, nil)
+	t.testToCIDRList([]string{"1.2.3.4", "not an address"}, 32, nil)
+	t.testToCIDRList([]string{"1.2.3.4"}, -1, nil)
+
+	t.testProtoBytes("1.2.3.4", []byte{1, 2, 3, 4})
+	t.testProtoBytes("1.2.3.0/24", []byte{1, 2, 3, 0})
+	t.testProtoBytes("::ffff:1.2.3.4", []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 1, 2, 3, 4})
+	t.testProtoBytes("fe80::1%eth0", []byte{0xfe, 0x80, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1})
+	t.testInvalidProtoBytes([]byte{1, 2, 3})
+	t.testInvalidProtoBytes(make([]byte, 15))
+	t.testInvalidProtoBytes(nil)
+
+	t.testSegmentedHexString("1.2.3.4", "01.02.03.04", "0x01.0x02.0x03.0x04", "0X01.0X02.0X03.0X04")
+	t.testSegmentedHexString("10.171.3.255/24", "0a.ab.03.ff/24", "0x0a.0xab.0x03.0xff/24", "0X0A.0XAB.0X03.0XFF/24")
+	t.testSegmentedHexString("a:b::c",
+		"000a:000b:0000:0000:0000:0000:0000:000c",
+		"0x000a:0x000b:0x0000:0x0000:0x0000:0x0000:0x0000:0x000c",
+		"0X000A:0X000B:0X0000:0X0000:0X0000:0X0000:0X0000:0X000C")
+	t.testSegmentedHexString("fe80::1%eth0",
+		"fe80:0000:0000:0000:0000:0000:0000:0001",
+		"0xfe80:0x0000:0x0000:0x0000:0x0000:0x0000:0x0000:0x0001",
+		"0XFE80:0X0000:0X0000:0X0000:0X0000:0X0000:0X0000:0X0001")
+
+	t.testAllocatorLists([]string{"10.0.0.0/24"}, []goip.BitCount{6, 4, 9},
+		[]string{"10.0.0.0/26", "10.0.0.64/28"},
+		[]string{"10.0.0.80/28", "10.0.0.96/27", "10.0.0.128/25"})
+	t.testAllocatorLists([]string{"10.0.1.0/24", "10.0.0.0/24", "10.0.3.0/24"}, nil,
+		nil,
+		[]string{"10.0.3.0/24", "10.0.0.0/23"})
+	t.testAllocatorLists([]string{"10.0.1.0/24", "10.0.3.0/24"}, []goip.BitCount{8, 8, 8},
+		[]string{"10.0.1.0/24", "10.0.3.0/24"},
+		nil)
+	t.testAllocatorLists(nil, []goip.BitCount{8}, nil, nil)
+
+	t.testCIDRWithMask("192.168.1.0/24", "192.168.1.0/24 255.255.255.0")
+	t.testCIDRWithMask("10.1.2.3/8", "10.1.2.3/8 255.0.0.0")
+	t.testCIDRWithMask("0.0.0.0/0", "0.0.0.0/0 0.0.0.0")
+	t.testCIDRWithMask("1.2.3.4/32", "1.2.3.4/32 255.255.255.255")
+	t.testCIDRWithMask("1.2.3.4", "")
+	t.testInvalidCIDRWithMask("192.168.1.0/24 255.255.0.0")
+	t.testInvalidCIDRWithMask("192.168.1.0/24")
+	t.testInvalidCIDRWithMask("192.168.1.0 255.255.255.0")
+	t.testInvalidCIDRWithMask("192.168.1.0/24 255.255.255.0 1")
+	t.testInvalidCIDRWithMask("192.168.1.0/24 255.255.255.0/24")
+	t.testInvalidCIDRWithMask("1::/24 ffff:ff00::")
+	t.testInvalidCIDRWithMask("")
+
+	t.testSolicitedNodeMulticast("fe80::2aa:ff:fe28:9c5a", "ff02::1:ff28:9c5a")
+	t.testSolicitedNodeMulticast("2001:db8::1", "ff02::1:ff00:1")
+	t.testSolicitedNodeMulticast("2001:db8::abcd:ef12/64", "ff02::1:ffcd:ef12")
+	t.testSolicitedNodeMulticast("fe80::1%eth0", "ff02::1:ff00:1")
+	t.testSolicitedNodeMulticast("2001:db8::/64", "")
+	t.testSolicitedNodeMulticast("ff02::1", "")
+	t.testSolicitedNodeMulticast("::1", "")
+	t.testSolicitedNodeMulticast("::", "")
+
+	t.testSupernets("1.2.3.0/24", 25, "1.2.3.0/24", "0.0.0.0/0")
+	t.testSupernets("1.2.3.4/24", 25, "1.2.3.0/24", "0.0.0.0/0")
+	t.testSupernets("1.2.3.4", 33, "1.2.3.4/32", "0.0.0.0/0")
+	t.testSupernets("0.0.0.0/0", 1, "0.0.0.0/0", "0.0.0.0/0")
+	t.testSupernets("2001:db8::/64", 65, "2001:db8::/64", "::/0")
+	t.testSupernets("2001:db8::1", 129, "2001:db8::1/128", "::/0")
+
+	t.testBitLayoutString("192.168.1.25/24", "192      168      1        25\n"+
+		"11000000 10101000 00000001|00011001\n"+
+		"network: 24 bits, host: 8 bits")
+	t.testBitLayoutString("10.1.2.3/12", "10       1         2        3\n"+
+		"00001010 0000|0001 00000010 00000011\n"+
+		"network: 12 bits, host: 20 bits")
+	t.testBitLayoutString("1.2.3.4", "1        2        3        4\n"+
+		"00000001 00000010 00000011 00000100\n"+
+		"no prefix length")
+	t.testBitLayoutString("1.2.3.4/0", " 1        2        3        4\n"+
+		"|00000001 00000010 00000011 00000100\n"+
+		"network: 0 bits, host: 32 bits")
+	t.testBitLayoutString("1.2.3.4/32", "1        2        3        4\n"+
+		"00000001 00000010 00000011 00000100|\n"+
+		"network: 32 bits, host: 0 bits")
+	t.testBitLayoutString("1::ab/64", "1                0                0                0                0                0                0                ab\n"+
+		"0000000000000001 0000000000000000 0000000000000000 0000000000000000|0000000000000000 0000000000000000 0000000000000000 0000000010101011\n"+
+		"network: 64 bits, host: 64 bits")
+
+	t.testAggregate([]string{"1.2.3.4", "1.2.3.5", "1.2.3.6", "1.2.3.7", "1.2.3.9"})
+	t.testAggregate([]string{"1.2.3.0/24", "1.2.4.0/24", "1.2.5.0/25", "1.2.3.128/25"})
+	if t.allowsRange() {
+		t.testAggregate([]string{"1.2-5.*.4", "1.2.3.4", "1.3.0.0/16"})
+		t.testAggregate([]string{"1:2:3:4:5:6:7-8:1-2", "1:2:3:4:5:6:7:3", "::ffff:1.2.3.4", "1.2.3.4-5"})
+	}
+	t.testAggregate([]string{"1.2.3.4", "::1", "1.2.3.5", "::2", "::3", "a:b:c:d::/64", "a:b:c:e::/64", "1.2.3.6"})
+	t.testAggregate([]string{"0.0.0.0/0", "1.2.3.4", "255.255.255.255"})
+	t.testAggregate([]string{"255.255.255.255", "255.255.255.254", "0.0.0.0"})
+	t.testAggregate([]string{"255.255.255.255"})
+	t.testAggregate([]string{"::/0", "::1", "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"})
+	t.testAggregateEmpty()
+}
+
+// testAggregate checks the package-level aggregation functions against the receiver-based Merge methods,
+// which merge the IPv4 and IPv6 addresses separately.
+func (t ipAddressTester) testAggregate(strs []string) {
+	var addrs []*goip.IPAddress
+	var ipv4Addrs []*goip.IPv4Address
+	var ipv6Addrs []*goip.IPv6Address
+	for _, str := range strs {
+		addr := t.createAddress(str).GetAddress()
+		addrs = append(addrs, addr)
+		if addr.IsIPv4() {
+			ipv4Addrs = append(ipv4Addrs, addr.ToIPv4())
+		} else {
+			ipv6Addrs = append(ipv6Addrs, addr.ToIPv6())
+		}
+	}
+
+	var ipv4PrefixBlocks, ipv4SequentialBlocks []*goip.IPv4Address
+	var ipv6PrefixBlocks, ipv6SequentialBlocks []*goip.IPv6Address
+	var expectedPrefixBlocks, expectedSequentialBlocks []*goip.IPAddress
+	if len(ipv4Addrs) > 0 {
+		ipv4PrefixBlocks = ipv4Addrs[0].MergeToPrefixBlocks(ipv4Addrs...)
+		ipv4SequentialBlocks = ipv4Addrs[0].MergeToSequentialBlocks(ipv4Addrs...)
+		for _, addr := range ipv4PrefixBlocks {
+			expectedPrefixBlocks = append(expectedPrefixBlocks, addr.ToIP())
+		}
+		for _, addr := range ipv4SequentialBlocks {
+			expectedSequentialBlocks = append(expectedSequentialBlocks, addr.ToIP())
+		}
+	}
+	if len(ipv6Addrs) > 0 {
+		ipv6PrefixBlocks = ipv6Addrs[0].MergeToPrefixBlocks(ipv6Addrs...)
+		ipv6SequentialBlocks = ipv6Addrs[0].MergeToSequentialBlocks(ipv6Addrs...)
+		for _, addr := range ipv6PrefixBlocks {
+			expectedPrefixBlocks = append(expectedPrefixBlocks, addr.ToIP())
+		}
+		for _, addr := range ipv6SequentialBlocks {
+			expectedSequentialBlocks = append(expectedSequentialBlocks, addr.ToIP())
+		}
+	}
+
+	// nil elements are ignored
+	withNil := append([]*goip.IPAddress{nil}, addrs...)
+	t.checkAggregate("AggregateToPrefixBlocks", strs, goip.AggregateToPrefixBlocks(withNil), expectedPrefixBlocks)
+	t.checkAggregate("AggregateToSequentialBlocks", strs, goip.AggregateToSequentialBlocks(withNil), expectedSequentialBlocks)
+
+	var actual, expected []*goip.IPAddress
+	for _, addr := range goip.AggregateIPv4ToPrefixBlocks(append(ipv4Addrs, nil)) {
+		actual = append(actual, addr.ToIP())
+	}
+	for _, addr := range ipv4PrefixBlocks {
+		expected = append(expected, addr.ToIP())
+	}
+	t.checkAggregate("AggregateIPv4ToPrefixBlocks", strs, actual, expected)
+
+	actual, expected = nil, nil
+	for _, addr := range goip.AggregateIPv4ToSequentialBlocks(ipv4Addrs) {
+		actual = append(actual, addr.ToIP())
+	}
+	for _, addr := range ipv4SequentialBlocks {
+		expected = append(expected, addr.ToIP())
+	}
+	t.checkAggregate("AggregateIPv4ToSequentialBlocks", strs, actual, expected)
+
+	actual, expected = nil, nil
+	for _, addr := range goip.AggregateIPv6ToPrefixBlocks(append(ipv6Addrs, nil)) {
+		actual = append(actual, addr.ToIP())
+	}
+	for _, addr := range ipv6PrefixBlocks {
+		expected = append(expected, addr.ToIP())
+	}
+	t.checkAggregate("AggregateIPv6ToPrefixBlocks", strs, actual, expected)
+
+	actual, expected = nil, nil
+	for _, addr := range goip.AggregateIPv6ToSequentialBlocks(ipv6Addrs) {
+		actual = append(actual, addr.ToIP())
+	}
+	for _, addr := range ipv6SequentialBlocks {
+		expected = append(expected, addr.ToIP())
+	}
+	t.checkAggregate("AggregateIPv6ToSequentialBlocks", strs, actual, expected)
+}
+
+func (t ipAddressTester) checkAggregate(name string, strs []string, actual, expected []*goip.IPAddress) {
+	if len(actual) != len(expected) {
+		t.addFailure(newFailure(fmt.Sprintf("%s of %v gave %v, expected %v", name, strs, actual, expected), nil))
+	} else {
+		for i, addr := range actual {
+			if !addr.Equal(expected[i]) || addr.String() != expected[i].String() {
+				t.addFailure(newIPAddrFailure(fmt.Sprintf("%s of %v gave %v, expected %v", name, strs, actual, expected), addr))
+				break
+			}
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testAggregateEmpty() {
+	if result := goip.AggregateToPrefixBlocks(nil); result != nil {
+		t.addFailure(newFailure(fmt.Sprintf("expected nil aggregating nil, got %v", result), nil))
+	} else if result = goip.AggregateToSequentialBlocks([]*goip.IPAddress{}); result != nil {
+		t.addFailure(newFailure(fmt.Sprintf("expected nil aggregating an empty slice, got %v", result), nil))
+	} else if result = goip.AggregateToPrefixBlocks([]*goip.IPAddress{nil, nil}); result != nil {
+		t.addFailure(newFailure(fmt.Sprintf("expected nil aggregating nil elements, got %v", result), nil))
+	} else if result := goip.AggregateIPv4ToPrefixBlocks(nil); result != nil {
+		t.addFailure(newFailure(fmt.Sprintf("expected nil aggregating nil IPv4, got %v", result), nil))
+	} else if result = goip.AggregateIPv4ToSequentialBlocks([]*goip.IPv4Address{nil}); result != nil {
+		t.addFailure(newFailure(fmt.Sprintf("expected nil aggregating nil IPv4 elements, got %v", result), nil))
+	} else if result := goip.AggregateIPv6ToPrefixBlocks([]*goip.IPv6Address{}); result != nil {
+		t.addFailure(newFailure(fmt.Sprintf("expected nil aggregating an empty IPv6 slice, got %v", result), nil))
+	} else if result = goip.AggregateIPv6ToSequentialBlocks(nil); result != nil {
+		t.addFailure(newFailure(fmt.Sprintf("expected nil aggregating nil IPv6, got %v", result), nil))
+	}
+	t.incrementTestCount()
 }
 
 func one28() *big.Int {
@@ -4932,6 +5631,1264 @@ func (t ipAddressTester) testIPv4Mapped(str string, expected bool) {
 	t.incrementTestCount()
 }
 
+func (t ipAddressTester) testFlagValue(str string, isValid bool) {
+	var zero goip.IPAddressString
+	if zero.String() != "" {
+		t.addFailure(newFailure("unexpected zero value string "+zero.String(), &zero))
+	}
+	flagVal := &zero
+	err := flagVal.Set(str)
+	if isValid {
+		if err != nil {
+			t.addFailure(newFailure("unexpected error "+err.Error(), flagVal))
+		} else if flagVal.String() != str {
+			t.addFailure(newFailure("flag value string mismatch, expected "+str, flagVal))
+		} else if !flagVal.Equal(goip.NewIPAddressString(str)) {
+			t.addFailure(newFailure("flag value mismatch, expected "+str, flagVal))
+		}
+	} else if err == nil {
+		t.addFailure(newFailure("expected error setting "+str, flagVal))
+	} else if flagVal.String() != "" {
+		t.addFailure(newFailure("flag value changed by invalid string "+str, flagVal))
+	}
+
+	params := new(address_string_param.IPAddressStringParamsBuilder).AllowIPv6(false).ToParams()
+	flagVal = goip.NewIPAddressStringFlag("1.2.3.4", params)
+	err = flagVal.Set(str)
+	if expectedValid := isValid && !strings.Contains(str, ":"); expectedValid != (err == nil) {
+		t.addFailure(newFailure(fmt.Sprint("flag validation options not applied, expected valid: ", expectedValid), flagVal))
+	} else if err != nil && flagVal.String() != "1.2.3.4" {
+		t.addFailure(newFailure("flag value changed by invalid string "+str, flagVal))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testWithBitSet(str string, n goip.BitCount, value bool, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	result, err := addr.WithBitSet(n, value)
+	if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if result.IsMultiple() {
+		// clearing the host bits must not produce the prefix block
+		t.addFailure(newIPAddrFailure("expected a single address, got count "+result.GetCount().String(), result))
+	} else if !result.WithoutPrefixLen().Equal(t.createAddress(expected).GetAddress()) {
+		t.addFailure(newIPAddrFailure("expected "+expected+" setting bit "+strconv.Itoa(int(n)), result))
+	} else if !result.GetPrefixLen().Equal(addr.GetPrefixLen()) {
+		t.addFailure(newIPAddrFailure("prefix length not retained", result))
+	} else if result.IsIPv6() && result.ToIPv6().GetZone() != addr.ToIPv6().GetZone() {
+		t.addFailure(newIPAddrFailure("zone not retained", result))
+	}
+
+	if _, err = addr.WithBitSet(addr.GetBitCount(), value); err == nil {
+		t.addFailure(newIPAddrFailure("expected error setting bit "+strconv.Itoa(int(addr.GetBitCount())), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testOperatorReserved(str string, expected bool) {
+	addr := t.createAddress(str).GetAddress()
+	if addr.IsOperatorReserved() != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("operator reserved mismatch, expected ", expected), addr))
+	} else if addr.IsIPv4() {
+		ipv4Addr := addr.ToIPv4()
+		if ipv4Addr.IsSharedAddressSpace() != expected {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("shared address space mismatch, expected ", expected), addr))
+		} else if expected && (ipv4Addr.IsPrivate() || ipv4Addr.IsLocal() || ipv4Addr.IsGloballyRoutable()) {
+			t.addFailure(newIPAddrFailure("shared address space must be neither private, local, nor globally routable", addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testDetect4in6Type(str string, expected goip.IPv4in6Type) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	if result := addr.Detect4in6Type(); result != expected {
+		t.addFailure(newIPAddrFailure("4-in-6 type mismatch "+result.String()+" expected "+expected.String(), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testTryCanonicalBlock(str string, isBlock bool) {
+	addr := t.createAddress(str).GetAddress()
+	result, err := addr.TryCanonicalBlock()
+	if isBlock {
+		if err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+		} else if result != addr {
+			t.addFailure(newIPAddrFailure("expected the same block, got "+result.String(), addr))
+		}
+	} else if err == nil {
+		t.addFailure(newIPAddrFailure("expected error for non-block, got "+result.String(), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testCompareStringSemantics(one, two string, expected int) {
+	oneStr, twoStr := t.createAddress(one), t.createAddress(two)
+	result, err := goip.CompareIPAddressStringSemantics(oneStr, twoStr)
+	if err != nil {
+		t.addFailure(newFailure("unexpected error "+err.Error(), oneStr))
+	} else if (result < 0) != (expected < 0) || (result > 0) != (expected > 0) {
+		t.addFailure(newFailure("comparison mismatch with "+two+", got "+strconv.Itoa(result)+" expected "+strconv.Itoa(expected), oneStr))
+	} else if reverse, _ := goip.CompareIPAddressStringSemantics(twoStr, oneStr); (reverse < 0) != (expected > 0) || (reverse > 0) != (expected < 0) {
+		t.addFailure(newFailure("reversed comparison mismatch with "+two+", got "+strconv.Itoa(reverse), oneStr))
+	}
+
+	if _, err = goip.CompareIPAddressStringSemantics(oneStr, t.createAddress("1.2.3.4.5")); err == nil {
+		t.addFailure(newFailure("expected error comparing with an invalid string", oneStr))
+	}
+	t.incrementTestCount()
+}
+
+// testCompareStringSemanticsError expects an error comparing the strings in either order
+func (t ipAddressTester) testCompareStringSemanticsError(oneStr, twoStr *goip.IPAddressString) {
+	if result, err := goip.CompareIPAddressStringSemantics(oneStr, twoStr); err == nil {
+		t.addFailure(newFailure("expected error comparing with "+twoStr.String()+", got "+strconv.Itoa(result), oneStr))
+	} else if result, err = goip.CompareIPAddressStringSemantics(twoStr, oneStr); err == nil {
+		t.addFailure(newFailure("expected error comparing in reverse with "+twoStr.String()+", got "+strconv.Itoa(result), oneStr))
+	}
+	t.incrementTestCount()
+}
+
+// testNetworkAddress tests the IPv4 network address and the IPv6 subnet-router anycast address,
+// applying the given prefix length, if not negative, without changing the address values
+func (t ipAddressTester) testNetworkAddress(str string, prefLen goip.BitCount, expected bool) {
+	addr := t.createAddress(str).GetAddress()
+	if prefLen >= 0 {
+		addr = addr.SetPrefixLen(prefLen)
+	}
+	var result bool
+	if addr.IsIPv4() {
+		result = addr.ToIPv4().IsNetworkAddress()
+	} else {
+		result = addr.ToIPv6().IsSubnetRouterAnycast()
+	}
+	if result != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("network address mismatch, expected ", expected), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testSocks5Address expects an error when the expected bytes are nil
+func (t ipAddressTester) testSocks5Address(str string, expected []byte) {
+	addr := t.createAddress(str).GetAddress()
+	result, err := addr.ToSocks5Address()
+	if expected == nil {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error for a subnet", addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if !bytes.Equal(result, expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprintf("SOCKS5 bytes %v do not match expected %v", result, expected), addr))
+	} else if back, err := goip.NewIPAddressFromSocks5(result); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if !back.Equal(addr) {
+		t.addFailure(newIPAddrFailure("SOCKS5 round trip produced "+back.String(), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidSocks5Address(data []byte) {
+	if addr, err := goip.NewIPAddressFromSocks5(data); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprintf("expected error for SOCKS5 bytes %v", data), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testBGPLargeCommunity(str string, asn, val1, val2 uint32) {
+	resultASN, resultVal1, resultVal2, err := goip.ParseBGPLargeCommunity(str)
+	if err != nil {
+		t.addFailure(newAddressItemFailure("unexpected error parsing "+str+": "+err.Error(), nil))
+	} else if resultASN != asn || resultVal1 != val1 || resultVal2 != val2 {
+		t.addFailure(newAddressItemFailure(fmt.Sprintf("parsed %d %d %d from %s, expected %d %d %d", resultASN, resultVal1, resultVal2, str, asn, val1, val2), nil))
+	} else if formatted := goip.NewBGPLargeCommunity(asn, val1, val2); formatted != strings.TrimSpace(str) {
+		t.addFailure(newAddressItemFailure("formatted "+formatted+", expected "+strings.TrimSpace(str), nil))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidBGPLargeCommunity(str string) {
+	if _, _, _, err := goip.ParseBGPLargeCommunity(str); err == nil {
+		t.addFailure(newAddressItemFailure("expected error parsing "+str, nil))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testPrefixLengthIterator(str, expectedFirst, expectedLast string) {
+	addr := t.createAddress(str).GetAddress()
+	var results []*goip.IPAddress
+	for iter := addr.PrefixLengthIterator(); iter.HasNext(); {
+		results = append(results, iter.Next())
+	}
+
+	if len(results) != addr.GetBitCount()+1 {
+		t.addFailure(newIPAddrFailure("iterated "+strconv.Itoa(len(results))+" prefix lengths", addr))
+	} else if !results[0].Equal(t.createAddress(expectedFirst).GetAddress()) {
+		t.addFailure(newIPAddrFailure("first block "+results[0].String()+" does not match expected "+expectedFirst, addr))
+	} else if !results[len(results)-1].Equal(t.createAddress(expectedLast).GetAddress()) {
+		t.addFailure(newIPAddrFailure("last block "+results[len(results)-1].String()+" does not match expected "+expectedLast, addr))
+	} else {
+		var versionResults []*goip.IPAddress
+		if addr.IsIPv4() {
+			for iter := addr.ToIPv4().PrefixLengthIterator(); iter.HasNext(); {
+				versionResults = append(versionResults, iter.Next().ToIP())
+			}
+		} else {
+			for iter := addr.ToIPv6().PrefixLengthIterator(); iter.HasNext(); {
+				versionResults = append(versionResults, iter.Next().ToIP())
+			}
+		}
+		if len(versionResults) != len(results) {
+			t.addFailure(newIPAddrFailure("version iterator produced "+strconv.Itoa(len(versionResults))+" prefix lengths", addr))
+		} else {
+			for i, result := range results {
+				if !result.Equal(addr.ToPrefixBlockLen(i)) || !result.GetPrefixLen().Equal(goip.ToPrefixLen(i)) {
+					t.addFailure(newIPAddrFailure("block "+result.String()+" does not match prefix length "+strconv.Itoa(i), addr))
+					break
+				} else if !versionResults[i].Equal(result) {
+					t.addFailure(newIPAddrFailure("version iterator produced "+versionResults[i].String()+" instead of "+result.String(), addr))
+					break
+				}
+			}
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testUint32NetworkMask expects an error when the address is neither prefixed nor a network mask, in which case the expected mask is ignored
+func (t ipAddressTester) testUint32NetworkMask(str string, expected uint32) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	mask, err := addr.ToUint32NetworkMask()
+	if !addr.IsPrefixed() && addr.GetBlockMaskPrefixLen(true) == nil {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error for address that is not a network mask", addr.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr.ToIP()))
+	} else if mask != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprintf("mask %x does not match expected %x", mask, expected), addr.ToIP()))
+	} else if maskAddr, err := goip.NewIPv4AddressFromUint32NetworkMask(mask); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr.ToIP()))
+	} else if maskAddr.Uint32Value() != mask {
+		t.addFailure(newIPAddrFailure("mask address "+maskAddr.String()+" does not match", addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidUint32NetworkMask(mask uint32) {
+	if addr, err := goip.NewIPv4AddressFromUint32NetworkMask(mask); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprintf("expected error for mask %x", mask), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testCompactJSON expects an error when the expected JSON is empty
+func (t ipAddressTester) testCompactJSON(str, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	result, err := addr.MarshalCompactJSON()
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error marshalling a subnet", addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if string(result) != expected {
+		t.addFailure(newIPAddrFailure("compact JSON "+string(result)+" does not match expected "+expected, addr))
+	} else {
+		var back goip.IPAddress
+		if err = back.UnmarshalCompactJSON(result); err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+		} else if !back.Equal(addr.WithoutPrefixLen()) || back.IsPrefixed() {
+			t.addFailure(newIPAddrFailure("compact JSON round trip produced "+back.String(), addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testUnmarshalCompactJSON expects an error when the expected address string is empty
+func (t ipAddressTester) testUnmarshalCompactJSON(data, expected string) {
+	var addr goip.IPAddress
+	err := addr.UnmarshalCompactJSON([]byte(data))
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error unmarshalling "+data, &addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error unmarshalling "+data+": "+err.Error(), nil))
+	} else if !addr.Equal(t.createAddress(expected).GetAddress()) {
+		t.addFailure(newIPAddrFailure("unmarshalled "+data+" does not match expected "+expected, &addr))
+	}
+	t.incrementTestCount()
+}
+
+// testNatPt expects the address not to be a NAT-PT address when the expected IPv4 string is empty
+func (t ipAddressTester) testNatPt(str, expectedIPv4 string) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	ipv4, ok := addr.GetNatPtIPv4()
+	if expectedIPv4 == "" {
+		if ok || addr.IsNatPt() {
+			t.addFailure(newIPAddrFailure("not expected to be NAT-PT", addr.ToIP()))
+		}
+	} else if !ok || !addr.IsNatPt() {
+		t.addFailure(newIPAddrFailure("expected to be NAT-PT", addr.ToIP()))
+	} else if !ipv4.Equal(t.createAddress(expectedIPv4).GetAddress().ToIPv4()) {
+		t.addFailure(newIPAddrFailure("embedded "+ipv4.String()+" does not match expected "+expectedIPv4, addr.ToIP()))
+	} else if natPt, err := goip.NewIPv6FromNatPt(ipv4.SetPrefixLen(24)); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr.ToIP()))
+	} else if !natPt.Equal(addr) || natPt.IsPrefixed() {
+		t.addFailure(newIPAddrFailure("constructed NAT-PT "+natPt.String()+" does not match", addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testNewNatPt constructs from a nil IPv4 address when the IPv4 string is empty, expecting a nil address,
+// and otherwise expects an error when the expected string is empty
+func (t ipAddressTester) testNewNatPt(ipv4Str, expected string) {
+	var ipv4 *goip.IPv4Address
+	if ipv4Str != "" {
+		ipv4 = t.createAddress(ipv4Str).GetAddress().ToIPv4()
+	}
+	natPt, err := goip.NewIPv6FromNatPt(ipv4)
+	if ipv4 == nil {
+		if natPt != nil || err != nil {
+			t.addFailure(newFailure(fmt.Sprint("expected nil constructing NAT-PT from nil, got ", natPt, " and error ", err), nil))
+		}
+	} else if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error constructing NAT-PT, got "+natPt.String(), ipv4.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), ipv4.ToIP()))
+	} else if !natPt.Equal(t.createAddress(expected).GetAddress()) {
+		t.addFailure(newIPAddrFailure("constructed NAT-PT "+natPt.String()+" does not match expected "+expected, ipv4.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testGloballyRoutable(str string, expected bool) {
+	addr := t.createAddress(str).GetAddress()
+	var versionResult bool
+	if addr.IsIPv4() {
+		versionResult = addr.ToIPv4().IsGloballyRoutable()
+	} else {
+		versionResult = addr.ToIPv6().IsGloballyRoutable()
+	}
+	if addr.IsGloballyRoutable() != expected || versionResult != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("globally routable mismatch, expected ", expected), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testIPAddressHTTPURL expects an error when the expected URL is empty
+func (t ipAddressTester) testIPAddressHTTPURL(str, scheme string, port uint16, path, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	result, err := addr.ToHTTPURL(scheme, port, path)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error, got URL "+result, addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if result != expected {
+		t.addFailure(newIPAddrFailure("URL "+result+" does not match expected "+expected, addr))
+	}
+	t.incrementTestCount()
+}
+
+// testBitsAndByteCount uses the zero IPAddress when the string is empty
+func (t ipAddressTester) testBitsAndByteCount(str string, expectedBits, expectedBytes int) {
+	addr := &goip.IPAddress{}
+	if str != "" {
+		addr = t.createAddress(str).GetAddress()
+	}
+	if addr.Bits() != expectedBits || addr.Bits() != addr.GetBitCount() {
+		t.addFailure(newIPAddrFailure("bit count "+strconv.Itoa(addr.Bits())+" does not match expected "+strconv.Itoa(expectedBits), addr))
+	} else if addr.ByteCount() != expectedBytes || addr.ByteCount() != addr.GetByteCount() {
+		t.addFailure(newIPAddrFailure("byte count "+strconv.Itoa(addr.ByteCount())+" does not match expected "+strconv.Itoa(expectedBytes), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testEnumerate expects an error when the expected count is negative
+func (t ipAddressTester) testEnumerate(lowerStr, upperStr string, maxCount, expectedCount int) {
+	lower, upper := t.createAddress(lowerStr).GetAddress(), t.createAddress(upperStr).GetAddress()
+	rng := lower.SpanWithRange(upper)
+	addrs, err := rng.Enumerate(maxCount)
+	if expectedCount < 0 {
+		if err == nil {
+			t.addFailure(newSeqRangeFailure("expected error enumerating with max count "+strconv.Itoa(maxCount), rng))
+		}
+	} else if err != nil {
+		t.addFailure(newSeqRangeFailure("unexpected error "+err.Error(), rng))
+	} else if len(addrs) != expectedCount {
+		t.addFailure(newSeqRangeFailure("enumerated "+strconv.Itoa(len(addrs))+" addresses, expected "+strconv.Itoa(expectedCount), rng))
+	} else if !addrs[0].Equal(rng.GetLower()) || !addrs[len(addrs)-1].Equal(rng.GetUpper()) {
+		t.addFailure(newSeqRangeFailure("enumerated addresses do not span the range", rng))
+	} else {
+		for i := 1; i < len(addrs); i++ {
+			if addrs[i].Compare(addrs[i-1].Increment(1)) != 0 {
+				t.addFailure(newSeqRangeFailure("enumerated addresses not in increasing order at "+addrs[i].String(), rng))
+				break
+			}
+		}
+	}
+
+	var nilRange *goip.IPAddressSeqRange
+	if addrs, err = nilRange.Enumerate(0); err != nil || len(addrs) != 0 {
+		t.addFailure(newSeqRangeFailure("expected no addresses from the nil range", nilRange))
+	}
+	t.incrementTestCount()
+}
+
+// testFirstNBits expects an error when the expected string is empty
+func (t ipAddressTester) testFirstNBits(str string, n goip.BitCount, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	result, err := addr.FirstNBits(n)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error for bit count "+strconv.Itoa(n)+", got "+result.String(), addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if result.IsMultiple() || !result.WithoutPrefixLen().Equal(t.createAddress(expected).GetAddress()) {
+		t.addFailure(newIPAddrFailure("first bits "+result.String()+" do not match expected "+expected, addr))
+	} else if !result.GetPrefixLen().Equal(goip.ToPrefixLen(n)) {
+		t.addFailure(newIPAddrFailure("prefix length of "+result.String()+" is not "+strconv.Itoa(n), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testMaskPair expects an error when the expected masks are empty
+func (t ipAddressTester) testMaskPair(str, expectedNetworkMask, expectedWildcardMask string) {
+	addr := t.createAddress(str).GetAddress()
+	networkMask, wildcardMask, err := addr.ToMaskPair()
+	if expectedNetworkMask == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error for address with no prefix length", addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if !networkMask.WithoutPrefixLen().Equal(t.createAddress(expectedNetworkMask).GetAddress()) {
+		t.addFailure(newIPAddrFailure("network mask "+networkMask.String()+" does not match expected "+expectedNetworkMask, addr))
+	} else if !wildcardMask.WithoutPrefixLen().Equal(t.createAddress(expectedWildcardMask).GetAddress()) {
+		t.addFailure(newIPAddrFailure("wildcard mask "+wildcardMask.String()+" does not match expected "+expectedWildcardMask, addr))
+	} else if addr.IsIPv4() {
+		ipv4NetworkMask, ipv4WildcardMask, _ := addr.ToIPv4().ToMaskPair()
+		if !ipv4NetworkMask.ToIP().Equal(networkMask) || !ipv4WildcardMask.ToIP().Equal(wildcardMask) {
+			t.addFailure(newIPAddrFailure("IPv4 mask pair "+ipv4NetworkMask.String()+" "+ipv4WildcardMask.String()+" does not match", addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testSetHostBits expects an error when the expected string is empty
+func (t ipAddressTester) testSetHostBits(str, hostStr, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	result, err := addr.SetHostBits(t.createAddress(hostStr).GetAddress())
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error setting host bits from "+hostStr+", got "+result.String(), addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if result.IsMultiple() || !result.WithoutPrefixLen().Equal(t.createAddress(expected).GetAddress()) {
+		t.addFailure(newIPAddrFailure("result "+result.String()+" does not match expected "+expected, addr))
+	} else if !result.GetPrefixLen().Equal(addr.GetPrefixLen()) {
+		t.addFailure(newIPAddrFailure("prefix length of "+result.String()+" not retained", addr))
+	}
+	t.incrementTestCount()
+}
+
+// testNextAvailableAddress expects an error when the expected string is empty, and passes a nil trie when usedStrs is nil
+func (t ipAddressTester) testNextAvailableAddress(subnetStr string, usedStrs []string, expected string) {
+	subnet := t.createAddress(subnetStr).GetAddress()
+	var result *goip.IPAddress
+	var err error
+	if subnet.IsIPv4() {
+		var trie *goip.IPv4AddressTrie
+		if usedStrs != nil {
+			trie = &goip.IPv4AddressTrie{}
+		}
+		for _, str := range usedStrs {
+			trie.Add(t.createAddress(str).GetAddress().ToIPv4())
+		}
+		var ipv4Result *goip.IPv4Address
+		if ipv4Result, err = goip.NextAvailableIPv4Address(subnet.ToIPv4(), trie); err == nil {
+			result = ipv4Result.ToIP()
+		}
+	} else {
+		var trie *goip.IPv6AddressTrie
+		if usedStrs != nil {
+			trie = &goip.IPv6AddressTrie{}
+		}
+		for _, str := range usedStrs {
+			trie.Add(t.createAddress(str).GetAddress().ToIPv6())
+		}
+		var ipv6Result *goip.IPv6Address
+		if ipv6Result, err = goip.NextAvailableIPv6Address(subnet.ToIPv6(), trie); err == nil {
+			result = ipv6Result.ToIP()
+		}
+	}
+
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected no available address, got "+result.String(), subnet))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), subnet))
+	} else if !result.Equal(t.createAddress(expected).GetAddress()) {
+		t.addFailure(newIPAddrFailure("next available "+result.String()+" does not match expected "+expected, subnet))
+	}
+	t.incrementTestCount()
+}
+
+// testStringMask expects an error when the expected string is empty
+func (t ipAddressTester) testStringMask(str, maskStr, expected string) {
+	addrStr := t.createAddress(str)
+	result, err := addrStr.Mask(t.createAddress(maskStr))
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newFailure("expected error masking with "+maskStr+", got "+result.String(), addrStr))
+		}
+	} else if err != nil {
+		t.addFailure(newFailure("unexpected error "+err.Error(), addrStr))
+	} else if !result.Equal(t.createAddress(expected).GetAddress()) {
+		t.addFailure(newFailure("masked "+result.String()+" does not match expected "+expected, addrStr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testAnnotatedString(str string, options goip.AnnotationOptions, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.ToCustomAnnotatedString(options); result != expected {
+		t.addFailure(newIPAddrFailure("annotated string "+result+" does not match expected "+expected, addr))
+	} else if options == goip.DefaultAnnotationOptions && addr.ToAnnotatedString() != result {
+		t.addFailure(newIPAddrFailure("default annotated string "+addr.ToAnnotatedString()+" does not match "+result, addr))
+	}
+	t.incrementTestCount()
+}
+
+// testMaskConstructors expects an error for each version for which the expected masks are empty
+func (t ipAddressTester) testMaskConstructors(prefLen goip.BitCount, expectedIPv4Network, expectedIPv4Host, expectedIPv6Network, expectedIPv6Host string) {
+	check := func(mask *goip.IPAddress, err error, expected string) {
+		if expected == "" {
+			if err == nil {
+				t.addFailure(newIPAddrFailure("expected error for prefix length "+strconv.Itoa(prefLen), mask))
+			}
+		} else if err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error for prefix length "+strconv.Itoa(prefLen)+": "+err.Error(), nil))
+		} else if !mask.Equal(t.createAddress(expected).GetAddress()) || mask.IsPrefixed() {
+			t.addFailure(newIPAddrFailure("mask for prefix length "+strconv.Itoa(prefLen)+" does not match expected "+expected, mask))
+		}
+	}
+	ipv4Network, err := goip.NewIPv4NetworkMask(prefLen)
+	check(ipv4Network.ToIP(), err, expectedIPv4Network)
+	ipv4Host, err := goip.NewIPv4HostMask(prefLen)
+	check(ipv4Host.ToIP(), err, expectedIPv4Host)
+	ipv6Network, err := goip.NewIPv6NetworkMask(prefLen)
+	check(ipv6Network.ToIP(), err, expectedIPv6Network)
+	ipv6Host, err := goip.NewIPv6HostMask(prefLen)
+	check(ipv6Host.ToIP(), err, expectedIPv6Host)
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testBase64(str string, urlSafe bool, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.ToBase64String(urlSafe); result != expected {
+		t.addFailure(newIPAddrFailure("base64 "+result+" does not match expected "+expected, addr))
+	} else if back, err := goip.NewIPAddressFromBase64(result, addr.GetIPVersion(), urlSafe); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr))
+	} else if !back.Equal(addr.WithoutPrefixLen()) {
+		t.addFailure(newIPAddrFailure("base64 round trip produced "+back.String(), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidBase64(str string, version goip.IPVersion, urlSafe bool) {
+	if addr, err := goip.NewIPAddressFromBase64(str, version, urlSafe); err == nil {
+		t.addFailure(newIPAddrFailure("expected error decoding "+str, addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testStrictContains(str, otherStr string, expected bool) {
+	addr, other := t.createAddress(str).GetAddress(), t.createAddress(otherStr).GetAddress()
+	if result := addr.StrictContains(other); result != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("strict containment of ", other, " is ", result, ", expected ", expected), addr))
+	} else if result && (!addr.Contains(other) || other.StrictContains(addr)) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("strict containment of ", other, " inconsistent with containment"), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testCompressedStrings(str, expectedCanonicalCompressed, expectedMaxCompressed string) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	if result := addr.ToCanonicalCompressedString(); result != expectedCanonicalCompressed {
+		t.addFailure(newIPAddrFailure("RFC 5952 string "+result+" does not match expected "+expectedCanonicalCompressed, addr.ToIP()))
+	} else if result = addr.ToMaxCompressedString(); result != expectedMaxCompressed {
+		t.addFailure(newIPAddrFailure("maximally compressed string "+result+" does not match expected "+expectedMaxCompressed, addr.ToIP()))
+	} else if !addr.IsMultiple() && addr.ToCanonicalCompressedString() != addr.ToCanonicalString() {
+		t.addFailure(newIPAddrFailure("RFC 5952 string does not match the canonical string "+addr.ToCanonicalString(), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testURLHostString(str, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.ToURLHostString(); result != expected {
+		t.addFailure(newIPAddrFailure("URL host "+result+" does not match expected "+expected, addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testPackedDecimal(str, expected string) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	if result := addr.ToPackedDecimalString(); result != expected {
+		t.addFailure(newIPAddrFailure("packed decimal string "+result+" does not match expected "+expected, addr.ToIP()))
+	} else if back, err := goip.NewIPv4AddressFromPackedDecimal(result); err != nil {
+		t.addFailure(newIPAddrFailure("failed to parse packed decimal string "+result+": "+err.Error(), addr.ToIP()))
+	} else if !back.Equal(addr.GetLower().WithoutPrefixLen()) {
+		t.addFailure(newIPAddrFailure("packed decimal round trip produced "+back.String(), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidPackedDecimal(str string) {
+	if addr, err := goip.NewIPv4AddressFromPackedDecimal(str); err == nil {
+		t.addFailure(newIPAddrFailure("expected error parsing packed decimal "+str, addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testZeroPaddedHex(str, expected string) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	if result := addr.ToZeroPaddedHexString(); result != expected {
+		t.addFailure(newIPAddrFailure("zero-padded hex string "+result+" does not match expected "+expected, addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testMulticastSourceBytes checks the IGMPv3 or MLDv2 source record bytes of the given address, with nil expected bytes indicating an error is expected
+func (t ipAddressTester) testMulticastSourceBytes(str string, expected []byte) {
+	addr := t.createAddress(str).GetAddress()
+	var result []byte
+	var err error
+	var back *goip.IPAddress
+	if addr.IsIPv4() {
+		if result, err = addr.ToIPv4().ToIGMPv3Bytes(); err == nil {
+			ipv4Addr, backErr := goip.NewIPv4AddressFromIGMPv3Bytes(result)
+			back, err = ipv4Addr.ToIP(), backErr
+		}
+	} else if result, err = addr.ToIPv6().ToMLDv2Bytes(); err == nil {
+		ipv6Addr, backErr := goip.NewIPv6AddressFromMLDv2Bytes(result)
+		back, err = ipv6Addr.ToIP(), backErr
+	}
+	if expected == nil {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error for source record bytes", addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error for source record bytes: "+err.Error(), addr))
+	} else if !bytes.Equal(result, expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("source record bytes ", result, " do not match expected ", expected), addr))
+	} else if !bytes.Equal(back.Bytes(), expected) || back.IsMultiple() {
+		t.addFailure(newIPAddrFailure("source record bytes round trip produced "+back.String(), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidMulticastSourceBytes(data []byte) {
+	if addr, err := goip.NewIPv4AddressFromIGMPv3Bytes(data); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error for IGMPv3 bytes ", data), addr.ToIP()))
+	} else if addr, err := goip.NewIPv6AddressFromMLDv2Bytes(data); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error for MLDv2 bytes ", data), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testOnionV3(str, expected string) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	lower := addr.GetLower().WithoutPrefixLen()
+	if result := goip.OnionV3FromIPv6(addr); result != expected {
+		t.addFailure(newIPAddrFailure("onion string "+result+" does not match expected "+expected, addr.ToIP()))
+	} else if back, err := goip.IPv6AddressFromOnionV3Style(result); err != nil {
+		t.addFailure(newIPAddrFailure("failed to decode onion string "+result+": "+err.Error(), addr.ToIP()))
+	} else if !back.Equal(lower) {
+		t.addFailure(newIPAddrFailure("onion round trip produced "+back.String(), addr.ToIP()))
+	} else if back, err = goip.IPv6AddressFromOnionV3Style(strings.ToUpper(strings.TrimSuffix(result, ".onion"))); err != nil || !back.Equal(lower) {
+		t.addFailure(newIPAddrFailure("failed to decode upper-case onion string without suffix", addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidOnionV3(str string) {
+	if addr, err := goip.IPv6AddressFromOnionV3Style(str); err == nil {
+		t.addFailure(newIPAddrFailure("expected error decoding onion string "+str, addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testBitString(str, expected, expectedWithPrefixLen string) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.ToBitString(); result != expected {
+		t.addFailure(newIPAddrFailure("bit string "+result+" does not match expected "+expected, addr))
+	} else if result = addr.ToBitStringWithPrefixLen(); result != expectedWithPrefixLen {
+		t.addFailure(newIPAddrFailure("bit string "+result+" does not match expected "+expectedWithPrefixLen, addr))
+	} else if back, err := goip.NewIPAddressFromBitString(result); err != nil {
+		t.addFailure(newIPAddrFailure("failed to parse bit string "+result+": "+err.Error(), addr))
+	} else if !back.GetLower().Equal(addr.GetLower()) || !back.GetPrefixLen().Equal(addr.GetPrefixLen()) {
+		t.addFailure(newIPAddrFailure("bit string round trip produced "+back.String(), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidBitString(str string) {
+	if addr, err := goip.NewIPAddressFromBitString(str); err == nil {
+		t.addFailure(newIPAddrFailure("expected error parsing bit string "+str, addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testCIDRSummary(str string, prefixLen goip.BitCount,
+	expectedNetwork, expectedBroadcast, expectedFirst, expectedLast string, expectedCount int64, expectedMask, expectedWildcard string) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	report := addr.CIDRSummary(prefixLen)
+	expectedPrefixLen := min(max(prefixLen, 0), goip.IPv4BitCount)
+	if report.NetworkAddress.String() != expectedNetwork || report.BroadcastAddress.String() != expectedBroadcast {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("network and broadcast ", report.NetworkAddress, " and ", report.BroadcastAddress,
+			" do not match expected ", expectedNetwork, " and ", expectedBroadcast), addr.ToIP()))
+	} else if report.FirstHost.String() != expectedFirst || report.LastHost.String() != expectedLast {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("host range ", report.FirstHost, " to ", report.LastHost,
+			" does not match expected ", expectedFirst, " to ", expectedLast), addr.ToIP()))
+	} else if report.HostCount != expectedCount || report.PrefixLen != expectedPrefixLen {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("host count ", report.HostCount, " and prefix length ", report.PrefixLen,
+			" do not match expected ", expectedCount, " and ", expectedPrefixLen), addr.ToIP()))
+	} else if report.NetworkMask != expectedMask || report.WildcardMask != expectedWildcard {
+		t.addFailure(newIPAddrFailure("masks "+report.NetworkMask+" and "+report.WildcardMask+
+			" do not match expected "+expectedMask+" and "+expectedWildcard, addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testPrefixHierarchy checks the hierarchy of the given blocks, written with each node in square brackets followed by its children
+func (t ipAddressTester) testPrefixHierarchy(strs []string, expected string) {
+	var addrs []*goip.IPAddress
+	for _, str := range strs {
+		addrs = append(addrs, t.createAddress(str).GetAddress())
+	}
+	hierarchy, err := goip.BuildPrefixHierarchy(addrs)
+	if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error building hierarchy: "+err.Error(), nil))
+	} else if hierarchy.Root.Prefix != nil {
+		t.addFailure(newIPAddrFailure("hierarchy root has prefix", hierarchy.Root.Prefix))
+	} else {
+		var writeNodes func(nodes []*goip.PrefixNode) string
+		writeNodes = func(nodes []*goip.PrefixNode) string {
+			strs := make([]string, len(nodes))
+			for i, node := range nodes {
+				strs[i] = "[" + node.Prefix.String()
+				if len(node.Children) > 0 {
+					strs[i] += " " + writeNodes(node.Children)
+				}
+				strs[i] += "]"
+			}
+			return strings.Join(strs, " ")
+		}
+		if result := writeNodes(hierarchy.Root.Children); result != expected {
+			t.addFailure(newIPAddrFailure("hierarchy "+result+" does not match expected "+expected, nil))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidPrefixHierarchy() {
+	block := t.createAddress("1.2.3.0/24").GetAddress()
+	for _, invalid := range []*goip.IPAddress{nil, {}, goip.NewIPAddressString("1.2.3-4.5").GetAddress()} {
+		if _, err := goip.BuildPrefixHierarchy([]*goip.IPAddress{block, invalid}); err == nil {
+			t.addFailure(newIPAddrFailure("expected error building hierarchy", invalid))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testHostIterator(str string, expectedCount int, expectedFirst, expectedLast string) {
+	addr := t.createAddress(str).GetAddress()
+	var hosts []*goip.IPAddress
+	if addr.IsIPv4() {
+		for iter := addr.ToIPv4().HostIterator(); iter.HasNext(); {
+			hosts = append(hosts, iter.Next().ToIP())
+		}
+	} else {
+		for iter := addr.ToIPv6().HostIterator(); iter.HasNext(); {
+			hosts = append(hosts, iter.Next().ToIP())
+		}
+	}
+	if len(hosts) != expectedCount {
+		t.addFailure(newIPAddrFailure("host count "+strconv.Itoa(len(hosts))+" does not match expected "+strconv.Itoa(expectedCount), addr))
+	} else if first, last := hosts[0].String(), hosts[len(hosts)-1].String(); first != expectedFirst || last != expectedLast {
+		t.addFailure(newIPAddrFailure("hosts "+first+" to "+last+" do not match expected "+expectedFirst+" to "+expectedLast, addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testAllowListHandler(str, remoteAddr string, expectedAllowed bool) {
+	addr := t.createAddress(str).GetAddress()
+	handler := addr.NewAllowListHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	expectedStatus := http.StatusForbidden
+	if expectedAllowed {
+		expectedStatus = http.StatusNoContent
+	}
+	if recorder.Code != expectedStatus {
+		t.addFailure(newIPAddrFailure("status "+strconv.Itoa(recorder.Code)+" for remote address "+remoteAddr+
+			" does not match expected "+strconv.Itoa(expectedStatus), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testGrowShrinkPrefix(str, expectedGrown, expectedShrunk string) {
+	addr := t.createAddress(str).GetAddress()
+	if grown := addr.GrowPrefix(); grown.String() != expectedGrown || !grown.Equal(addr.AdjustPrefixLen(1)) {
+		t.addFailure(newIPAddrFailure("grown prefix "+grown.String()+" does not match expected "+expectedGrown, addr))
+	} else if shrunk := addr.ShrinkPrefix(); shrunk.String() != expectedShrunk || !shrunk.Equal(addr.AdjustPrefixLen(-1)) {
+		t.addFailure(newIPAddrFailure("shrunk prefix "+shrunk.String()+" does not match expected "+expectedShrunk, addr))
+	}
+	t.incrementTestCount()
+}
+
+// testReverseDNS checks parsing the given PTR name, with an empty expected string indicating an error is expected.
+// For individual addresses, the PTR name of the result must also parse back to the result.
+func (t ipAddressTester) testReverseDNS(ptrName, expected string) {
+	addr, err := goip.NewIPAddressFromReverseDNS(ptrName)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error parsing reverse DNS name "+ptrName, addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error parsing reverse DNS name "+ptrName+": "+err.Error(), nil))
+	} else if addr.String() != expected {
+		t.addFailure(newIPAddrFailure("reverse DNS name "+ptrName+" does not match expected "+expected, addr))
+	} else if !addr.IsPrefixed() {
+		if name, err := addr.ToReverseDNSString(); err != nil {
+			t.addFailure(newIPAddrFailure("unexpected error producing reverse DNS name: "+err.Error(), addr))
+		} else if back, err := goip.NewIPAddressFromReverseDNS(name); err != nil || !back.Equal(addr) {
+			t.addFailure(newIPAddrFailure("reverse DNS round trip failed for "+name, addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testConcatenateIPv6Sections joins the first highCount segments of the high address with the last lowCount segments of the low address,
+// with an empty expected string indicating an error is expected
+func (t ipAddressTester) testConcatenateIPv6Sections(highStr string, highCount int, lowStr string, lowCount int, expected string) {
+	high := t.createAddress(highStr).GetAddress().ToIPv6()
+	low := t.createAddress(lowStr).GetAddress().ToIPv6()
+	highSection := high.GetSubSection(0, highCount)
+	lowSection := low.GetSubSection(goip.IPv6SegmentCount-lowCount, goip.IPv6SegmentCount)
+	if highCount+lowCount > goip.IPv6SegmentCount {
+		lowSection = low.GetSection()
+	}
+	section, err := goip.ConcatenateIPv6Sections(highSection, lowSection)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error concatenating with "+lowStr, high.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error concatenating with "+lowStr+": "+err.Error(), high.ToIP()))
+	} else if addr, err := goip.NewIPv6Address(section); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error constructing concatenation: "+err.Error(), high.ToIP()))
+	} else if addr.String() != expected {
+		t.addFailure(newIPAddrFailure("concatenation "+addr.String()+" does not match expected "+expected, high.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testConcatenateIPv4AndIPv6Sections checks embedding the IPv4 address at the given bit index,
+// with an empty expected string indicating an error is expected
+func (t ipAddressTester) testConcatenateIPv4AndIPv6Sections(ipv4Str, ipv6Str string, bitIndex int, expected string) {
+	ipv4 := t.createAddress(ipv4Str).GetAddress().ToIPv4()
+	ipv6 := t.createAddress(ipv6Str).GetAddress().ToIPv6()
+	section, err := goip.ConcatenateIPv4AndIPv6Sections(ipv4.GetSection(), ipv6.GetSection(), bitIndex)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("expected error embedding ", ipv4, " at bit ", bitIndex), ipv6.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("unexpected error embedding ", ipv4, " at bit ", bitIndex, ": ", err), ipv6.ToIP()))
+	} else if addr, err := goip.NewIPv6Address(section); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error constructing embedded address: "+err.Error(), ipv6.ToIP()))
+	} else if addr.String() != expected {
+		t.addFailure(newIPAddrFailure("embedded address "+addr.String()+" does not match expected "+expected, ipv6.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testNetworkHostBoundary checks the network and host boundaries, with empty expected strings indicating errors are expected
+func (t ipAddressTester) testNetworkHostBoundary(str, expectedNetwork, expectedHost string) {
+	addr := t.createAddress(str).GetAddress()
+	network, networkErr := addr.ToNetworkBoundary()
+	host, hostErr := addr.ToHostBoundary()
+	if expectedNetwork == "" {
+		if networkErr == nil || hostErr == nil {
+			t.addFailure(newIPAddrFailure("expected errors for boundaries", addr))
+		}
+	} else if networkErr != nil || hostErr != nil {
+		t.addFailure(newIPAddrFailure("unexpected error for boundaries", addr))
+	} else if network.String() != expectedNetwork || network.IsMultiple() {
+		t.addFailure(newIPAddrFailure("network boundary "+network.String()+" does not match expected "+expectedNetwork, addr))
+	} else if host.String() != expectedHost || host.IsMultiple() {
+		t.addFailure(newIPAddrFailure("host boundary "+host.String()+" does not match expected "+expectedHost, addr))
+	}
+	t.incrementTestCount()
+}
+
+// testEmbeddedIPv4Section checks the embedded IPv4 section of the first segCount segments of the given address,
+// with an empty expected string indicating an error is expected
+func (t ipAddressTester) testEmbeddedIPv4Section(str string, segCount int, expected string) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	section, err := addr.GetSubSection(0, segCount).ToEmbeddedIPv4Section()
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error for embedded IPv4 section "+section.String(), addr.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error for embedded IPv4 section: "+err.Error(), addr.ToIP()))
+	} else if section.String() != expected {
+		t.addFailure(newIPAddrFailure("embedded IPv4 section "+section.String()+" does not match expected "+expected, addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testUsableHostCount(str, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.UsableHostCount().String(); result != expected {
+		t.addFailure(newIPAddrFailure("usable host count "+result+" does not match expected "+expected, addr))
+	} else if addr.IsIPv4() && strconv.FormatInt(addr.ToIPv4().UsableHostCount(), 10) != expected {
+		t.addFailure(newIPAddrFailure("IPv4 usable host count does not match expected "+expected, addr))
+	} else if addr.IsIPv6() && addr.ToIPv6().UsableHostCount().String() != expected {
+		t.addFailure(newIPAddrFailure("IPv6 usable host count does not match expected "+expected, addr))
+	}
+	t.incrementTestCount()
+}
+
+// testNATRule checks translating the given address, with an empty original subnet string indicating a nil subnet,
+// and an empty expected string indicating an error is expected
+func (t ipAddressTester) testNATRule(originalStr, translatedStr, str, expected string) {
+	rule := &goip.NATRule{TranslatedSubnet: t.createAddress(translatedStr).GetAddress()}
+	if originalStr != "" {
+		rule.OriginalSubnet = t.createAddress(originalStr).GetAddress()
+	}
+	addr := t.createAddress(str).GetAddress()
+	result, err := rule.Translate(addr)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error translating to "+translatedStr+", got "+result.String(), addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error translating to "+translatedStr+": "+err.Error(), addr))
+	} else if result.String() != expected {
+		t.addFailure(newIPAddrFailure("translation "+result.String()+" does not match expected "+expected, addr))
+	}
+	t.incrementTestCount()
+}
+
+// testIANARegistration checks the registry entry for the address, with an empty expected name indicating no entry is expected
+func (t ipAddressTester) testIANARegistration(str, expectedName, expectedRFC string, expectedForwardable, expectedGloballyRoutable bool) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	entry := addr.GetIANARegistration()
+	if expectedName == "" {
+		if entry != nil {
+			t.addFailure(newIPAddrFailure("unexpected registration "+entry.Name, addr.ToIP()))
+		}
+	} else if entry == nil {
+		t.addFailure(newIPAddrFailure("missing registration "+expectedName, addr.ToIP()))
+	} else if entry.Name != expectedName || entry.RFC != expectedRFC {
+		t.addFailure(newIPAddrFailure("registration "+entry.Name+" ("+entry.RFC+") does not match expected "+expectedName+" ("+expectedRFC+")", addr.ToIP()))
+	} else if entry.Forwardable != expectedForwardable || entry.GloballyRoutable != expectedGloballyRoutable {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("registration ", entry.Name, " forwardable and globally routable are ", entry.Forwardable, " and ", entry.GloballyRoutable), addr.ToIP()))
+	} else if !entry.Block.Contains(addr) {
+		t.addFailure(newIPAddrFailure("registration block "+entry.Block.String()+" does not contain address", addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testApplySubnetMask checks applying the mask, with an empty expected string indicating an error is expected
+func (t ipAddressTester) testApplySubnetMask(str, maskStr, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	mask := t.createAddress(maskStr).GetAddress()
+	result, err := addr.ApplySubnetMask(mask)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error applying mask "+maskStr+", got "+result.String(), addr))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error applying mask "+maskStr+": "+err.Error(), addr))
+	} else if result.String() != expected {
+		t.addFailure(newIPAddrFailure("applying mask "+maskStr+" gives "+result.String()+", expected "+expected, addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testSegmentSlices(str string, expected []uint32) {
+	addr := t.createAddress(str).GetAddress()
+	ints, segBytes := addr.ToSegmentIntsSlice(), addr.ToSegmentBytesSlice()
+	if fmt.Sprint(ints) != fmt.Sprint(expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("segment values ", ints, " do not match expected ", expected), addr))
+	} else if len(segBytes) != len(expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("segment bytes ", segBytes, " do not match expected ", expected), addr))
+	} else {
+		for i, b := range segBytes {
+			if len(b) != addr.GetBytesPerSegment() || new(big.Int).SetBytes(b).Uint64() != uint64(expected[i]) {
+				t.addFailure(newIPAddrFailure(fmt.Sprint("segment bytes ", b, " do not match expected ", expected[i]), addr))
+				break
+			}
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testNAT64 checks embedding the IPv4 address in the NAT64 prefix and extracting it back,
+// with an empty expected string indicating an error is expected
+func (t ipAddressTester) testNAT64(prefixStr, ipv4Str, expected string) {
+	prefix := t.createAddress(prefixStr).GetAddress().ToIPv6()
+	ipv4 := t.createAddress(ipv4Str).GetAddress().ToIPv4()
+	result, err := goip.NewIPv6FromNAT64(prefix, ipv4)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error embedding "+ipv4Str+", got "+result.String(), prefix.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error embedding "+ipv4Str+": "+err.Error(), prefix.ToIP()))
+	} else if result.String() != expected {
+		t.addFailure(newIPAddrFailure("embedding "+ipv4Str+" gives "+result.String()+", expected "+expected, prefix.ToIP()))
+	} else if back, ok := result.GetNAT64IPv4WithPrefix(prefix); !ok || !back.Equal(ipv4) {
+		t.addFailure(newIPAddrFailure("extracting "+ipv4Str+" from "+expected+" gives "+back.String(), prefix.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testNAT64IPv4 checks the IPv4 address embedded with a well-known or local-use NAT64 prefix,
+// with an empty expected string indicating no address is expected
+func (t ipAddressTester) testNAT64IPv4(str, expected string) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	if result, ok := addr.GetNAT64IPv4(); expected == "" {
+		if ok {
+			t.addFailure(newIPAddrFailure("unexpected NAT64 IPv4 address "+result.String(), addr.ToIP()))
+		}
+	} else if !ok || result.String() != expected {
+		t.addFailure(newIPAddrFailure("NAT64 IPv4 address "+result.String()+" does not match expected "+expected, addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testGeoIPKey(str string, expectedIPv4 uint32, expectedHigh, expectedLow uint64) {
+	addr := t.createAddress(str).GetAddress()
+	if addr.IsIPv4() {
+		if key := addr.ToIPv4().ToGeoIPKey(); key != expectedIPv4 {
+			t.addFailure(newIPAddrFailure(fmt.Sprintf("GeoIP key %x does not match expected %x", key, expectedIPv4), addr))
+		}
+	} else if key := addr.ToIPv6().ToGeoIPKey(); key != [2]uint64{expectedHigh, expectedLow} {
+		t.addFailure(newIPAddrFailure(fmt.Sprintf("GeoIP key %x does not match expected %x %x", key, expectedHigh, expectedLow), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testOddEven(str string, expectedOdd bool) {
+	addr := t.createAddress(str).GetAddress()
+	if addr.IsOdd() != expectedOdd || addr.IsEven() == expectedOdd {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("odd is ", addr.IsOdd(), " and even is ", addr.IsEven(), ", expected odd ", expectedOdd), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testIPv4ScopedMulticast(str string, expectedAdminLocal, expectedOrganizationLocal bool) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	if result := addr.IsAdminLocalMulticast(); result != expectedAdminLocal {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("admin-local multicast is ", result, ", expected ", expectedAdminLocal), addr.ToIP()))
+	} else if result = addr.IsOrganizationLocalMulticast(); result != expectedOrganizationLocal {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("organization-local multicast is ", result, ", expected ", expectedOrganizationLocal), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testMulticastScope(str string, expected goip.IPv6MulticastScope, expectedName string) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	if scope := addr.GetMulticastScope(); scope != expected {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("multicast scope ", int(scope), " does not match expected ", int(expected)), addr.ToIP()))
+	} else if scope.String() != expectedName {
+		t.addFailure(newIPAddrFailure("multicast scope name "+scope.String()+" does not match expected "+expectedName, addr.ToIP()))
+	} else if addr.IsAdminLocalMulticast() != (expected == goip.MulticastScopeAdminLocal) ||
+		addr.IsOrganizationLocalMulticast() != (expected == goip.MulticastScopeOrganizationLocal) {
+		t.addFailure(newIPAddrFailure("scoped multicast inconsistent with scope "+expectedName, addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testPaddedDottedDecimal checks that the padded strings of the given addresses, sorted as strings, match the expected strings,
+// which are sorted in the order of the address values
+func (t ipAddressTester) testPaddedDottedDecimal(strs []string, expected []string) {
+	var result []string
+	for _, str := range strs {
+		addr := t.createAddress(str).GetAddress().ToIPv4()
+		padded := addr.ToPaddedDottedDecimalString()
+		if padded != addr.ToZeroPaddedDottedDecimalString() || padded != addr.ToPackedDecimalString() {
+			t.addFailure(newIPAddrFailure("padded strings do not match "+padded, addr.ToIP()))
+		}
+		result = append(result, padded)
+	}
+	sort.Strings(result)
+	if fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("sorted padded strings ", result, " do not match expected ", expected), nil))
+	}
+	t.incrementTestCount()
+}
+
+// testToCIDRList checks normalizing the given strings, with nil expected strings indicating an error is expected
+func (t ipAddressTester) testToCIDRList(strs []string, maxPrefixLen goip.BitCount, expected []string) {
+	result, err := goip.ToCIDRList(strs, maxPrefixLen)
+	if expected == nil {
+		if err == nil {
+			t.addFailure(newIPAddrFailure(fmt.Sprint("expected error normalizing ", strs, ", got ", result), nil))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("unexpected error normalizing ", strs, ": ", err), nil))
+	} else if fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("CIDR list ", result, " does not match expected ", expected), nil))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testProtoBytes(str string, expected []byte) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.ToProtoBytes(); !bytes.Equal(result, expected) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("proto bytes ", result, " do not match expected ", expected), addr))
+	} else if back, err := goip.NewIPAddressFromProtoBytes(result); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error decoding proto bytes: "+err.Error(), addr))
+	} else if back.GetIPVersion() != addr.GetIPVersion() || !bytes.Equal(back.Bytes(), expected) || back.IsMultiple() {
+		t.addFailure(newIPAddrFailure("proto bytes round trip produced "+back.String(), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidProtoBytes(data []byte) {
+	if addr, err := goip.NewIPAddressFromProtoBytes(data); err == nil {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("expected error decoding proto bytes ", data), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testSegmentedHexString(str, expected, expectedPrefixed, expectedUpperPrefixed string) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.ToSegmentedHexString(false, false); result != expected {
+		t.addFailure(newIPAddrFailure("segmented hex string "+result+" does not match expected "+expected, addr))
+	} else if result = addr.ToSegmentedHexString(false, true); result != expectedPrefixed {
+		t.addFailure(newIPAddrFailure("segmented hex string "+result+" does not match expected "+expectedPrefixed, addr))
+	} else if result = addr.ToSegmentedHexString(true, true); result != expectedUpperPrefixed {
+		t.addFailure(newIPAddrFailure("segmented hex string "+result+" does not match expected "+expectedUpperPrefixed, addr))
+	} else if result = addr.ToSegmentedHexString(true, false); result != strings.ToUpper(expected) {
+		t.addFailure(newIPAddrFailure("segmented hex string "+result+" does not match expected "+strings.ToUpper(expected), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testAllocatorLists allocates the given bit-lengths one at a time and checks the lists of allocated and available blocks
+func (t ipAddressTester) testAllocatorLists(blockStrs []string, bitLengths []goip.BitCount, expectedAllocated, expectedAvailable []string) {
+	alloc := goip.IPv4PrefixBlockAllocator{}
+	for _, str := range blockStrs {
+		alloc.AddAvailable(t.createAddress(str).GetAddress().ToIPv4())
+	}
+	for _, bitLength := range bitLengths {
+		alloc.AllocateBitLen(bitLength)
+	}
+	allocated, available := alloc.ListAllocated(), alloc.ListAvailable()
+	if fmt.Sprint(allocated) != fmt.Sprint(expectedAllocated) || (allocated == nil) != (expectedAllocated == nil) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("allocated blocks ", allocated, " do not match expected ", expectedAllocated), nil))
+	} else if fmt.Sprint(available) != fmt.Sprint(expectedAvailable) || (available == nil) != (expectedAvailable == nil) {
+		t.addFailure(newIPAddrFailure(fmt.Sprint("available blocks ", available, " do not match expected ", expectedAvailable), nil))
+	}
+	t.incrementTestCount()
+}
+
+// testCIDRWithMask checks the string with prefix length and mask, with an empty expected string indicating an error is expected
+func (t ipAddressTester) testCIDRWithMask(str, expected string) {
+	addr := t.createAddress(str).GetAddress().ToIPv4()
+	result, err := addr.ToCIDRWithMaskString()
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error, got "+result, addr.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr.ToIP()))
+	} else if result != expected {
+		t.addFailure(newIPAddrFailure("string with mask "+result+" does not match expected "+expected, addr.ToIP()))
+	} else if back, err := goip.NewIPv4AddressFromCIDRWithMask(" " + result + "\t"); err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error parsing "+result+": "+err.Error(), addr.ToIP()))
+	} else if !back.Equal(addr) || !back.GetPrefixLen().Equal(addr.GetPrefixLen()) {
+		t.addFailure(newIPAddrFailure("round trip of "+result+" produced "+back.String(), addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testInvalidCIDRWithMask(str string) {
+	if addr, err := goip.NewIPv4AddressFromCIDRWithMask(str); err == nil {
+		t.addFailure(newIPAddrFailure("expected error parsing "+str, addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+// testSolicitedNodeMulticast checks the solicited-node multicast address, with an empty expected string indicating an error is expected
+func (t ipAddressTester) testSolicitedNodeMulticast(str, expected string) {
+	addr := t.createAddress(str).GetAddress().ToIPv6()
+	result, err := addr.ToSolicitedNodeMulticast()
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newIPAddrFailure("expected error, got "+result.String(), addr.ToIP()))
+		}
+	} else if err != nil {
+		t.addFailure(newIPAddrFailure("unexpected error "+err.Error(), addr.ToIP()))
+	} else if result.String() != expected {
+		t.addFailure(newIPAddrFailure("solicited-node multicast "+result.String()+" does not match expected "+expected, addr.ToIP()))
+	} else if result.GetMulticastScope() != goip.MulticastScopeLinkLocal {
+		t.addFailure(newIPAddrFailure("solicited-node multicast "+result.String()+" is not link-local", addr.ToIP()))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testSupernets(str string, expectedCount int, expectedFirst, expectedLast string) {
+	addr := t.createAddress(str).GetAddress()
+	supernets := addr.Supernets()
+	if len(supernets) != expectedCount {
+		t.addFailure(newIPAddrFailure("supernet count "+strconv.Itoa(len(supernets))+" does not match expected "+strconv.Itoa(expectedCount), addr))
+	} else if first, last := supernets[0].String(), supernets[len(supernets)-1].String(); first != expectedFirst || last != expectedLast {
+		t.addFailure(newIPAddrFailure("supernets "+first+" to "+last+" do not match expected "+expectedFirst+" to "+expectedLast, addr))
+	} else {
+		for i, supernet := range supernets {
+			if !supernet.IsSinglePrefixBlock() || !supernet.Contains(addr) || (i > 0 && !supernet.Contains(supernets[i-1])) {
+				t.addFailure(newIPAddrFailure("supernet "+supernet.String()+" is not a containing prefix block", addr))
+				break
+			}
+		}
+	}
+	if (&goip.IPAddress{}).SupernetIterator().HasNext() {
+		t.addFailure(newIPAddrFailure("zero address has supernets", nil))
+	}
+	t.incrementTestCount()
+}
+
+func (t ipAddressTester) testBitLayoutString(str, expected string) {
+	addr := t.createAddress(str).GetAddress()
+	if result := addr.ToBitLayoutString(); result != expected {
+		t.addFailure(newIPAddrFailure("bit layout\n"+result+"\ndoes not match expected\n"+expected, addr))
+	}
+	t.incrementTestCount()
+}
+
 var trueVal = true
 
 var conv = goip.DefaultAddressConverter{}
@@ -1,7 +1,11 @@
 package test
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"math"
+	"math/rand"
 	"strconv"
 
 	"github.com/pchchv/goip"
@@ -614,6 +618,36 @@ func (t macAddressRangeTester) run() {
 
 	t.testTrees()
 
+	t.testOUI("aa:bb:cc:*:*:*", "aa:bb:cc:00:00:00", "aa:bb:cc", "00:00:00:*:*:*", true)
+	t.testOUI("aa:bb:*:dd:ee:ff", "aa:bb:*:00:00:00", "aa:bb:*", "00:00:00:dd:ee:ff", true)
+	t.testOUI("a8:bb:cc:dd:*:*:*:*", "a8:bb:cc:00:00:00:00:00", "a8:bb:cc", "00:00:00:dd:*:*:*:*", false)
+	t.testOUI("aa:bb:cc:0-7f:*:*", "aa:bb:cc:00:00:00", "aa:bb:cc", "00:00:00:0-7f:*:*", true)
+
+	t.testMACJSON("01:02:03:04:05:06", `"01-02-03-04-05-06"`)
+	t.testMACJSON("01:02:03:*:*:*", `"01-02-03-*-*-*"`)
+	t.testMACJSON("01:02:03:04-05:*:*", `"01-02-03-04|05-*-*"`)
+	t.testMACJSON("01:02:03:04:05:06:07:08", `"01-02-03-04-05-06-07-08"`)
+	t.testMACJSON("01:02:03:ff:fe:*:*:*", `"01-02-03-ff-fe-*-*-*"`)
+
+	t.testMACText("01:02:03:04:05:06", "01-02-03-04-05-06")
+	t.testMACText("01:02:03:*:*:*", "01-02-03-*-*-*")
+	t.testMACText("01:02:03:04-05:*:*", "01-02-03-04|05-*-*")
+	t.testMACText("01:02:03:ff:fe:*:*:*", "01-02-03-ff-fe-*-*-*")
+
+	t.testMACBinary("01:02:03:04:05:06", []byte{1, 48, 1, 2, 3, 4, 5, 6, 1, 2, 3, 4, 5, 6, 0})
+	t.testMACBinary("01:02:03:*:*:*", []byte{1, 48, 1, 2, 3, 0, 0, 0, 1, 2, 3, 255, 255, 255, 1, 24})
+	t.testMACBinary("01:02:03:04-05:*:*", nil)
+	t.testMACBinary("01:02:03:ff:fe:*:*:*", nil)
+
+	t.testMACReverseIterators("01:02:03:04:05:06")
+	t.testMACReverseIterators("01:02:03:04:05:*")
+	t.testMACReverseIterators("01:02:03:04:05-06:1-3")
+
+	t.testMACRandomAddress("01:02:03:04:05:06")
+	t.testMACRandomAddress("01:02:03:04:05:*")
+	t.testMACRandomAddress("01:02:03:04-05:*:1-3")
+	t.testMACRandomAddress("01:02:03:ff:fe:*:*:*")
+
 	t.macAddressTester.run()
 }
 
@@ -1103,3 +1137,107 @@ func (t macAddressRangeTester) testTrees() {
 		"*:*:*:*:*:*",
 	})
 }
+
+func (t macAddressRangeTester) testMACJSON(addrStr, expected string) {
+	addr := t.createMACAddress(addrStr).GetAddress()
+	data, err := json.Marshal(addr)
+	if err != nil {
+		t.addFailure(newSegmentSeriesFailure("unexpected error marshalling: "+err.Error(), addr))
+	} else if string(data) != expected {
+		t.addFailure(newSegmentSeriesFailure("JSON "+string(data)+" does not match expected "+expected, addr))
+	} else {
+		var back goip.MACAddress
+		if err = json.Unmarshal(data, &back); err != nil {
+			t.addFailure(newSegmentSeriesFailure("unexpected error unmarshalling "+string(data)+": "+err.Error(), addr))
+		} else if !back.Equal(addr) {
+			t.addFailure(newSegmentSeriesFailure("JSON round trip produced "+back.String(), addr))
+		}
+	}
+	if err = json.Unmarshal([]byte(`"01:02:03:04:05:0g"`), &goip.MACAddress{}); err == nil {
+		t.addFailure(newSegmentSeriesFailure("expected error unmarshalling invalid JSON", addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t macAddressRangeTester) testMACText(addrStr, expected string) {
+	addr := t.createMACAddress(addrStr).GetAddress()
+	text, err := addr.MarshalText()
+	if err != nil {
+		t.addFailure(newSegmentSeriesFailure("unexpected error marshalling text: "+err.Error(), addr))
+	} else if string(text) != expected {
+		t.addFailure(newSegmentSeriesFailure("text "+string(text)+" does not match expected "+expected, addr))
+	} else {
+		var back goip.MACAddress
+		if err = back.UnmarshalText(text); err != nil {
+			t.addFailure(newSegmentSeriesFailure("unexpected error unmarshalling text "+string(text)+": "+err.Error(), addr))
+		} else if !back.Equal(addr) {
+			t.addFailure(newSegmentSeriesFailure("text round trip produced "+back.String(), addr))
+		}
+	}
+
+	addrString := goip.NewMACAddressString(addrStr)
+	var backString goip.MACAddressString
+	if text, err = addrString.MarshalText(); err != nil || string(text) != addrStr {
+		t.addFailure(newSegmentSeriesFailure("address string text "+string(text)+" does not match original "+addrStr, addr))
+	} else if err = backString.UnmarshalText(text); err != nil || backString.String() != addrStr {
+		t.addFailure(newSegmentSeriesFailure("address string text round trip produced "+backString.String(), addr))
+	} else if err = backString.UnmarshalText([]byte("01:02:03:04:05:0g")); err == nil || backString.String() != addrStr {
+		t.addFailure(newSegmentSeriesFailure("invalid text replaced the address string", addr))
+	}
+	if err = new(goip.MACAddress).UnmarshalText(nil); err == nil {
+		t.addFailure(newSegmentSeriesFailure("expected error unmarshalling empty text", addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t macAddressRangeTester) testMACBinary(addrStr string, expected []byte) {
+	addr := t.createMACAddress(addrStr).GetAddress()
+	data, err := addr.MarshalBinary()
+	if err != nil {
+		t.addFailure(newSegmentSeriesFailure("unexpected error marshalling binary: "+err.Error(), addr))
+	} else if expected != nil && !bytes.Equal(data, expected) {
+		t.addFailure(newSegmentSeriesFailure(fmt.Sprint("binary ", data, " does not match expected ", expected), addr))
+	} else {
+		var back goip.MACAddress
+		if err = back.UnmarshalBinary(data); err != nil {
+			t.addFailure(newSegmentSeriesFailure("unexpected error unmarshalling binary: "+err.Error(), addr))
+		} else if !back.Equal(addr) || back.String() != addr.String() {
+			t.addFailure(newSegmentSeriesFailure("binary round trip produced "+back.String(), addr))
+		} else if err = back.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.addFailure(newSegmentSeriesFailure("expected error unmarshalling truncated binary", addr))
+		} else if err = new(goip.IPAddress).UnmarshalBinary(data); err == nil {
+			t.addFailure(newSegmentSeriesFailure("expected error unmarshalling MAC binary as IP", addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t macAddressRangeTester) testMACReverseIterators(addrStr string) {
+	addr := t.createMACAddress(addrStr).GetAddress()
+	if forward, reverse := collectStrings(addr.Iterator()), collectStrings(addr.ReverseIterator()); !isReversed(forward, reverse) {
+		t.addFailure(newSegmentSeriesFailure(fmt.Sprint("reverse iterator produced ", reverse, ", expected the reverse of ", forward), addr))
+	}
+	if forward, reverse := collectStrings(addr.SequentialBlockIterator()), collectStrings(addr.SequentialBlockReverseIterator()); !isReversed(forward, reverse) {
+		t.addFailure(newSegmentSeriesFailure(fmt.Sprint("reverse sequential block iterator produced ", reverse, ", expected the reverse of ", forward), addr))
+	}
+	if addr.IsPrefixed() {
+		if forward, reverse := collectStrings(addr.PrefixBlockIterator()), collectStrings(addr.PrefixBlockReverseIterator()); !isReversed(forward, reverse) {
+			t.addFailure(newSegmentSeriesFailure(fmt.Sprint("reverse prefix block iterator produced ", reverse, ", expected the reverse of ", forward), addr))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t macAddressRangeTester) testMACRandomAddress(addrStr string) {
+	addr := t.createMACAddress(addrStr).GetAddress()
+	for i := 0; i < 10; i++ {
+		if random := addr.RandomAddress(); random.IsMultiple() || !addr.Contains(random) || random.GetSegmentCount() != addr.GetSegmentCount() {
+			t.addFailure(newSegmentSeriesFailure(fmt.Sprint("random address ", random, " is not an address of the collection"), addr))
+			break
+		}
+	}
+	if first, second := addr.RandomAddressWithRand(rand.New(rand.NewSource(1))), addr.RandomAddressWithRand(rand.New(rand.NewSource(1))); !first.Equal(second) {
+		t.addFailure(newSegmentSeriesFailure(fmt.Sprint("random addresses ", first, " and ", second, " from the same seed do not match"), addr))
+	}
+	t.incrementTestCount()
+}
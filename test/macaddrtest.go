@@ -2,6 +2,7 @@ package test
 
 import (
 	"bytes"
+	"fmt"
 	"math"
 	"math/big"
 	"net"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/pchchv/goip"
+	"github.com/pchchv/goip/address_error"
 	"github.com/pchchv/goip/address_string"
 	"github.com/pchchv/goip/address_string_param"
 )
@@ -492,6 +494,31 @@ func (t macAddressTester) run() {
 	t.testMACIPv6("FE80::212:7FFF:FEEB:6B40", "0012.7feb.6b40")
 	t.testMACIPv6("2001:DB8::212:7FFF:FEEB:6B40", "0012.7feb.6b40")
 	t.testStrings()
+
+	t.testOUI("aa:bb:cc:dd:ee:ff", "aa:bb:cc:00:00:00", "aa:bb:cc", "00:00:00:dd:ee:ff", true)
+	t.testOUI("a8:bb:cc:dd:ee:ff", "a8:bb:cc:00:00:00", "a8:bb:cc", "00:00:00:dd:ee:ff", false)
+	t.testOUI("aa:bb:cc:dd:ee:ff:11:22", "aa:bb:cc:00:00:00:00:00", "aa:bb:cc", "00:00:00:dd:ee:ff:11:22", true)
+	t.testOUI("00:1a:2b:3c:4d:5e:6f:70", "00:1a:2b:00:00:00:00:00", "00:1a:2b", "00:00:00:3c:4d:5e:6f:70", false)
+	t.testOUI("02:00:00:00:00:01", "02:00:00:00:00:00", "02:00:00", "00:00:00:00:00:01", true)
+
+	t.testEUI48String("aa:bb:cc:dd:ee:ff", ':', "aa:bb:cc:dd:ee:ff")
+	t.testEUI48String("aa:bb:cc:dd:ee:ff", '-', "aa-bb-cc-dd-ee-ff")
+	t.testEUI48String("aa:bb:cc:dd:ee:ff", '.', "aabb.ccdd.eeff")
+	t.testEUI48String("aa:bb:cc:dd:ee:ff", ' ', "aa bb cc dd ee ff")
+	t.testEUI48String("aa:bb:cc:dd:ee:ff", '/', "")
+	t.testEUI48String("aa:bb:cc:dd:ee:ff:11:22", ':', "")
+
+	t.testAdministered("00:1a:2b:3c:4d:5e", false)
+	t.testAdministered("02:1a:2b:3c:4d:5e", true)
+	t.testAdministered("01:00:5e:00:00:01", false)
+	t.testAdministered("03:00:00:00:00:01", true)
+	t.testAdministered("fe:ff:ff:ff:ff:ff", true)
+	t.testAdministered("fd:ff:ff:ff:ff:ff", false)
+	t.testAdministered("02:1a:2b:ff:fe:3c:4d:5e", true)
+	t.testAdministered("00:1a:2b:ff:fe:3c:4d:5e", false)
+
+	t.testMACSQL("01:02:03:04:05:06", "01-02-03-04-05-06")
+	t.testMACSQL("01:02:03:04:05:06:07:08", "01-02-03-04-05-06-07-08")
 }
 
 func (t macAddressTester) testMACValues(segs []int, decimal string) {
@@ -743,6 +770,94 @@ func (t macAddressTester) testLongShort2(longAddr, shortAddr string, shortCanBeL
 	t.incrementTestCount()
 }
 
+func (t macAddressTester) testOUI(addrStr, expectedOUI, expectedOUIString, expectedDevice string, isLocal bool) {
+	addr := t.createMACAddress(addrStr).GetAddress()
+	oui, device := addr.GetOUI(), addr.GetDeviceIdentifier()
+	if !oui.Equal(t.createMACAddress(expectedOUI).GetAddress()) || oui.IsPrefixed() {
+		t.addFailure(newSegmentSeriesFailure("OUI mismatch "+oui.String()+" expected "+expectedOUI, addr))
+	} else if ouiStr := addr.GetOUIString(); ouiStr != expectedOUIString {
+		t.addFailure(newSegmentSeriesFailure("OUI string mismatch "+ouiStr+" expected "+expectedOUIString, addr))
+	} else if !device.Equal(t.createMACAddress(expectedDevice).GetAddress()) || device.IsPrefixed() {
+		t.addFailure(newSegmentSeriesFailure("device identifier mismatch "+device.String()+" expected "+expectedDevice, addr))
+	} else if oui.GetSegmentCount() != addr.GetSegmentCount() || device.GetSegmentCount() != addr.GetSegmentCount() {
+		t.addFailure(newSegmentSeriesFailure("segment count mismatch "+oui.String()+" "+device.String(), addr))
+	} else if !oui.GetOUISection().Equal(addr.GetOUISection()) || !device.GetODISection().Equal(addr.GetODISection()) {
+		t.addFailure(newSegmentSeriesFailure("section mismatch "+oui.String()+" "+device.String(), addr))
+	} else if !addr.SetPrefixLen(16).GetOUI().Equal(oui) || !addr.SetPrefixLen(16).GetDeviceIdentifier().Equal(device) {
+		t.addFailure(newSegmentSeriesFailure("prefixed mismatch "+oui.String()+" "+device.String(), addr))
+	} else if addr.IsLocallyAdministered() != isLocal || addr.IsGloballyUnique() == isLocal || oui.IsLocallyAdministered() != isLocal {
+		t.addFailure(newSegmentSeriesFailure("administration bit mismatch, expected local "+strconv.FormatBool(isLocal), addr))
+	}
+	t.incrementTestCount()
+}
+
+// testEUI48String expects an error when the expected string is empty
+func (t macAddressTester) testEUI48String(str string, separator rune, expected string) {
+	addr := t.createMACAddress(str).GetAddress()
+	result, err := addr.ToEUI48String(separator)
+	if expected == "" {
+		if err == nil {
+			t.addFailure(newMACAddrFailure("expected error for separator "+string(separator)+", got "+result, addr))
+		}
+	} else if err != nil {
+		t.addFailure(newMACAddrFailure("unexpected error "+err.Error(), addr))
+	} else if result != expected {
+		t.addFailure(newMACAddrFailure("EUI-48 string "+result+" does not match expected "+expected, addr))
+	} else {
+		params := new(address_string_param.MACAddressStringParamsBuilder).SetSeparator(separator).ToParams()
+		if parsed := goip.NewMACAddressStringParams(result, params); !parsed.IsValid() || !parsed.GetAddress().Equal(addr) {
+			t.addFailure(newMACFailure("not parsed with separator "+string(separator), parsed))
+		}
+
+		otherSeparator := ':'
+		if separator == otherSeparator {
+			otherSeparator = '-'
+		}
+		params = new(address_string_param.MACAddressStringParamsBuilder).SetSeparator(otherSeparator).ToParams()
+		if parsed := goip.NewMACAddressStringParams(result, params); parsed.IsValid() {
+			t.addFailure(newMACFailure("parsed with separator "+string(otherSeparator), parsed))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t macAddressTester) testAdministered(addrStr string, isLocal bool) {
+	addr := t.createMACAddress(addrStr).GetAddress()
+	if addr.IsLocallyAdministered() != isLocal || addr.IsLocal() != isLocal {
+		t.addFailure(newSegmentSeriesFailure("locally administered mismatch, expected "+strconv.FormatBool(isLocal), addr))
+	} else if addr.IsUniversallyAdministered() == isLocal || addr.IsUniversal() == isLocal {
+		t.addFailure(newSegmentSeriesFailure("universally administered mismatch, expected "+strconv.FormatBool(!isLocal), addr))
+	}
+	t.incrementTestCount()
+}
+
+func (t macAddressTester) testMACSQL(addrStr, expected string) {
+	addr := t.createMACAddress(addrStr).GetAddress()
+	value, err := addr.Value()
+	if err != nil {
+		t.addFailure(newSegmentSeriesFailure("unexpected error for database value: "+err.Error(), addr))
+	} else if value != expected {
+		t.addFailure(newSegmentSeriesFailure(fmt.Sprint("database value ", value, " does not match expected ", expected), addr))
+	} else {
+		for _, src := range []interface{}{value, []byte(expected), net.HardwareAddr(addr.Bytes())} {
+			var back goip.MACAddress
+			if err = back.Scan(src); err != nil {
+				t.addFailure(newSegmentSeriesFailure(fmt.Sprintf("unexpected error scanning %T: %v", src, err), addr))
+			} else if !back.Equal(addr) {
+				t.addFailure(newSegmentSeriesFailure(fmt.Sprintf("scanning %T produced %v", src, back.String()), addr))
+			}
+		}
+	}
+	if err = new(goip.MACAddress).Scan(42); err == nil {
+		t.addFailure(newSegmentSeriesFailure("expected error scanning an integer", addr))
+	} else if err = new(goip.MACAddress).Scan("01:02:03:04:05:0g"); err == nil {
+		t.addFailure(newSegmentSeriesFailure("expected error scanning an invalid string", addr))
+	} else if valueErr, ok := err.(address_error.AddressValueError); !ok || valueErr.GetKey() != "ipaddress.error.scan.parse" {
+		t.addFailure(newSegmentSeriesFailure("expected value error scanning an invalid string, got "+err.Error(), addr))
+	}
+	t.incrementTestCount()
+}
+
 func (t macAddressTester) testContains(addr1, addr2 string, equal bool) {
 	w := t.createMACAddress(addr1).GetAddress()
 	w2 := t.createMACAddress(addr2).GetAddress()
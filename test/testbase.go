@@ -768,7 +768,7 @@ func (t testBase) testIPv6Strings(w *goip.IPAddressString, ipAddr *goip.IPAddres
 	t.testStrings(w, ipAddr, normalizedString, normalizedWildcardString, canonicalWildcardString, sqlString, fullString, compressedString, canonicalString, subnetString, subnetString, compressedWildcardString, reverseDNSString, uncHostString, singleHex, singleOctal)
 
 	//now test some IPv6-only strings
-	t.testIPv6OnlyStrings(w, goip.ToIPv6(), mixedStringNoCompressMixed,
+	t.testIPv6OnlyStrings(w, ipAddr.ToIPv6(), mixedStringNoCompressMixed,
 		mixedStringNoCompressHost, mixedStringCompressCoveredHost, mixedString, base85String)
 }
 
@@ -782,7 +782,7 @@ func (t testBase) testIPv6OnlyStrings(w *goip.IPAddressString, ipAddr *goip.IPv6
 	base85 := ""
 
 	var err error
-	base85, err = goip.ToBase85String()
+	base85, err = ipAddr.ToBase85String()
 	if err != nil {
 		isMatch := base85String == ""
 		if !isMatch {
@@ -795,22 +795,22 @@ func (t testBase) testIPv6OnlyStrings(w *goip.IPAddressString, ipAddr *goip.IPv6
 		}
 	}
 
-	m, _ := goip.ToMixedString()
+	m, _ := ipAddr.ToMixedString()
 
 	compressOpts := new(address_string.CompressOptionsBuilder).SetCompressSingle(true).SetCompressionChoiceOptions(address_string.ZerosOrHost).SetMixedCompressionOptions(address_string.MixedCompressionCoveredByHost)
 	mixedParams := new(address_string.IPv6StringOptionsBuilder).SetMixed(true).SetCompressOptions(compressOpts).ToOptions()
-	mixedCompressCoveredHost, _ := goip.ToCustomString(mixedParams)
+	mixedCompressCoveredHost, _ := ipAddr.ToCustomString(mixedParams)
 
 	compressOpts = new(address_string.CompressOptionsBuilder).SetCompressSingle(true).SetCompressionChoiceOptions(address_string.ZerosOrHost).SetMixedCompressionOptions(address_string.MixedCompressionNoHost)
 	mixedParams = new(address_string.IPv6StringOptionsBuilder).SetMixed(true).SetCompressOptions(compressOpts).ToOptions()
-	mixedNoCompressHost, _ := goip.ToCustomString(mixedParams)
+	mixedNoCompressHost, _ := ipAddr.ToCustomString(mixedParams)
 
 	compressOpts = new(address_string.CompressOptionsBuilder).SetCompressSingle(true).SetCompressionChoiceOptions(address_string.ZerosOrHost).SetMixedCompressionOptions(address_string.NoMixedCompression)
 	mixedParams = new(address_string.IPv6StringOptionsBuilder).SetMixed(true).SetCompressOptions(compressOpts).ToOptions()
-	mixedNoCompressMixed, _ := goip.ToCustomString(mixedParams)
+	mixedNoCompressMixed, _ := ipAddr.ToCustomString(mixedParams)
 
-	t.confirmAddrStrings(goip.ToIP(), m, mixedCompressCoveredHost, mixedNoCompressHost, mixedNoCompressMixed, base85)
-	t.confirmHostStrings(goip.ToIP(), false, m, mixedCompressCoveredHost, mixedNoCompressHost, mixedNoCompressMixed)
+	t.confirmAddrStrings(ipAddr.ToIP(), m, mixedCompressCoveredHost, mixedNoCompressHost, mixedNoCompressMixed, base85)
+	t.confirmHostStrings(ipAddr.ToIP(), false, m, mixedCompressCoveredHost, mixedNoCompressHost, mixedNoCompressMixed)
 
 	nMatch := m == (mixedString)
 	if !nMatch {
@@ -854,7 +854,7 @@ func (t testBase) confirmAddrStrings(ipAddr *goip.IPAddress, strs ...string) boo
 		}
 		addrString := t.createParamsAddress(str, defaultOptions)
 		addr := addrString.GetAddress()
-		if !goip.Equal(addr) {
+		if !ipAddr.Equal(addr) {
 			t.addFailure(newIPAddrFailure("failed produced string: "+str, ipAddr))
 			return false
 		}
@@ -866,7 +866,7 @@ func (t testBase) confirmAddrStrings(ipAddr *goip.IPAddress, strs ...string) boo
 func (t testBase) confirmIPAddrStrings(ipAddr *goip.IPAddress, strs ...*goip.IPAddressString) bool {
 	for _, str := range strs {
 		addr := str.GetAddress()
-		if !goip.Equal(addr) {
+		if !ipAddr.Equal(addr) {
 			t.addFailure(newIPAddrFailure("failed produced string: "+str.String(), ipAddr))
 			return false
 		}
@@ -880,18 +880,18 @@ func (t testBase) confirmHostStrings(ipAddr *goip.IPAddress, omitZone bool, strs
 		hostName := goip.NewHostName(str)
 		a := hostName.GetAddress()
 		if omitZone {
-			ipv6Addr := goip.ToIPv6()
+			ipv6Addr := ipAddr.ToIPv6()
 			ipv6Addr, _ = goip.NewIPv6Address(ipv6Addr.GetSection())
 			ipAddr = ipv6Addr.ToIP()
 		}
-		if !goip.Equal(a) {
+		if !ipAddr.Equal(a) {
 			t.addFailure(newIPAddrFailure("failed produced string: "+str, ipAddr))
 			return false
 		}
 		again := hostName.ToNormalizedString()
 		hostName = goip.NewHostName(again)
 		a = hostName.GetAddress()
-		if !goip.Equal(a) {
+		if !ipAddr.Equal(a) {
 			t.addFailure(newIPAddrFailure("failed produced string: "+str, ipAddr))
 			return false
 		}
@@ -903,14 +903,14 @@ func (t testBase) confirmHostStrings(ipAddr *goip.IPAddress, omitZone bool, strs
 func (t testBase) confirmHostNameStrings(ipAddr *goip.IPAddress, strs ...*goip.HostName) bool {
 	for _, str := range strs {
 		a := str.GetAddress()
-		if !goip.Equal(a) {
+		if !ipAddr.Equal(a) {
 			t.addFailure(newIPAddrFailure("failed produced string: "+str.String(), ipAddr))
 			return false
 		}
 		again := str.ToNormalizedString()
 		str = goip.NewHostName(again)
 		a = str.GetAddress()
-		if !goip.Equal(a) {
+		if !ipAddr.Equal(a) {
 			t.addFailure(newIPAddrFailure("failed produced string: "+str.String(), ipAddr))
 			return false
 		}
@@ -928,16 +928,16 @@ func (t testBase) testMACStrings(w *goip.MACAddressString,
 	spaceDelimitedString,
 	singleHex string) {
 	// testing: could test a leading zero split digit non-reverse string - a funky range string with split digits and leading zeros, like 100-299.*.10-19.4-7 which should be 1-2.0-9.0-9.*.*.*.0.1.0-9.0.0.4-7
-	c := goip.ToCompressedString()
-	canonical := goip.ToCanonicalString()
-	d := goip.ToDashedString()
-	n := goip.ToNormalizedString()
-	cd := goip.ToColonDelimitedString()
-	sd := goip.ToSpaceDelimitedString()
+	c := ipAddr.ToCompressedString()
+	canonical := ipAddr.ToCanonicalString()
+	d := ipAddr.ToDashedString()
+	n := ipAddr.ToNormalizedString()
+	cd := ipAddr.ToColonDelimitedString()
+	sd := ipAddr.ToSpaceDelimitedString()
 
 	var hex, hexNoPrefix string
 	var err error
-	hex, err = goip.ToHexString(true)
+	hex, err = ipAddr.ToHexString(true)
 	if err != nil {
 		isMatch := singleHex == ""
 		if !isMatch {
@@ -946,7 +946,7 @@ func (t testBase) testMACStrings(w *goip.MACAddressString,
 	} else {
 		t.confirmMACAddrStrings(ipAddr, hex)
 	}
-	hexNoPrefix, err = goip.ToHexString(false)
+	hexNoPrefix, err = ipAddr.ToHexString(false)
 	if err != nil {
 		isMatch := singleHex == ""
 		if !isMatch {
@@ -980,7 +980,7 @@ func (t testBase) testMACStrings(w *goip.MACAddressString,
 				} else {
 					var sMatch bool
 					var dotted string
-					dotted, err = goip.ToDottedString()
+					dotted, err = ipAddr.ToDottedString()
 					if err != nil {
 						sMatch = dottedString == ""
 					} else {
@@ -1046,7 +1046,7 @@ func (t testBase) testStrings(w *goip.IPAddressString,
 	singleOctal string) {
 	// testing: could test a leading zero split digit non-reverse string - a funky range string with split digits and leading zeros, like 100-299.*.10-19.4-7 which should be 1-2.0-9.0-9.*.*.*.0.1.0-9.0.0.4-7
 
-	if !goip.IsIPv6() || !goip.ToIPv6().HasZone() {
+	if !ipAddr.IsIPv6() || !ipAddr.ToIPv6().HasZone() {
 		if singleHex != "" && singleOctal != "" {
 			fmtStr := fmt.Sprintf("%s %v %#x %#o", ipAddr, ipAddr, ipAddr, ipAddr)
 			expectedFmtStr := canonicalString + " " + canonicalString + " " + singleHex + " " + singleOctal
@@ -1064,23 +1064,23 @@ func (t testBase) testStrings(w *goip.IPAddressString,
 
 	t.testHostAddressStr(w.String())
 
-	c := goip.ToCompressedString()
-	canonical := goip.ToCanonicalString()
-	s := goip.ToSubnetString()
-	cidr := goip.ToPrefixLenString()
-	n := goip.ToNormalizedString()
-	nw := goip.ToNormalizedWildcardString()
-	caw := goip.ToCanonicalWildcardString()
-	cw := goip.ToCompressedWildcardString()
-	sql := goip.ToSQLWildcardString()
-	full := goip.ToFullString()
-	rDNS, _ := goip.ToReverseDNSString()
-	unc := goip.ToUNCHostName()
+	c := ipAddr.ToCompressedString()
+	canonical := ipAddr.ToCanonicalString()
+	s := ipAddr.ToSubnetString()
+	cidr := ipAddr.ToPrefixLenString()
+	n := ipAddr.ToNormalizedString()
+	nw := ipAddr.ToNormalizedWildcardString()
+	caw := ipAddr.ToCanonicalWildcardString()
+	cw := ipAddr.ToCompressedWildcardString()
+	sql := ipAddr.ToSQLWildcardString()
+	full := ipAddr.ToFullString()
+	rDNS, _ := ipAddr.ToReverseDNSString()
+	unc := ipAddr.ToUNCHostName()
 
 	var hex, hexNoPrefix, octal string
 	var err error
 	//try {
-	hex, err = goip.ToHexString(true)
+	hex, err = ipAddr.ToHexString(true)
 	if err != nil {
 		isMatch := singleHex == ""
 		if !isMatch {
@@ -1094,19 +1094,19 @@ func (t testBase) testStrings(w *goip.IPAddressString,
 		t.confirmAddrStrings(ipAddr, hex)
 	}
 
-	hexNoPrefix, err = goip.ToHexString(false)
+	hexNoPrefix, err = ipAddr.ToHexString(false)
 	if err != nil {
 		isMatch := singleHex == ""
 		if !isMatch {
 			t.addFailure(newFailure("failed expected: "+singleHex+" actual: "+err.Error(), w))
 		}
 	} else {
-		if goip.IsIPv6() {
+		if ipAddr.IsIPv6() {
 			t.confirmAddrStrings(ipAddr, hexNoPrefix) //For ipv4, no 0x means decimal
 		}
 	}
 
-	octal, err = goip.ToOctalString(true)
+	octal, err = ipAddr.ToOctalString(true)
 	if err != nil {
 		isMatch := singleOctal == ""
 		if !isMatch {
@@ -1117,12 +1117,12 @@ func (t testBase) testStrings(w *goip.IPAddressString,
 		if !isMatch {
 			t.addFailure(newFailure("failed expected: "+singleOctal+" actual: "+octal, w))
 		}
-		if goip.IsIPv4() {
+		if ipAddr.IsIPv4() {
 			t.confirmAddrStrings(ipAddr, octal)
 		}
 	}
 
-	binary, err := goip.ToBinaryString(false)
+	binary, err := ipAddr.ToBinaryString(false)
 	if err != nil {
 		isMatch := singleHex == ""
 		if !isMatch {
@@ -1164,9 +1164,9 @@ func (t testBase) testStrings(w *goip.IPAddressString,
 		t.confirmAddrStrings(ipAddr, withStrPrefix)
 	}
 
-	binary = goip.ToSegmentedBinaryString()
+	binary = ipAddr.ToSegmentedBinaryString()
 	t.confirmAddrStrings(ipAddr, c, canonical, s, cidr, n, nw, caw, cw, binary)
-	if goip.IsIPv6() {
+	if ipAddr.IsIPv6() {
 		t.confirmAddrStrings(ipAddr, full)
 		t.confirmHostStrings(ipAddr, true, rDNS) // reverse-DNS are valid hosts with embedded addresses
 		skipUncParse := false
@@ -1181,16 +1181,16 @@ func (t testBase) testStrings(w *goip.IPAddressString,
 			t.confirmHostStrings(ipAddr, false, unc) // UNCs are usually (as long as no abnormal zone) valid hosts with embedded addresses
 		}
 	} else {
-		params := new(address_string_param.IPAddressStringParamsBuilder).Allow_inet_aton(false).ToParams()
+		params := new(address_string_param.IPAddressStringParamsBuilder).AllowInetAton(false).ToParams()
 		fullAddrString := goip.NewIPAddressStringParams(full, params)
 		t.confirmIPAddrStrings(ipAddr, fullAddrString)
 		t.confirmHostStrings(ipAddr, false, rDNS, unc) //these two are valid hosts with embedded addresses
 	}
 	t.confirmHostStrings(ipAddr, false, c, canonical, s, cidr, n, nw, caw, cw)
-	if goip.IsIPv6() {
+	if ipAddr.IsIPv6() {
 		t.confirmHostStrings(ipAddr, false, full)
 	} else {
-		params := new(address_string_param.HostNameParamsBuilder).GetIPAddressParamsBuilder().Allow_inet_aton(false).GetParentBuilder().ToParams()
+		params := new(address_string_param.HostNameParamsBuilder).GetIPAddressParamsBuilder().AllowInetAton(false).GetParentBuilder().ToParams()
 		fullAddrString := goip.NewHostNameParams(full, params)
 		t.confirmHostNameStrings(ipAddr, fullAddrString)
 	}
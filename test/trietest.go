@@ -1,6 +1,7 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -63,6 +64,8 @@ func (t trieTesterGeneric) run() {
 			}
 			t.testIterate(ipv6Tree)
 			t.testContains(ipv6Tree)
+			t.testMarshalBinary(ipv6Tree)
+			t.testMarshalJSON(ipv6Tree)
 		}
 
 		ipv4Tree := NewIPv4AddressGenericTrie()
@@ -74,6 +77,8 @@ func (t trieTesterGeneric) run() {
 			}
 			t.testIterate(ipv4Tree)
 			t.testContains(ipv4Tree)
+			t.testMarshalBinary(ipv4Tree)
+			t.testMarshalJSON(ipv4Tree)
 		}
 	}
 
@@ -122,6 +127,8 @@ func (t trieTesterGeneric) run() {
 			}
 			t.testIterate(macTree)
 			t.testContains(macTree)
+			t.testMarshalBinary(macTree)
+			t.testMarshalJSON(macTree)
 		}
 	}
 
@@ -237,6 +244,588 @@ func (t trieTesterGeneric) run() {
 		t.addFailure(newTrieFailure("unexpected size "+strconv.Itoa(trie.Size()), trie))
 	}
 	t.incrementTestCount()
+
+	t.testVersionedMarshal([]string{"1.2.3.4", "1.2.3.0/24", "10.0.0.0/8", "255.255.255.255", "0.0.0.0/0", "::1", "1::/64", "1:2::/32", "ffff::1"})
+	t.testVersionedMarshal([]string{"1.2.3.4", "::"})
+	t.testVersionedMarshal(nil)
+
+	t.testNodeDescendants([]string{"1.2.0.0/16", "1.2.3.0/24", "1.2.3.4", "1.2.128.0/17", "1.3.0.0/16", "1.2.3.5"})
+	t.testNodeDescendants([]string{"1::/64", "1::1", "1::2", "2::/16"})
+	t.testNodeDescendants([]string{"1.2.3.4"})
+	t.testNodeDescendants(nil)
+
+	t.testUnionWithIntersectWith(
+		[]string{"1.2.0.0/16", "1.2.3.4", "10.0.0.1"},
+		[]string{"1.2.3.4", "1.2.3.0/24", "10.0.0.1", "11.0.0.1"},
+		[]string{"1.2.0.0/16", "1.2.3.0/24", "1.2.3.4", "10.0.0.1", "11.0.0.1"},
+		[]string{"1.2.3.4", "10.0.0.1"})
+	t.testUnionWithIntersectWith([]string{"1::/64", "1::1"}, nil, []string{"1::/64", "1::1"}, nil)
+	t.testUnionWithIntersectWith(nil, nil, nil, nil)
+
+	t.testFilteredPrunedIterators([]string{"1.2.0.0/16", "1.2.3.0/24", "1.2.3.4", "1.2.128.0/17", "1.3.0.0/16", "1.2.3.5", "2.0.0.1"}, "1.2.3.0/24")
+	t.testFilteredPrunedIterators([]string{"1.2.0.0/16", "1.2.3.0/24", "1.2.3.4", "1.3.0.0/16"}, "1.2.0.0/16")
+	t.testFilteredPrunedIterators([]string{"1::/64", "1::1", "1::2", "2::/16"}, "1::/64")
+	t.testFilteredPrunedIterators([]string{"1.2.3.4"}, "1.2.3.4")
+	t.testFilteredPrunedIterators(nil, "1.2.3.4")
+
+	t.testWalk([]string{"1.2.0.0/16", "1.2.3.0/24", "1.2.3.4", "1.2.128.0/17", "1.3.0.0/16", "1.2.3.5"}, "1.2.3.0/24")
+	t.testWalk([]string{"1::/64", "1::1", "1::2", "2::/16"}, "1::/64")
+	t.testWalk([]string{"1.2.3.4"}, "1.2.3.4")
+	t.testWalk(nil, "1.2.3.4")
+
+	t.testIntersectContained(
+		[]string{"1.2.0.0/16", "1.2.3.4", "10.0.0.1"},
+		[]string{"1.2.3.4", "1.2.3.0/24", "10.0.0.1", "11.0.0.1"},
+		[]string{"1.2.3.0/24", "1.2.3.4", "10.0.0.1"})
+	t.testIntersectContained(
+		[]string{"1.2.0.0/16"},
+		[]string{"1.2.3.0/24", "1.2.0.0/16", "1.0.0.0/8", "5.6.7.8"},
+		[]string{"1.2.0.0/16", "1.2.3.0/24"})
+	t.testIntersectContained(
+		[]string{"0.0.0.0/0"},
+		[]string{"1.2.3.0/24", "5.6.7.8"},
+		[]string{"1.2.3.0/24", "5.6.7.8"})
+	t.testIntersectContained([]string{"1::/64", "2::1"}, []string{"1::1", "1:0:0:1::/64", "2::/16"}, []string{"1::1", "2::1"})
+	t.testIntersectContained([]string{"1.2.3.4"}, []string{"1.2.3.5"}, nil)
+	t.testIntersectContained([]string{"1.2.3.4"}, nil, nil)
+
+	t.testAssociativeJSON([]string{"1.2.0.0/16", "1.2.3.4", "5.0.0.0/8"})
+	t.testAssociativeJSON([]string{"1::/64", "1::1%eth0", "::"})
+	t.testAssociativeJSON(nil)
+	t.testInvalidTrieJSON(`["1.2.3.4","1.2.3.*"]`)
+	t.testInvalidTrieJSON(`["1.2.3.4","1.2.3.256"]`)
+	t.testInvalidTrieJSON(`["1.2.3.4",5]`)
+	t.testInvalidTrieJSON(`{"1.2.3.4":1}`)
+
+	t.testBulkLoad([]string{"1.2.3.4", "1.2.0.0/16", "0.0.0.0/0", "1.2.3.0/24", "255.255.255.255", "1.2.3.4", "10.0.0.0/8", "1.2.128.0/17", "0.0.0.0"})
+	t.testBulkLoad([]string{"1::1", "1::/64", "::/0", "ffff::", "1::", "1::/64", "2::/16", "::"})
+	t.testBulkLoad([]string{"1.2.3.4"})
+	t.testBulkLoad(nil)
+
+	lpmStrs := []string{"0.0.0.0/0", "1.2.0.0/16", "1.2.3.0/24", "1.2.3.4"}
+	lpmIPv6Strs := []string{"1::/64", "1::1"}
+	t.testLongestPrefixMatchWithValue(lpmStrs, "1.2.3.4", "1.2.3.4")
+	t.testLongestPrefixMatchWithValue(lpmStrs, "1.2.3.5", "1.2.3.0/24")
+	t.testLongestPrefixMatchWithValue(lpmStrs, "1.2.3.0/24", "1.2.3.0/24")
+	t.testLongestPrefixMatchWithValue(lpmStrs, "1.2.3.0/25", "1.2.3.0/24")
+	t.testLongestPrefixMatchWithValue(lpmStrs, "1.2.4.0/24", "1.2.0.0/16")
+	t.testLongestPrefixMatchWithValue(lpmStrs, "1.0.0.0/8", "0.0.0.0/0")
+	t.testLongestPrefixMatchWithValue(lpmStrs, "5.6.7.8", "0.0.0.0/0")
+	t.testLongestPrefixMatchWithValue(lpmIPv6Strs, "1::1", "1::1")
+	t.testLongestPrefixMatchWithValue(lpmIPv6Strs, "1::2", "1::/64")
+	t.testLongestPrefixMatchWithValue(lpmIPv6Strs, "1::/48", "")
+	t.testLongestPrefixMatchWithValue(lpmIPv6Strs, "2::1", "")
+	t.testLongestPrefixMatchWithValue(nil, "1.2.3.4", "")
+}
+
+func (t trieTesterGeneric) testMarshalBinary(trie *AddressTrie) {
+	bytes, err := trie.MarshalBinary()
+	if err != nil {
+		t.addFailure(newTrieFailure("unexpected marshal error "+err.Error(), trie))
+		return
+	}
+
+	res := &AddressTrie{}
+	if err = res.UnmarshalBinary(bytes); err != nil {
+		t.addFailure(newTrieFailure("unexpected unmarshal error "+err.Error(), trie))
+	} else if trie.Size() > 0 && res.String() != trie.String() {
+		t.addFailure(newTrieFailure("binary round trip produced a different trie "+res.String(), trie))
+	} else if res.Size() != trie.Size() {
+		t.addFailure(newTrieFailure("binary round trip produced a trie of size "+strconv.Itoa(res.Size()), trie))
+	} else if resBytes, _ := res.MarshalBinary(); trie.Size() > 0 && string(resBytes) != string(bytes) {
+		t.addFailure(newTrieFailure("binary round trip produced different bytes", trie))
+	}
+
+	// each trailing byte holds part of the last node
+	if len(bytes) > 2 {
+		if err = (&AddressTrie{}).UnmarshalBinary(bytes[:len(bytes)-1]); err == nil {
+			t.addFailure(newTrieFailure("expected error unmarshalling truncated data", trie))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testVersionedMarshal tests the binary round trip of the IPv4 and IPv6 tries holding the given addresses,
+// along with the errors for data of the other version and for an unsupported format version
+func (t trieTesterGeneric) testVersionedMarshal(strs []string) {
+	ipv4Trie, ipv6Trie := &goip.IPv4AddressTrie{}, &goip.IPv6AddressTrie{}
+	for _, str := range strs {
+		addr := t.createAddress(str).GetAddress()
+		if addr.IsIPv4() {
+			ipv4Trie.Add(addr.ToIPv4())
+		} else {
+			ipv6Trie.Add(addr.ToIPv6())
+		}
+	}
+
+	ipv4Bytes, err := ipv4Trie.Marshal()
+	if err != nil {
+		t.addFailure(newAddressItemFailure("unexpected marshal error "+err.Error(), nil))
+	} else if res, err := goip.UnmarshalIPv4AddressTrie(ipv4Bytes); err != nil {
+		t.addFailure(newAddressItemFailure("unexpected unmarshal error "+err.Error()+" for "+ipv4Trie.String(), nil))
+	} else if res.String() != ipv4Trie.String() || res.Size() != ipv4Trie.Size() {
+		t.addFailure(newAddressItemFailure("IPv4 round trip produced "+res.String()+" instead of "+ipv4Trie.String(), nil))
+	} else if ipv4Trie.Size() > 0 {
+		if _, err = goip.UnmarshalIPv6AddressTrie(ipv4Bytes); err == nil {
+			t.addFailure(newAddressItemFailure("expected error unmarshalling IPv4 trie data as IPv6", nil))
+		}
+	}
+
+	ipv6Bytes, err := ipv6Trie.Marshal()
+	if err != nil {
+		t.addFailure(newAddressItemFailure("unexpected marshal error "+err.Error(), nil))
+	} else if res, err := goip.UnmarshalIPv6AddressTrie(ipv6Bytes); err != nil {
+		t.addFailure(newAddressItemFailure("unexpected unmarshal error "+err.Error()+" for "+ipv6Trie.String(), nil))
+	} else if res.String() != ipv6Trie.String() || res.Size() != ipv6Trie.Size() {
+		t.addFailure(newAddressItemFailure("IPv6 round trip produced "+res.String()+" instead of "+ipv6Trie.String(), nil))
+	} else if ipv6Trie.Size() > 0 {
+		if _, err = goip.UnmarshalIPv4AddressTrie(ipv6Bytes); err == nil {
+			t.addFailure(newAddressItemFailure("expected error unmarshalling IPv6 trie data as IPv4", nil))
+		}
+	}
+
+	// the first byte is the format version
+	unsupported := append([]byte{0xff}, ipv4Bytes[1:]...)
+	if _, err = goip.UnmarshalIPv4AddressTrie(unsupported); err == nil {
+		t.addFailure(newAddressItemFailure("expected error for unsupported format version", nil))
+	}
+	if _, err = goip.UnmarshalIPv4AddressTrie(nil); err == nil {
+		t.addFailure(newAddressItemFailure("expected error for no data", nil))
+	}
+	t.incrementTestCount()
+}
+
+// testNodeDescendants checks the children and descendants of every node of the trie holding the given addresses
+func (t trieTesterGeneric) testNodeDescendants(strs []string) {
+	trie := &AddressTrie{}
+	for _, str := range strs {
+		trie.Add(t.createAddress(str).GetAddress().ToAddressBase())
+	}
+
+	for nodeIter := trie.AllNodeIterator(true); nodeIter.HasNext(); {
+		node := nodeIter.Next()
+		var expectedChildren []*AddressTrieNode
+		if lower := node.GetLowerSubNode(); lower != nil {
+			expectedChildren = append(expectedChildren, lower)
+		}
+		if upper := node.GetUpperSubNode(); upper != nil {
+			expectedChildren = append(expectedChildren, upper)
+		}
+		if !reflect.DeepEqual(node.GetAllChildren(), expectedChildren) {
+			t.addFailure(newTrieFailure("children mismatch for node "+node.String(), trie))
+		}
+
+		key := node.GetKey()
+		var expected []*goip.Address
+		for iter := trie.Iterator(); iter.HasNext(); {
+			if addr := iter.Next(); key.Contains(addr) && !addr.Equal(key) {
+				expected = append(expected, addr)
+			}
+		}
+		descendants := node.GetAllDescendants()
+		if len(descendants) != len(expected) || node.GetDescendantCount() != len(expected) {
+			t.addFailure(newTrieFailure("descendant count "+strconv.Itoa(len(descendants))+" for node "+node.String()+" does not match expected "+strconv.Itoa(len(expected)), trie))
+		} else {
+			for i, descendant := range descendants {
+				if !descendant.Equal(expected[i]) {
+					t.addFailure(newTrieFailure("descendant "+descendant.String()+" for node "+node.String()+" does not match expected "+expected[i].String(), trie))
+					break
+				}
+			}
+		}
+	}
+
+	var nilNode *AddressTrieNode
+	if nilNode.GetAllDescendants() != nil || nilNode.GetDescendantCount() != 0 {
+		t.addFailure(newTrieFailure("expected no descendants for the nil node", trie))
+	}
+	t.incrementTestCount()
+}
+
+// testUnionWithIntersectWith checks the union and exact-match intersection of the tries of the given addresses,
+// as well as those of the associative tries in which each address is mapped to its index plus one, or to its index plus 100 for the second trie
+func (t trieTesterGeneric) testUnionWithIntersectWith(oneStrs, twoStrs, expectedUnion, expectedIntersection []string) {
+	one, two := &AddressTrie{}, &AddressTrie{}
+	oneAssoc, twoAssoc := &goip.AssociativeTrie[*goip.Address, int]{}, &goip.AssociativeTrie[*goip.Address, int]{}
+	for i, str := range oneStrs {
+		addr := t.createAddress(str).GetAddress().ToAddressBase()
+		one.Add(addr)
+		oneAssoc.Put(addr, i+1)
+	}
+	for i, str := range twoStrs {
+		addr := t.createAddress(str).GetAddress().ToAddressBase()
+		two.Add(addr)
+		twoAssoc.Put(addr, i+100)
+	}
+	oneStr, twoStr := one.String(), two.String()
+
+	checkElements := func(op string, res *AddressTrie, expected []string) {
+		if res.Size() != len(expected) {
+			t.addFailure(newTrieFailure(op+" has "+strconv.Itoa(res.Size())+" elements, expected "+strconv.Itoa(len(expected)), res))
+			return
+		}
+		for _, str := range expected {
+			if !res.Contains(t.createAddress(str).GetAddress().ToAddressBase()) {
+				t.addFailure(newTrieFailure(op+" is missing expected element "+str, res))
+				return
+			}
+		}
+	}
+	checkElements("union", one.UnionWith(two), expectedUnion)
+	checkElements("union", two.UnionWith(one), expectedUnion)
+	checkElements("intersection", one.IntersectWith(two), expectedIntersection)
+	checkElements("intersection", two.IntersectWith(one), expectedIntersection)
+	if one.String() != oneStr || two.String() != twoStr {
+		t.addFailure(newTrieFailure("union or intersection modified the original trie", one))
+	}
+
+	union := oneAssoc.UnionWith(twoAssoc)
+	if union.Size() != len(expectedUnion) {
+		t.addFailure(newAssocTrieFailure("associative union has size "+strconv.Itoa(union.Size()), nil))
+	}
+	sums := oneAssoc.UnionWithFunc(twoAssoc, func(value, otherValue int) int { return value + otherValue })
+	for iter := union.NodeIterator(true); iter.HasNext(); {
+		node := iter.Next()
+		key := node.GetKey()
+		oneValue, inOne := oneAssoc.Get(key)
+		twoValue, inTwo := twoAssoc.Get(key)
+		expectedValue, expectedSum := oneValue, oneValue
+		if inTwo {
+			expectedValue, expectedSum = twoValue, twoValue
+			if inOne {
+				expectedSum = oneValue + twoValue
+			}
+		}
+		if node.GetValue() != expectedValue {
+			t.addFailure(newAssocTrieFailure("associative union value for "+key.String()+" is "+strconv.Itoa(node.GetValue()), nil))
+		} else if sum, _ := sums.Get(key); sum != expectedSum {
+			t.addFailure(newAssocTrieFailure("merged union value for "+key.String()+" is "+strconv.Itoa(sum), nil))
+		}
+	}
+
+	intersection := oneAssoc.IntersectWith(twoAssoc)
+	if intersection.Size() != len(expectedIntersection) {
+		t.addFailure(newAssocTrieFailure("associative intersection has size "+strconv.Itoa(intersection.Size()), nil))
+	}
+	for iter := intersection.NodeIterator(true); iter.HasNext(); {
+		node := iter.Next()
+		if twoValue, _ := twoAssoc.Get(node.GetKey()); node.GetValue() != twoValue {
+			t.addFailure(newAssocTrieFailure("associative intersection value for "+node.GetKey().String()+" is "+strconv.Itoa(node.GetValue()), nil))
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testFilteredPrunedIterators checks the filtered and pruned iterators with a predicate rejecting the given excluded address.
+// The filtered iterator skips only the excluded node, while the pruned iterator also skips the elements it contains.
+func (t trieTesterGeneric) testFilteredPrunedIterators(strs []string, excludedStr string) {
+	trie := &AddressTrie{}
+	for _, str := range strs {
+		trie.Add(t.createAddress(str).GetAddress().ToAddressBase())
+	}
+	excluded := t.createAddress(excludedStr).GetAddress().ToAddressBase()
+	predicate := func(node *AddressTrieNode) bool {
+		return !node.GetKey().Equal(excluded)
+	}
+
+	checkNodes := func(op string, iter, expectedIter goip.Iterator[*AddressTrieNode], include func(*goip.Address) bool) {
+		for expectedIter.HasNext() {
+			expected := expectedIter.Next()
+			if !include(expected.GetKey()) {
+				continue
+			}
+			if !iter.HasNext() {
+				t.addFailure(newTrieFailure(op+" iterator is missing "+expected.String(), trie))
+				return
+			} else if node := iter.Next(); node != expected {
+				t.addFailure(newTrieFailure(op+" iterator node "+node.String()+" does not match expected "+expected.String(), trie))
+				return
+			}
+		}
+		if iter.HasNext() {
+			t.addFailure(newTrieFailure(op+" iterator has extra node "+iter.Next().String(), trie))
+		}
+	}
+	checkNodes("filtered", trie.FilteredIterator(predicate), trie.NodeIterator(true), func(key *goip.Address) bool {
+		return !key.Equal(excluded)
+	})
+	checkNodes("pruned", trie.PrunedIterator(predicate), trie.ContainingFirstIterator(true), func(key *goip.Address) bool {
+		return !excluded.Contains(key)
+	})
+	t.incrementTestCount()
+}
+
+// testWalk checks that walking visits the same nodes as the containing-first iterator of all nodes,
+// and that the sub-trie of the node with the given key is not visited when the visitor returns false for it
+func (t trieTesterGeneric) testWalk(strs []string, prunedStr string) {
+	trie := &AddressTrie{}
+	assocTrie := &goip.AssociativeTrie[*goip.Address, int]{}
+	for i, str := range strs {
+		addr := t.createAddress(str).GetAddress().ToAddressBase()
+		trie.Add(addr)
+		assocTrie.Put(addr, i)
+	}
+	pruned := t.createAddress(prunedStr).GetAddress().ToAddressBase()
+
+	var expected, expectedPruned []*AddressTrieNode
+	for iter := trie.ContainingFirstAllNodeIterator(true); iter.HasNext(); {
+		node := iter.Next()
+		expected = append(expected, node)
+		if key := node.GetKey(); key.Equal(pruned) || !pruned.Contains(key) {
+			expectedPruned = append(expectedPruned, node)
+		}
+	}
+
+	var visited []*AddressTrieNode
+	trie.Walk(func(node *AddressTrieNode, depth int, isLeaf bool) bool {
+		expectedDepth := 0
+		for parent := node.GetParent(); parent != nil; parent = parent.GetParent() {
+			expectedDepth++
+		}
+		if depth != expectedDepth {
+			t.addFailure(newTrieFailure("depth "+strconv.Itoa(depth)+" of "+node.String()+" does not match expected "+strconv.Itoa(expectedDepth), trie))
+		} else if isLeaf != (node.GetLowerSubNode() == nil && node.GetUpperSubNode() == nil) {
+			t.addFailure(newTrieFailure("leaf mismatch for "+node.String(), trie))
+		}
+		visited = append(visited, node)
+		return true
+	})
+	var visitedPruned []*AddressTrieNode
+	trie.Walk(func(node *AddressTrieNode, depth int, isLeaf bool) bool {
+		visitedPruned = append(visitedPruned, node)
+		return !node.GetKey().Equal(pruned)
+	})
+	var assocKeys []*goip.Address
+	assocTrie.Walk(func(node *goip.AssociativeTrieNode[*goip.Address, int], depth int, isLeaf bool) bool {
+		assocKeys = append(assocKeys, node.GetKey())
+		return true
+	})
+
+	if !reflect.DeepEqual(visited, expected) {
+		t.addFailure(newTrieFailure(fmt.Sprint("walk visited ", visited, ", expected ", expected), trie))
+	} else if !reflect.DeepEqual(visitedPruned, expectedPruned) {
+		t.addFailure(newTrieFailure(fmt.Sprint("pruned walk visited ", visitedPruned, ", expected ", expectedPruned), trie))
+	} else if len(assocKeys) != len(expected) {
+		t.addFailure(newTrieFailure(fmt.Sprint("associative walk visited ", assocKeys), trie))
+	} else {
+		for i, key := range assocKeys {
+			if !key.Equal(expected[i].GetKey()) {
+				t.addFailure(newTrieFailure("associative walk visited "+key.String()+", expected "+expected[i].GetKey().String(), trie))
+				break
+			}
+		}
+	}
+	t.incrementTestCount()
+}
+
+// testIntersectContained checks the intersection of two tries includes the elements of each trie contained by the elements of the other,
+// and that the merged values come from the longest prefix match in each trie
+func (t trieTesterGeneric) testIntersectContained(oneStrs, twoStrs, expected []string) {
+	one, two := &AddressTrie{}, &AddressTrie{}
+	oneAssoc, twoAssoc := &goip.AssociativeTrie[*goip.Address, int]{}, &goip.AssociativeTrie[*goip.Address, int]{}
+	for i, str := range oneStrs {
+		addr := t.createAddress(str).GetAddress().ToAddressBase()
+		one.Add(addr)
+		oneAssoc.Put(addr, i+1)
+	}
+	for i, str := range twoStrs {
+		addr := t.createAddress(str).GetAddress().ToAddressBase()
+		two.Add(addr)
+		twoAssoc.Put(addr, i+100)
+	}
+	oneStr, twoStr := one.String(), two.String()
+
+	checkElements := func(res *AddressTrie) {
+		if res.Size() != len(expected) {
+			t.addFailure(newTrieFailure("intersection has "+strconv.Itoa(res.Size())+" elements, expected "+strconv.Itoa(len(expected)), res))
+			return
+		}
+		for _, str := range expected {
+			if !res.Contains(t.createAddress(str).GetAddress().ToAddressBase()) {
+				t.addFailure(newTrieFailure("intersection is missing expected element "+str, res))
+				return
+			}
+		}
+	}
+	checkElements(one.IntersectContained(two))
+	checkElements(two.IntersectContained(one))
+	if one.String() != oneStr || two.String() != twoStr {
+		t.addFailure(newTrieFailure("intersection modified the original trie", one))
+	}
+
+	merged := oneAssoc.IntersectContainedFunc(twoAssoc, func(value, otherValue int) int { return value*1000 + otherValue })
+	if merged.Size() != len(expected) {
+		t.addFailure(newAssocTrieFailure("associative intersection has size "+strconv.Itoa(merged.Size()), nil))
+	}
+	for iter := merged.NodeIterator(true); iter.HasNext(); {
+		node := iter.Next()
+		key := node.GetKey()
+		_, oneValue, _ := oneAssoc.LongestPrefixMatchWithValue(key)
+		_, twoValue, _ := twoAssoc.LongestPrefixMatchWithValue(key)
+		if expectedValue := oneValue*1000 + twoValue; node.GetValue() != expectedValue {
+			t.addFailure(newAssocTrieFailure("merged intersection value for "+key.String()+" is "+strconv.Itoa(node.GetValue())+", expected "+strconv.Itoa(expectedValue), nil))
+		}
+	}
+	t.incrementTestCount()
+}
+
+func (t trieTesterGeneric) testMarshalJSON(trie *AddressTrie) {
+	data, err := json.Marshal(trie)
+	if err != nil {
+		t.addFailure(newTrieFailure("unexpected JSON marshal error "+err.Error(), trie))
+		return
+	}
+
+	res := &AddressTrie{}
+	if err = json.Unmarshal(data, res); err != nil {
+		t.addFailure(newTrieFailure("unexpected JSON unmarshal error "+err.Error()+" for "+string(data), trie))
+	} else if res.Size() != trie.Size() || (trie.Size() > 0 && res.String() != trie.String()) {
+		t.addFailure(newTrieFailure("JSON round trip produced a different trie "+res.String(), trie))
+	} else if resData, _ := json.Marshal(res); string(resData) != string(data) {
+		t.addFailure(newTrieFailure("JSON round trip produced "+string(resData)+" instead of "+string(data), trie))
+	} else if trie.Size() == 0 && string(data) != "[]" {
+		t.addFailure(newTrieFailure("empty trie produced JSON "+string(data), trie))
+	}
+	t.incrementTestCount()
+}
+
+// testAssociativeJSON checks the JSON round trip of an associative trie mapping the given addresses to their indices,
+// along with the JSON of the nil trie and of JSON null
+func (t trieTesterGeneric) testAssociativeJSON(strs []string) {
+	trie := &goip.AssociativeTrie[*goip.Address, int]{}
+	for i, str := range strs {
+		trie.Put(t.createAddress(str).GetAddress().ToAddressBase(), i)
+	}
+
+	data, err := json.Marshal(trie)
+	if err != nil {
+		t.addFailure(newAssocTrieFailure("unexpected JSON marshal error "+err.Error(), nil))
+		return
+	}
+	res := &goip.AssociativeTrie[*goip.Address, int]{}
+	if err = json.Unmarshal(data, res); err != nil {
+		t.addFailure(newAssocTrieFailure("unexpected JSON unmarshal error "+err.Error()+" for "+string(data), nil))
+	} else if res.Size() != trie.Size() {
+		t.addFailure(newAssocTrieFailure("JSON round trip of "+string(data)+" produced a trie of size "+strconv.Itoa(res.Size()), nil))
+	} else {
+		for i, str := range strs {
+			if value, found := res.Get(t.createAddress(str).GetAddress().ToAddressBase()); !found || value != i {
+				t.addFailure(newAssocTrieFailure("JSON round trip of "+string(data)+" lost the value of "+str, nil))
+			}
+		}
+	}
+
+	if data, err = (*goip.AssociativeTrie[*goip.Address, int])(nil).MarshalJSON(); err != nil || string(data) != "[]" {
+		t.addFailure(newAssocTrieFailure("nil trie produced JSON "+string(data), nil))
+	} else if err = json.Unmarshal([]byte("null"), res); err != nil || res.Size() != 0 {
+		t.addFailure(newAssocTrieFailure("JSON null did not produce an empty trie", nil))
+	}
+	t.incrementTestCount()
+}
+
+// testInvalidTrieJSON checks that unmarshalling the given JSON fails, leaving the trie unchanged
+func (t trieTesterGeneric) testInvalidTrieJSON(str string) {
+	trie := &goip.IPv4AddressTrie{}
+	trie.Add(t.createAddress("9.9.9.9").GetAddress().ToIPv4())
+	original := trie.String()
+	if err := json.Unmarshal([]byte(str), trie); err == nil {
+		t.addFailure(newAddressItemFailure("expected error unmarshalling trie JSON "+str, nil))
+	} else if trie.String() != original {
+		t.addFailure(newAddressItemFailure("failed unmarshalling of "+str+" changed the trie to "+trie.String(), nil))
+	}
+	ipv6Trie := &goip.IPv6AddressTrie{}
+	if err := json.Unmarshal([]byte(`["1.2.3.4"]`), ipv6Trie); err == nil {
+		t.addFailure(newAddressItemFailure("expected error unmarshalling IPv4 JSON into an IPv6 trie", nil))
+	}
+	t.incrementTestCount()
+}
+
+// testBulkLoad checks that the tries constructed from a slice or map of the given addresses match the tries constructed by adding each address,
+// including duplicate addresses, and that the given slice is not modified
+func (t trieTesterGeneric) testBulkLoad(strs []string) {
+	var ipv4Addrs []*goip.IPv4Address
+	var ipv6Addrs []*goip.IPv6Address
+	ipv4Trie, ipv6Trie := &goip.IPv4AddressTrie{}, &goip.IPv6AddressTrie{}
+	ipv4Map, ipv6Map := map[*goip.IPv4Address]int{}, map[*goip.IPv6Address]int{}
+	for i, str := range strs {
+		addr := t.createAddress(str).GetAddress()
+		if addr.IsIPv4() {
+			ipv4Addrs = append(ipv4Addrs, addr.ToIPv4())
+			ipv4Trie.Add(addr.ToIPv4())
+			ipv4Map[addr.ToIPv4()] = i
+		} else {
+			ipv6Addrs = append(ipv6Addrs, addr.ToIPv6())
+			ipv6Trie.Add(addr.ToIPv6())
+			ipv6Map[addr.ToIPv6()] = i
+		}
+	}
+	ipv4Order := fmt.Sprint(ipv4Addrs)
+
+	if bulk := goip.NewIPv4AddressTrieFromSlice(ipv4Addrs); bulk.String() != ipv4Trie.String() || bulk.Size() != ipv4Trie.Size() {
+		t.addFailure(newAddressItemFailure("trie from slice "+bulk.String()+" does not match "+ipv4Trie.String(), nil))
+	} else if fmt.Sprint(ipv4Addrs) != ipv4Order {
+		t.addFailure(newAddressItemFailure("constructing the trie from the slice reordered the slice to "+fmt.Sprint(ipv4Addrs), nil))
+	}
+	if bulk := goip.NewIPv6AddressTrieFromSlice(ipv6Addrs); bulk.String() != ipv6Trie.String() || bulk.Size() != ipv6Trie.Size() {
+		t.addFailure(newAddressItemFailure("trie from slice "+bulk.String()+" does not match "+ipv6Trie.String(), nil))
+	}
+
+	ipv4Assoc := goip.NewIPv4AddressAssociativeTrieFromMap(ipv4Map)
+	if ipv4Assoc.Size() != ipv4Trie.Size() {
+		t.addFailure(newAssocTrieFailure("trie from map has size "+strconv.Itoa(ipv4Assoc.Size())+", expected "+strconv.Itoa(ipv4Trie.Size()), nil))
+	}
+	occurrences := map[string]int{}
+	for addr := range ipv4Map {
+		occurrences[addr.String()]++
+	}
+	for addr, value := range ipv4Map {
+		// the map may have distinct keys for the same address, in which case any of their values may be kept
+		if got, found := ipv4Assoc.Get(addr); !found {
+			t.addFailure(newAssocTrieFailure("trie from map is missing "+addr.String(), nil))
+		} else if got != value && occurrences[addr.String()] == 1 {
+			t.addFailure(newAssocTrieFailure("trie from map has value "+strconv.Itoa(got)+" for "+addr.String()+", expected "+strconv.Itoa(value), nil))
+		}
+	}
+	if ipv6Assoc := goip.NewIPv6AddressAssociativeTrieFromMap(ipv6Map); ipv6Assoc.Size() != ipv6Trie.Size() {
+		t.addFailure(newAssocTrieFailure("trie from map has size "+strconv.Itoa(ipv6Assoc.Size())+", expected "+strconv.Itoa(ipv6Trie.Size()), nil))
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.addFailure(newAddressItemFailure("expected panic constructing a trie from a subnet that is not a prefix block", nil))
+			}
+		}()
+		goip.NewIPv4AddressTrieFromSlice(append(ipv4Addrs[:len(ipv4Addrs):len(ipv4Addrs)], t.createAddress("1.2.3-4.5").GetAddress().ToIPv4()))
+	}()
+	t.incrementTestCount()
+}
+
+// testLongestPrefixMatchWithValue checks the longest prefix match of the given address in an associative trie of the given addresses,
+// each mapped to its index, matches the expected element and its value, with an empty expected string meaning no match
+func (t trieTesterGeneric) testLongestPrefixMatchWithValue(strs []string, str, expected string) {
+	trie := &goip.AssociativeTrie[*goip.Address, int]{}
+	values := map[string]int{}
+	for i, str := range strs {
+		addr := t.createAddress(str).GetAddress().ToAddressBase()
+		trie.Put(addr, i)
+		values[addr.String()] = i
+	}
+
+	addr := t.createAddress(str).GetAddress().ToAddressBase()
+	match, value, found := trie.LongestPrefixMatchWithValue(addr)
+	if expected == "" {
+		if found || match != nil || value != 0 {
+			t.addFailure(newAssocTrieFailure(fmt.Sprint("unexpected longest prefix match ", match, " with value ", value, " for ", addr), nil))
+		}
+	} else if expectedAddr := t.createAddress(expected).GetAddress().ToAddressBase(); !found || !match.Equal(expectedAddr) {
+		t.addFailure(newAssocTrieFailure(fmt.Sprint("longest prefix match ", match, " for ", addr, " does not match expected ", expected), nil))
+	} else if value != values[expectedAddr.String()] {
+		t.addFailure(newAssocTrieFailure(fmt.Sprint("longest prefix match ", match, " for ", addr, " has value ", value, ", expected ", values[expectedAddr.String()]), nil))
+	} else if lpm := trie.LongestPrefixMatch(addr); !lpm.Equal(match) {
+		t.addFailure(newAssocTrieFailure(fmt.Sprint("longest prefix match ", match, " with value does not match longest prefix match ", lpm), nil))
+	}
+	t.incrementTestCount()
 }
 
 func (t trieTesterGeneric) testString(strs trieStrings) {
@@ -421,6 +421,51 @@ func (trie *BinTrie[E, V]) AddNode(key E) *BinTrieNode[E, V] {
 	return node
 }
 
+// AddSorted adds the given keys to the trie, returning the number of keys that were not there already.
+//
+// The keys must be in the order of a pre-order traversal of the trie with lower sub-nodes first,
+// in which each key follows the keys that contain it, and otherwise the keys are in ascending order.
+// Each key is added starting from the position of the previously added key, rather than from the root,
+// which is faster than adding the keys individually with Add.
+func (trie *BinTrie[E, V]) AddSorted(keys []E) int {
+	return trie.addSorted(keys, nil, false)
+}
+
+// PutSorted is similar to AddSorted, but associates each of the given keys with the value at the same index in the given values.
+// When a key is already in the trie, its value is replaced.
+func (trie *BinTrie[E, V]) PutSorted(keys []E, values []V) int {
+	return trie.addSorted(keys, values, true)
+}
+
+func (trie *BinTrie[E, V]) addSorted(keys []E, values []V, withValues bool) (count int) {
+	if len(keys) == 0 {
+		return
+	}
+
+	root := trie.ensureRoot(keys[0])
+	node := root
+	for i, key := range keys {
+		// move up to the nearest node containing the key, which is the root at the very least
+		for node != root && !keyContains(node.GetKey(), key) {
+			node = node.GetParent()
+		}
+
+		result := &opResult[E, V]{
+			key: key,
+			op:  insert,
+		}
+		if withValues {
+			result.newValue = values[i]
+			// new value assignment
+		}
+		node = trie.addNode(result, node)
+		if !result.exists {
+			count++
+		}
+	}
+	return
+}
+
 func (trie *BinTrie[E, V]) addNode(result *opResult[E, V], fromNode *BinTrieNode[E, V]) *BinTrieNode[E, V] {
 	fromNode.matchBitsFromIndex(fromNode.GetKey().GetPrefixLen().Len(), result)
 	node := result.existingNode
@@ -1319,6 +1319,38 @@ func (node *BinTrieNode[E, V]) CeilingAddedNode(key E) *BinTrieNode[E, V] {
 	return node.findNodeNear(key, false, false)
 }
 
+// containmentCompare records the result of matching a key against a key that might contain it.
+type containmentCompare struct {
+	matched, matchedPartially bool
+}
+
+func (comp *containmentCompare) MismatchCallbackRequired() bool {
+	return false
+}
+
+func (comp *containmentCompare) BitsMatchPartially() bool {
+	comp.matchedPartially = true
+	return false
+}
+
+func (comp *containmentCompare) BitsDoNotMatch(BitCount) {}
+
+func (comp *containmentCompare) BitsMatch() {
+	comp.matched = true
+}
+
+// keyContains returns whether the existing key contains the new key.
+func keyContains[E TrieKey[E]](existingKey, newKey E) bool {
+	comp := containmentCompare{}
+	newKey.MatchBits(existingKey, 0, true, &comp, newKey.GetTrieKeyData())
+	if comp.matchedPartially {
+		return true
+	}
+	// a full match means the new key contains the existing key, so the existing key contains the new key only if they are the same
+	existingPrefLen, newPrefLen := existingKey.GetPrefixLen(), newKey.GetPrefixLen()
+	return comp.matched && (existingPrefLen == nil) == (newPrefLen == nil) && existingPrefLen.Len() == newPrefLen.Len()
+}
+
 type nodeCompare[E TrieKey[E], V any] struct {
 	result *opResult[E, V]
 	node   *BinTrieNode[E, V]